@@ -7,6 +7,7 @@ import (
 	"github.com/joho/godotenv"
 	"golang.org/x/crypto/bcrypt"
 	"log"
+	"time"
 )
 
 func main() {
@@ -46,13 +47,16 @@ func createSuperAdmin() {
 		log.Fatal("Failed to hash password:", err)
 	}
 
-	// Create super admin user
+	// Create super admin user (the seeded address counts as verified). The role's mfa_required stays
+	// off: MFA is API-only, the web client cannot complete the second login step yet.
+	verifiedAt := time.Now()
 	superAdmin := models.User{
-		Name:     "System Administrator",
-		Email:    "admin@system.com",
-		Password: string(hashedPassword),
-		Position: "System Administrator",
-		RoleID:   superAdminRole.ID,
+		Name:            "System Administrator",
+		Email:           "admin@system.com",
+		Password:        string(hashedPassword),
+		Position:        "System Administrator",
+		RoleID:          superAdminRole.ID,
+		EmailVerifiedAt: &verifiedAt,
 	}
 
 	if err := database.GetDB().Create(&superAdmin).Error; err != nil {
@@ -2,8 +2,11 @@ package main
 
 import (
 	"dev-bridge-manager/internal/database"
+	"dev-bridge-manager/internal/jobs"
+	"dev-bridge-manager/internal/services"
 	"log"
 	"os"
+	"strings"
 
 	"dev-bridge-manager/internal/middleware"
 	"dev-bridge-manager/internal/routes"
@@ -33,12 +36,32 @@ func main() {
 	// Connect to database with GORM
 	database.Connect()
 
+	// Az MFA-t használó userek titkai MFA_ENCRYPTION_KEY nélkül nem olvashatók
+	if err := services.NewMFAService().CheckConfiguration(); err != nil {
+		log.Fatalf("❌ MFA configuration error: %v", err)
+	}
+
+	// Start background jobs
+	jobs.Start()
+
+	trustedProxies := splitList(os.Getenv("TRUSTED_PROXIES"))
+
 	// Create Fiber app with config
 	app := fiber.New(fiber.Config{
 		AppName:      "Dev Bridge Manager v1.0",
 		ErrorHandler: errorHandler,
+		// A kliens IP (login zárolás, session lista) a PROXY_HEADER fejlécből jön, de csak a
+		// TRUSTED_PROXIES címeiről érkező kéréseknél; üres lista esetén mindig a TCP kapcsolat címe
+		EnableTrustedProxyCheck: true,
+		TrustedProxies:          trustedProxies,
+		ProxyHeader:             proxyHeader(trustedProxies),
+		EnableIPValidation:      true,
 	})
 
+	// Csak a feltöltés route kap nagyobb body limitet (+1 MB a multipart keretnek),
+	// a többi kérésre az alapértelmezett 4 MB érvényes
+	app.Server().HeaderReceived = routes.UploadBodyLimit(int(services.MaxAttachmentSize()) + 1<<20)
+
 	// Global middleware
 	app.Use(logger.New())
 	app.Use(middleware.CORS())
@@ -72,6 +95,25 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+// proxyHeader returns the header carrying the client IP; it is only read behind trusted proxies
+func proxyHeader(trustedProxies []string) string {
+	if len(trustedProxies) == 0 {
+		return ""
+	}
+	return getEnv("PROXY_HEADER", "X-Real-IP")
+}
+
+// splitList splits a comma separated environment value, dropping empty items
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 func errorHandler(c *fiber.Ctx, err error) error {
 	code := fiber.StatusInternalServerError
 	if e, ok := err.(*fiber.Error); ok {
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// clientIP returns the IP the app sees for a request with the given X-Real-IP header
+func clientIP(t *testing.T, trustedProxies []string, header string) string {
+	t.Helper()
+	app := fiber.New(fiber.Config{
+		EnableTrustedProxyCheck: true,
+		TrustedProxies:          trustedProxies,
+		ProxyHeader:             proxyHeader(trustedProxies),
+		EnableIPValidation:      true,
+	})
+	app.Get("/", func(c *fiber.Ctx) error { return c.SendString(c.IP()) })
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("X-Real-IP", header)
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	return string(body)
+}
+
+func TestClientIPBehindProxy(t *testing.T) {
+	// Az app.Test kérései a 0.0.0.0 címről érkeznek
+	if ip := clientIP(t, nil, "203.0.113.7"); ip != "0.0.0.0" {
+		t.Errorf("without trusted proxies the header was used: %s", ip)
+	}
+	if ip := clientIP(t, []string{"10.0.0.0/8"}, "203.0.113.7"); ip != "0.0.0.0" {
+		t.Errorf("header of an untrusted peer was used: %s", ip)
+	}
+	if ip := clientIP(t, []string{"0.0.0.0"}, "203.0.113.7"); ip != "203.0.113.7" {
+		t.Errorf("header of a trusted proxy was ignored: %s", ip)
+	}
+}
+
+func TestSplitList(t *testing.T) {
+	got := splitList(" 10.0.0.1, ,172.16.0.0/12,")
+	if len(got) != 2 || got[0] != "10.0.0.1" || got[1] != "172.16.0.0/12" {
+		t.Errorf("splitList = %q", got)
+	}
+	if got := splitList(""); got != nil {
+		t.Errorf("splitList(\"\") = %q, want nil", got)
+	}
+}
@@ -179,6 +179,52 @@ func shouldSkipMigration(db *sql.DB, filename string, version int) (bool, error)
 		return columnExists(db, "users", "role_id")
 	case strings.Contains(base, "create_projects_table"):
 		return tableExists(db, "projects")
+	case strings.Contains(base, "create_tasks_table"):
+		return tableExists(db, "tasks")
+	case strings.Contains(base, "create_kanban_boards_table"):
+		return tableExists(db, "kanban_boards")
+	case strings.Contains(base, "add_task_archiving"):
+		return columnExists(db, "tasks", "archived_at")
+	case strings.Contains(base, "create_time_entries_table"):
+		return tableExists(db, "time_entries")
+	case strings.Contains(base, "create_timers_table"):
+		return tableExists(db, "timers")
+	case strings.Contains(base, "add_time_entry_billable"):
+		return columnExists(db, "time_entries", "billable")
+	case strings.Contains(base, "create_task_comments_table"):
+		return tableExists(db, "task_comments")
+	case strings.Contains(base, "create_task_attachments_table"):
+		return tableExists(db, "task_attachments")
+	case strings.Contains(base, "create_tags_table"):
+		return tableExists(db, "task_tags")
+	case strings.Contains(base, "add_subtasks_and_checklists"):
+		return tableExists(db, "task_checklist_items")
+	case strings.Contains(base, "add_kanban_column_category"):
+		return columnExists(db, "kanban_columns", "category")
+	case strings.Contains(base, "create_task_links_table"):
+		return tableExists(db, "task_links")
+	case strings.Contains(base, "create_sprints_table"):
+		return tableExists(db, "sprint_scope_changes")
+	case strings.Contains(base, "create_progress_snapshots"):
+		return tableExists(db, "project_snapshots")
+	case strings.Contains(base, "create_task_status_history"):
+		return tableExists(db, "task_status_history")
+	case strings.Contains(base, "create_workflow_transitions"):
+		return tableExists(db, "workflow_transitions")
+	case strings.Contains(base, "create_recurring_task_templates"):
+		return tableExists(db, "recurring_task_runs")
+	case strings.Contains(base, "create_notifications"):
+		return tableExists(db, "task_reminders")
+	case strings.Contains(base, "create_sessions"):
+		return tableExists(db, "session_refresh_tokens")
+	case strings.Contains(base, "add_user_token_version"):
+		return columnExists(db, "users", "token_version")
+	case strings.Contains(base, "add_mfa"):
+		return tableExists(db, "user_recovery_codes")
+	case strings.Contains(base, "create_login_attempts"):
+		return tableExists(db, "login_lockouts")
+	case strings.Contains(base, "add_email_verification"):
+		return tableExists(db, "user_tokens")
 	}
 
 	// If we can't determine, don't skip
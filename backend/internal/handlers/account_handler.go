@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"dev-bridge-manager/internal/services"
+	"dev-bridge-manager/internal/types"
+	"errors"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AccountHandler struct {
+	accountService    *services.AccountService
+	permissionService *services.PermissionService
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type ResetPasswordRequest struct {
+	Token           string `json:"token" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required,min=6"`
+	ConfirmPassword string `json:"confirm_password" validate:"required"`
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+func NewAccountHandler() *AccountHandler {
+	return &AccountHandler{
+		accountService:    services.NewAccountService(),
+		permissionService: services.NewPermissionService(),
+	}
+}
+
+// ForgotPassword - POST /api/v1/auth/forgot-password
+// Jelszó visszaállító link küldése; a válasz mindig ugyanaz, így nem derül ki, létezik-e a fiók
+func (h *AccountHandler) ForgotPassword(c *fiber.Ctx) error {
+	var req ForgotPasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(types.AuthResponse{
+			Success: false,
+			Message: "Invalid request body",
+		})
+	}
+	if strings.TrimSpace(req.Email) == "" {
+		return c.Status(400).JSON(types.AuthResponse{
+			Success: false,
+			Message: "Email is required",
+		})
+	}
+
+	// A keresés és a küldés a háttérben fut, így a válaszidő sem árulja el, létezik-e a fiók
+	h.accountService.RequestPasswordReset(req.Email)
+
+	return c.JSON(types.AuthResponse{
+		Success: true,
+		Message: "If an account exists for this email, a password reset link has been sent",
+	})
+}
+
+// ResetPassword - POST /api/v1/auth/reset-password
+// Új jelszó beállítása az emailben kapott tokennel; minden session és kiadott token érvényét veszti
+func (h *AccountHandler) ResetPassword(c *fiber.Ctx) error {
+	var req ResetPasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(types.AuthResponse{
+			Success: false,
+			Message: "Invalid request body",
+		})
+	}
+
+	if strings.TrimSpace(req.Token) == "" {
+		return c.Status(400).JSON(types.AuthResponse{
+			Success: false,
+			Message: "Token is required",
+		})
+	}
+	if len(req.NewPassword) < 6 {
+		return c.Status(400).JSON(types.AuthResponse{
+			Success: false,
+			Message: "Password must be at least 6 characters",
+		})
+	}
+	if req.NewPassword != req.ConfirmPassword {
+		return c.Status(400).JSON(types.AuthResponse{
+			Success: false,
+			Message: "Password confirmation does not match",
+		})
+	}
+
+	if _, err := h.accountService.ResetPassword(req.Token, req.NewPassword); err != nil {
+		if errors.Is(err, services.ErrInvalidUserToken) {
+			return c.Status(400).JSON(types.AuthResponse{
+				Success: false,
+				Message: "Invalid or expired password reset link",
+			})
+		}
+		return c.Status(500).JSON(types.AuthResponse{
+			Success: false,
+			Message: "Failed to reset password",
+		})
+	}
+
+	return c.JSON(types.AuthResponse{
+		Success: true,
+		Message: "Password reset successfully. Please log in with your new password.",
+	})
+}
+
+// VerifyEmail - POST /api/v1/auth/verify-email
+// Email cím megerősítése az emailben kapott tokennel; utána a szerepkör összes jogosultsága érvényes
+func (h *AccountHandler) VerifyEmail(c *fiber.Ctx) error {
+	var req VerifyEmailRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(types.AuthResponse{
+			Success: false,
+			Message: "Invalid request body",
+		})
+	}
+	if strings.TrimSpace(req.Token) == "" {
+		return c.Status(400).JSON(types.AuthResponse{
+			Success: false,
+			Message: "Token is required",
+		})
+	}
+
+	user, err := h.accountService.VerifyEmail(req.Token)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidUserToken) {
+			return c.Status(400).JSON(types.AuthResponse{
+				Success: false,
+				Message: "Invalid or expired email confirmation link",
+			})
+		}
+		return c.Status(500).JSON(types.AuthResponse{
+			Success: false,
+			Message: "Failed to confirm email address",
+		})
+	}
+
+	userWithRole, err := h.permissionService.GetUserWithPermissions(user.ID)
+	if err != nil {
+		return c.Status(500).JSON(types.AuthResponse{
+			Success: false,
+			Message: "Email address confirmed but failed to load user",
+		})
+	}
+
+	return c.JSON(types.AuthResponse{
+		Success: true,
+		Message: "Email address confirmed successfully",
+		User:    authUserResponse(userWithRole),
+	})
+}
+
+// ResendVerification - POST /api/v1/auth/verify-email/resend
+// Új megerősítő email a bejelentkezett user aktuális címére; a korábbi link érvényét veszti
+func (h *AccountHandler) ResendVerification(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+
+	user, err := h.permissionService.GetUserWithPermissions(userID)
+	if err != nil {
+		return c.Status(404).JSON(types.AuthResponse{
+			Success: false,
+			Message: "User not found",
+		})
+	}
+
+	err = h.accountService.SendVerification(user)
+	switch {
+	case errors.Is(err, services.ErrEmailAlreadyVerified):
+		return c.Status(409).JSON(types.AuthResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+	case errors.Is(err, services.ErrTokenRecentlySent):
+		return c.Status(429).JSON(types.AuthResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+	case err != nil:
+		return c.Status(500).JSON(types.AuthResponse{
+			Success: false,
+			Message: "Failed to send confirmation email",
+		})
+	}
+
+	return c.JSON(types.AuthResponse{
+		Success: true,
+		Message: "Confirmation email sent",
+	})
+}
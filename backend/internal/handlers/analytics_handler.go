@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"dev-bridge-manager/internal/models"
+	"dev-bridge-manager/internal/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AnalyticsHandler struct {
+	permissionService *services.PermissionService
+	analyticsService  *services.AnalyticsService
+}
+
+func NewAnalyticsHandler() *AnalyticsHandler {
+	return &AnalyticsHandler{
+		permissionService: services.NewPermissionService(),
+		analyticsService:  services.NewAnalyticsService(),
+	}
+}
+
+// GetProjectAnalytics - GET /api/v1/projects/:id/analytics
+// Cycle time, lead time, heti throughput és oszloponkénti idő; startDate/endDate (YYYY-MM-DD),
+// alapból az utolsó 90 nap
+func (h *AnalyticsHandler) GetProjectAnalytics(c *fiber.Ctx) error {
+	currentUserID := c.Locals("userID").(uint)
+
+	projectID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(400).JSON(models.ProjectAnalyticsResponse{
+			Success: false,
+			Message: "Invalid project ID",
+		})
+	}
+
+	if err := requireProjectRole(h.permissionService, currentUserID, projectID, "viewer"); err != nil {
+		return err
+	}
+
+	from, to, err := parseDateRange(c, services.AnalyticsDefaultDays)
+	if err != nil {
+		return err
+	}
+
+	analytics, err := h.analyticsService.ProjectAnalytics(projectID, from, to)
+	if err != nil {
+		return c.Status(500).JSON(models.ProjectAnalyticsResponse{
+			Success: false,
+			Message: "Error computing project analytics",
+		})
+	}
+
+	return c.JSON(models.ProjectAnalyticsResponse{
+		Success:   true,
+		Message:   "Project analytics retrieved successfully",
+		Analytics: analytics,
+	})
+}
+
+// GetTaskHistory - GET /api/v1/projects/:id/tasks/:taskId/history
+// A task oszlopváltásai időrendben
+func (h *AnalyticsHandler) GetTaskHistory(c *fiber.Ctx) error {
+	currentUserID := c.Locals("userID").(uint)
+
+	projectID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(400).JSON(models.TaskStatusHistoryResponse{
+			Success: false,
+			Message: "Invalid project ID",
+		})
+	}
+
+	taskID, err := parseIDParam(c, "taskId")
+	if err != nil {
+		return c.Status(400).JSON(models.TaskStatusHistoryResponse{
+			Success: false,
+			Message: "Invalid task ID",
+		})
+	}
+
+	if err := requireProjectRole(h.permissionService, currentUserID, projectID, "viewer"); err != nil {
+		return err
+	}
+
+	if _, err := findProjectTask(projectID, taskID); err != nil {
+		return c.Status(404).JSON(models.TaskStatusHistoryResponse{
+			Success: false,
+			Message: "Task not found",
+		})
+	}
+
+	history, err := h.analyticsService.TaskHistory(taskID)
+	if err != nil {
+		return c.Status(500).JSON(models.TaskStatusHistoryResponse{
+			Success: false,
+			Message: "Error fetching task history",
+		})
+	}
+
+	return c.JSON(models.TaskStatusHistoryResponse{
+		Success: true,
+		Message: "Task history retrieved successfully",
+		History: history,
+		Count:   len(history),
+	})
+}
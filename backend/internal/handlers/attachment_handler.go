@@ -0,0 +1,314 @@
+package handlers
+
+import (
+	"dev-bridge-manager/internal/database"
+	"dev-bridge-manager/internal/models"
+	"dev-bridge-manager/internal/services"
+	"dev-bridge-manager/internal/storage"
+	"errors"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AttachmentHandler struct {
+	permissionService *services.PermissionService
+	attachmentService *services.AttachmentService
+}
+
+func NewAttachmentHandler() *AttachmentHandler {
+	return &AttachmentHandler{
+		permissionService: services.NewPermissionService(),
+		attachmentService: services.NewAttachmentService(),
+	}
+}
+
+// attachmentParams - projekt és task azonosító beolvasása, jogosultság ellenőrzése
+func (h *AttachmentHandler) attachmentParams(c *fiber.Ctx, minRole string) (projectID, taskID uint, err error) {
+	currentUserID := c.Locals("userID").(uint)
+
+	if projectID, err = parseIDParam(c, "id"); err != nil {
+		return 0, 0, fiber.NewError(400, "Invalid project ID")
+	}
+	if taskID, err = parseIDParam(c, "taskId"); err != nil {
+		return 0, 0, fiber.NewError(400, "Invalid task ID")
+	}
+	if err = requireProjectRole(h.permissionService, currentUserID, projectID, minRole); err != nil {
+		return 0, 0, err
+	}
+	return projectID, taskID, nil
+}
+
+// loadAttachment - a csatolmány betöltése az útvonal paraméterei alapján
+func (h *AttachmentHandler) loadAttachment(c *fiber.Ctx, minRole string) (*models.TaskAttachment, error) {
+	projectID, taskID, err := h.attachmentParams(c, minRole)
+	if err != nil {
+		return nil, err
+	}
+
+	attachmentID, err := parseIDParam(c, "attachmentId")
+	if err != nil {
+		return nil, fiber.NewError(400, "Invalid attachment ID")
+	}
+
+	attachment, err := h.attachmentService.Load(projectID, taskID, attachmentID)
+	if err != nil {
+		return nil, fiber.NewError(404, "Attachment not found")
+	}
+	return attachment, nil
+}
+
+// attachmentResponse - API válasz aláírt, lejáró letöltési URL-lel
+func (h *AttachmentHandler) attachmentResponse(attachment *models.TaskAttachment) (models.AttachmentResponse, error) {
+	url, expiresAt, err := h.attachmentService.SignedURL(attachment)
+	if err != nil {
+		return models.AttachmentResponse{}, err
+	}
+	return attachment.ToResponse(url, expiresAt), nil
+}
+
+// GetTaskAttachments - GET /api/v1/projects/:id/tasks/:taskId/attachments
+func (h *AttachmentHandler) GetTaskAttachments(c *fiber.Ctx) error {
+	projectID, taskID, err := h.attachmentParams(c, "viewer")
+	if err != nil {
+		return err
+	}
+
+	attachments, err := h.attachmentService.List(projectID, taskID)
+	if err != nil {
+		return c.Status(500).JSON(models.AttachmentListResponse{
+			Success: false,
+			Message: "Error fetching attachments",
+		})
+	}
+
+	response := make([]models.AttachmentResponse, 0, len(attachments))
+	for i := range attachments {
+		item, err := h.attachmentResponse(&attachments[i])
+		if err != nil {
+			return c.Status(500).JSON(models.AttachmentListResponse{
+				Success: false,
+				Message: "Error signing attachment URLs",
+			})
+		}
+		response = append(response, item)
+	}
+
+	return c.JSON(models.AttachmentListResponse{
+		Success:     true,
+		Message:     "Attachments retrieved successfully",
+		Attachments: response,
+		Count:       len(response),
+	})
+}
+
+// UploadAttachment - POST /api/v1/projects/:id/tasks/:taskId/attachments
+// Multipart feltöltés a "file" mezőben; méret limit és projekt kvóta ellenőrzéssel
+func (h *AttachmentHandler) UploadAttachment(c *fiber.Ctx) error {
+	currentUserID := c.Locals("userID").(uint)
+
+	projectID, taskID, err := h.attachmentParams(c, "member")
+	if err != nil {
+		return err
+	}
+
+	// Task létezésének ellenőrzése
+	var task models.Task
+	if err := database.GetDB().Where("id = ? AND project_id = ?", taskID, projectID).First(&task).Error; err != nil {
+		return c.Status(404).JSON(models.AttachmentListResponse{
+			Success: false,
+			Message: "Task not found",
+		})
+	}
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(400).JSON(models.AttachmentListResponse{
+			Success: false,
+			Message: "A file is required in the 'file' form field",
+		})
+	}
+
+	attachment, err := h.attachmentService.Upload(c.Context(), projectID, taskID, currentUserID, header)
+	if err != nil {
+		var quotaErr *services.QuotaError
+		switch {
+		case errors.Is(err, services.ErrAttachmentTooLarge):
+			return c.Status(413).JSON(models.AttachmentListResponse{
+				Success: false,
+				Message: fmt.Sprintf("File exceeds the maximum size of %d MB", h.attachmentService.MaxSize>>20),
+			})
+		case errors.As(err, &quotaErr):
+			return c.Status(409).JSON(models.AttachmentListResponse{
+				Success: false,
+				Message: "Project attachment quota exceeded",
+				Usage: &models.AttachmentUsage{
+					UsedBytes:  quotaErr.UsedBytes,
+					QuotaBytes: quotaErr.QuotaBytes,
+				},
+			})
+		}
+		return c.Status(500).JSON(models.AttachmentListResponse{
+			Success: false,
+			Message: "Error uploading attachment",
+		})
+	}
+
+	response, err := h.attachmentResponse(attachment)
+	if err != nil {
+		return c.Status(500).JSON(models.AttachmentListResponse{
+			Success: false,
+			Message: "Attachment uploaded but failed to sign its URL",
+		})
+	}
+
+	return c.Status(201).JSON(models.AttachmentListResponse{
+		Success:    true,
+		Message:    "Attachment uploaded successfully",
+		Attachment: &response,
+	})
+}
+
+// GetAttachmentURL - GET /api/v1/projects/:id/tasks/:taskId/attachments/:attachmentId/url
+// Új aláírt, lejáró letöltési URL
+func (h *AttachmentHandler) GetAttachmentURL(c *fiber.Ctx) error {
+	attachment, err := h.loadAttachment(c, "viewer")
+	if err != nil {
+		return err
+	}
+
+	response, err := h.attachmentResponse(attachment)
+	if err != nil {
+		return c.Status(500).JSON(models.AttachmentListResponse{
+			Success: false,
+			Message: "Error signing attachment URL",
+		})
+	}
+
+	return c.JSON(models.AttachmentListResponse{
+		Success:    true,
+		Message:    "Attachment URL generated successfully",
+		Attachment: &response,
+	})
+}
+
+// DownloadAttachment - GET /api/v1/projects/:id/tasks/:taskId/attachments/:attachmentId/download
+func (h *AttachmentHandler) DownloadAttachment(c *fiber.Ctx) error {
+	attachment, err := h.loadAttachment(c, "viewer")
+	if err != nil {
+		return err
+	}
+
+	reader, err := h.attachmentService.Open(c.Context(), attachment)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return fiber.NewError(404, "Attachment file not found")
+		}
+		return fiber.NewError(500, "Error reading attachment")
+	}
+
+	setDownloadHeaders(c, attachment.MimeType, attachment.OriginalName)
+	return c.SendStream(reader, int(attachment.Size))
+}
+
+// DeleteAttachment - DELETE /api/v1/projects/:id/tasks/:taskId/attachments/:attachmentId
+// A feltöltő vagy a projekt manager törölheti
+func (h *AttachmentHandler) DeleteAttachment(c *fiber.Ctx) error {
+	currentUserID := c.Locals("userID").(uint)
+
+	attachment, err := h.loadAttachment(c, "member")
+	if err != nil {
+		return err
+	}
+
+	if attachment.UploadedBy == nil || *attachment.UploadedBy != currentUserID {
+		if err := requireProjectRole(h.permissionService, currentUserID, attachment.ProjectID, "manager"); err != nil {
+			return err
+		}
+	}
+
+	if err := h.attachmentService.Delete(c.Context(), attachment); err != nil {
+		return c.Status(500).JSON(models.AttachmentListResponse{
+			Success: false,
+			Message: "Error deleting attachment",
+		})
+	}
+
+	return c.JSON(models.AttachmentListResponse{
+		Success: true,
+		Message: "Attachment deleted successfully",
+	})
+}
+
+// GetAttachmentUsage - GET /api/v1/projects/:id/attachments/usage
+func (h *AttachmentHandler) GetAttachmentUsage(c *fiber.Ctx) error {
+	currentUserID := c.Locals("userID").(uint)
+
+	projectID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(400).JSON(models.AttachmentListResponse{
+			Success: false,
+			Message: "Invalid project ID",
+		})
+	}
+
+	if err := requireProjectRole(h.permissionService, currentUserID, projectID, "viewer"); err != nil {
+		return err
+	}
+
+	used, err := h.attachmentService.Usage(database.GetDB(), projectID)
+	if err != nil {
+		return c.Status(500).JSON(models.AttachmentListResponse{
+			Success: false,
+			Message: "Error calculating attachment usage",
+		})
+	}
+
+	return c.JSON(models.AttachmentListResponse{
+		Success: true,
+		Message: "Attachment usage retrieved successfully",
+		Usage: &models.AttachmentUsage{
+			UsedBytes:  used,
+			QuotaBytes: h.attachmentService.ProjectQuota,
+		},
+	})
+}
+
+// DownloadSignedFile - GET /api/v1/files/* - aláírt URL-es letöltés (local storage driver)
+// JWT nélkül érhető el, az aláírás és a lejárat védi
+func (h *AttachmentHandler) DownloadSignedFile(c *fiber.Ctx) error {
+	local, ok := storage.Default().(*storage.LocalStorage)
+	if !ok {
+		return fiber.NewError(404, "Not found")
+	}
+
+	key := c.Params("*")
+	name := c.Query("name")
+	if err := local.Verify(key, name, c.Query("expires"), c.Query("signature")); err != nil {
+		return fiber.NewError(403, "Invalid or expired download link")
+	}
+
+	var attachment models.TaskAttachment
+	if err := database.GetDB().Where("storage_key = ?", key).First(&attachment).Error; err != nil {
+		return fiber.NewError(404, "Attachment not found")
+	}
+
+	reader, err := local.Open(c.Context(), key)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return fiber.NewError(404, "Attachment file not found")
+		}
+		return fiber.NewError(500, "Error reading attachment")
+	}
+
+	setDownloadHeaders(c, attachment.MimeType, name)
+	return c.SendStream(reader, int(attachment.Size))
+}
+
+// setDownloadHeaders - a fájl mindig letöltésként érkezik, a böngésző nem próbálja értelmezni
+func setDownloadHeaders(c *fiber.Ctx, mimeType, filename string) {
+	c.Set(fiber.HeaderContentType, mimeType)
+	c.Set(fiber.HeaderContentDisposition, storage.ContentDisposition(filename))
+	c.Set(fiber.HeaderXContentTypeOptions, "nosniff")
+	c.Set(fiber.HeaderCacheControl, "private, max-age=300")
+}
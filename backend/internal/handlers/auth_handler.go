@@ -5,12 +5,21 @@ import (
 	"dev-bridge-manager/internal/models"
 	"dev-bridge-manager/internal/services"
 	"dev-bridge-manager/internal/types"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
 )
 
 type AuthHandler struct {
-	authService       *services.AuthService
-	permissionService *services.PermissionService
+	accountService      *services.AccountService
+	authService         *services.AuthService
+	loginAttemptService *services.LoginAttemptService
+	permissionService   *services.PermissionService
+	sessionService      *services.SessionService
 }
 
 type RegisterRequest struct {
@@ -19,18 +28,156 @@ type RegisterRequest struct {
 	Password string `json:"password" validate:"required,min=6"`
 	Position string `json:"position"`
 	RoleName string `json:"role_name,omitempty"` // Optional, only admins can set
+	Device   string `json:"device,omitempty"`    // Optional device name shown in the session list
 }
 
 type LoginRequest struct {
 	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"password" validate:"required"`
+	Device   string `json:"device,omitempty"` // Optional device name shown in the session list
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
 }
 
 func NewAuthHandler() *AuthHandler {
 	return &AuthHandler{
-		authService:       services.NewAuthService(),
-		permissionService: services.NewPermissionService(),
+		accountService:      services.NewAccountService(),
+		authService:         services.NewAuthService(),
+		loginAttemptService: services.NewLoginAttemptService(),
+		permissionService:   services.NewPermissionService(),
+		sessionService:      services.NewSessionService(),
+	}
+}
+
+// loginLocked - 429-es válasz Retry-After fejléccel a zárolás végéig
+func loginLocked(c *fiber.Ctx, until time.Time) error {
+	seconds := int(time.Until(until).Seconds()) + 1
+	c.Set(fiber.HeaderRetryAfter, strconv.Itoa(seconds))
+	return c.Status(429).JSON(types.AuthResponse{
+		Success:     false,
+		Message:     "Too many failed login attempts, try again later",
+		LockedUntil: &until,
+	})
+}
+
+// beginLogin - a kísérlet számolása még az ellenőrzés előtt; zárolt fiók vagy IP esetén elküldi a
+// hibaválaszt és nil kísérletet ad vissza
+func beginLogin(c *fiber.Ctx, loginAttemptService *services.LoginAttemptService, email string) (*services.LoginAttempt, error) {
+	attempt, until, err := loginAttemptService.Begin(email, c.IP(), time.Now())
+	if err != nil {
+		return nil, c.Status(500).JSON(types.AuthResponse{
+			Success: false,
+			Message: "Failed to check login attempts",
+		})
+	}
+	if attempt == nil {
+		return nil, loginLocked(c, until)
 	}
+	return attempt, nil
+}
+
+// loginFailed - a kísérlet sikertelenként zárul; ha ez zárolta a fiókot vagy az IP-t, 429, egyébként 401
+func loginFailed(c *fiber.Ctx, attempt *services.LoginAttempt, userID *uint, message string) error {
+	until, err := attempt.Fail(userID)
+	if err != nil {
+		return c.Status(500).JSON(types.AuthResponse{
+			Success: false,
+			Message: "Failed to record login attempt",
+		})
+	}
+	if !until.IsZero() {
+		return loginLocked(c, until)
+	}
+	return c.Status(401).JSON(types.AuthResponse{
+		Success: false,
+		Message: message,
+	})
+}
+
+// sessionMeta - a kliens adatai a session listához
+func sessionMeta(c *fiber.Ctx, device string) services.SessionMeta {
+	return services.SessionMeta{
+		Device:    strings.TrimSpace(device),
+		IPAddress: c.IP(),
+		UserAgent: c.Get("User-Agent"),
+	}
+}
+
+// authUserResponse - a bejelentkezett user adatai role-lal és jogosultságokkal
+func authUserResponse(user *models.User) *types.UserResponse {
+	return &types.UserResponse{
+		ID:            user.ID,
+		Name:          user.Name,
+		Email:         user.Email,
+		EmailVerified: user.EmailVerified(),
+		Position:      user.Position,
+		Role: types.RoleInfo{
+			ID:          user.Role.ID,
+			Name:        user.Role.Name,
+			DisplayName: user.Role.DisplayName,
+		},
+		Permissions: user.GetPermissions(),
+	}
+}
+
+// completeLogin - sikeres jelszó ellenőrzés után: MFA-val rendelkező usernek kód bekérő challenge,
+// kötelező MFA-s szerepkörnél beállító challenge, egyébként új session és tokenek
+func (h *AuthHandler) completeLogin(c *fiber.Ctx, user *models.User, device string, status int, message string) error {
+	purpose := ""
+	switch {
+	case user.MFAEnabled:
+		purpose = services.MFAChallengeVerify
+	case user.Role.MFARequired:
+		purpose = services.MFAChallengeSetup
+	}
+
+	if purpose != "" {
+		challenge, err := h.authService.IssueMFAChallenge(user, purpose, strings.TrimSpace(device))
+		if err != nil {
+			return c.Status(500).JSON(types.AuthResponse{
+				Success: false,
+				Message: "Failed to generate MFA challenge",
+			})
+		}
+
+		response := types.AuthResponse{
+			Success:  true,
+			Message:  "Two-factor verification required",
+			MFAToken: challenge,
+		}
+		if purpose == services.MFAChallengeVerify {
+			response.MFARequired = true
+		} else {
+			response.Message = "Two-factor authentication must be set up for your role"
+			response.MFASetupRequired = true
+		}
+		return c.Status(status).JSON(response)
+	}
+
+	return startSession(c, h.authService, user, device, nil, status, message)
+}
+
+// startSession - új session létrehozása és a tokenek visszaadása
+func startSession(c *fiber.Ctx, authService *services.AuthService, user *models.User, device string, recoveryCodes []string, status int, message string) error {
+	tokens, err := authService.StartSession(user, sessionMeta(c, device))
+	if err != nil {
+		return c.Status(500).JSON(types.AuthResponse{
+			Success: false,
+			Message: "Failed to generate token",
+		})
+	}
+
+	return c.Status(status).JSON(types.AuthResponse{
+		Success:       true,
+		Message:       message,
+		Token:         tokens.AccessToken,
+		ExpiresAt:     &tokens.ExpiresAt,
+		RefreshToken:  tokens.RefreshToken,
+		RecoveryCodes: recoveryCodes,
+		User:          authUserResponse(user),
+	})
 }
 
 // Register creates a new user account
@@ -127,32 +274,14 @@ func (h *AuthHandler) Register(c *fiber.Ctx) error {
 		})
 	}
 
-	// Generate token
-	token, err := h.authService.GenerateToken(user.ID, user.Email, user.Name)
-	if err != nil {
-		return c.Status(500).JSON(types.AuthResponse{
-			Success: false,
-			Message: "Failed to generate token",
-		})
+	// Megerősítő email; a megerősítésig a fiók csak korlátozott jogosultságokkal használható
+	message := "User registered successfully. Please confirm your email address."
+	if err := h.accountService.SendVerification(userWithRole); err != nil {
+		message = "User registered successfully, but the confirmation email could not be sent. Please request a new one."
 	}
 
-	return c.Status(201).JSON(types.AuthResponse{
-		Success: true,
-		Message: "User registered successfully",
-		Token:   token,
-		User: &types.UserResponse{
-			ID:       userWithRole.ID,
-			Name:     userWithRole.Name,
-			Email:    userWithRole.Email,
-			Position: userWithRole.Position,
-			Role: types.RoleInfo{
-				ID:          userWithRole.Role.ID,
-				Name:        userWithRole.Role.Name,
-				DisplayName: userWithRole.Role.DisplayName,
-			},
-			Permissions: userWithRole.GetPermissions(),
-		},
-	})
+	// Session és tokenek létrehozása (kötelező MFA esetén előbb a beállítás)
+	return h.completeLogin(c, userWithRole, req.Device, 201, message)
 }
 
 // Login authenticates user and returns JWT token
@@ -165,20 +294,37 @@ func (h *AuthHandler) Login(c *fiber.Ctx) error {
 		})
 	}
 
+	// A kísérlet a jelszó ellenőrzése előtt számít, így párhuzamos próbálkozásokkal sem léphető át a küszöb;
+	// zárolt fiók vagy IP esetén a jelszót nem is ellenőrizzük
+	attempt, err := beginLogin(c, h.loginAttemptService, req.Email)
+	if attempt == nil {
+		return err
+	}
+
 	// Find user by email with role
 	var user models.User
 	if err := database.GetDB().Preload("Role.Permissions").Where("email = ?", req.Email).First(&user).Error; err != nil {
-		return c.Status(401).JSON(types.AuthResponse{
-			Success: false,
-			Message: "Invalid credentials",
-		})
+		return loginFailed(c, attempt, nil, "Invalid credentials")
 	}
 
 	// Check password
 	if !user.CheckPassword(req.Password) {
-		return c.Status(401).JSON(types.AuthResponse{
+		return loginFailed(c, attempt, &user.ID, "Invalid credentials")
+	}
+
+	// Megerősítetlen email címmel csak a UNVERIFIED_PERMISSIONS jogosultságai érvényesek
+	h.permissionService.LimitUnverified(&user)
+
+	// MFA nélkül itt ér véget a belépés; MFA esetén a helyes jelszó csak visszaadja a kísérletet, a fiók
+	// számlálója a kód ellenőrzése után nullázódik, különben a jelszó birtokában korlátlanul lehetne kódokat próbálgatni
+	settle := attempt.Succeed
+	if user.MFAEnabled {
+		settle = attempt.Refund
+	}
+	if err := settle(); err != nil {
+		return c.Status(500).JSON(types.AuthResponse{
 			Success: false,
-			Message: "Invalid credentials",
+			Message: "Failed to record login attempt",
 		})
 	}
 
@@ -190,32 +336,8 @@ func (h *AuthHandler) Login(c *fiber.Ctx) error {
 		})
 	}
 
-	// Generate token
-	token, err := h.authService.GenerateToken(user.ID, user.Email, user.Name)
-	if err != nil {
-		return c.Status(500).JSON(types.AuthResponse{
-			Success: false,
-			Message: "Failed to generate token",
-		})
-	}
-
-	return c.JSON(types.AuthResponse{
-		Success: true,
-		Message: "Login successful",
-		Token:   token,
-		User: &types.UserResponse{
-			ID:       user.ID,
-			Name:     user.Name,
-			Email:    user.Email,
-			Position: user.Position,
-			Role: types.RoleInfo{
-				ID:          user.Role.ID,
-				Name:        user.Role.Name,
-				DisplayName: user.Role.DisplayName,
-			},
-			Permissions: user.GetPermissions(),
-		},
-	})
+	// Session és tokenek létrehozása, MFA esetén előbb a második lépés
+	return h.completeLogin(c, &user, req.Device, 200, "Login successful")
 }
 
 // Me returns current user info
@@ -234,10 +356,11 @@ func (h *AuthHandler) Me(c *fiber.Ctx) error {
 		Success: true,
 		Message: "User info retrieved",
 		User: &types.UserResponse{
-			ID:       userWithRole.ID,
-			Name:     userWithRole.Name,
-			Email:    userWithRole.Email,
-			Position: userWithRole.Position,
+			ID:            userWithRole.ID,
+			Name:          userWithRole.Name,
+			Email:         userWithRole.Email,
+			EmailVerified: userWithRole.EmailVerified(),
+			Position:      userWithRole.Position,
 			Role: types.RoleInfo{
 				ID:          userWithRole.Role.ID,
 				Name:        userWithRole.Role.Name,
@@ -248,28 +371,137 @@ func (h *AuthHandler) Me(c *fiber.Ctx) error {
 	})
 }
 
-// RefreshToken refreshes the JWT token
+// RefreshToken - POST /api/v1/auth/refresh
+// A refresh token egyszer használható: új access és refresh tokent ad, a régi érvényét veszti.
+// Egy már felhasznált refresh token ismételt bemutatása a teljes sessiont visszavonja.
 func (h *AuthHandler) RefreshToken(c *fiber.Ctx) error {
-	authHeader := c.Get("Authorization")
-	if authHeader == "" {
-		return c.Status(401).JSON(types.AuthResponse{
+	var req RefreshRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(types.AuthResponse{
+			Success: false,
+			Message: "Invalid request body",
+		})
+	}
+	if strings.TrimSpace(req.RefreshToken) == "" {
+		return c.Status(400).JSON(types.AuthResponse{
 			Success: false,
-			Message: "Authorization header required",
+			Message: "Refresh token is required",
 		})
 	}
 
-	tokenString := authHeader[7:] // Remove "Bearer " prefix
-	newToken, err := h.authService.RefreshToken(tokenString)
-	if err != nil {
+	tokens, user, err := h.authService.RefreshSession(strings.TrimSpace(req.RefreshToken), sessionMeta(c, ""))
+	switch {
+	case errors.Is(err, services.ErrInvalidRefreshToken), errors.Is(err, services.ErrRefreshTokenReused):
 		return c.Status(401).JSON(types.AuthResponse{
 			Success: false,
-			Message: "Invalid or expired token",
+			Message: err.Error(),
+		})
+	case errors.Is(err, services.ErrAccountDeactivated):
+		return c.Status(403).JSON(types.AuthResponse{
+			Success: false,
+			Message: "Account is deactivated",
+		})
+	case errors.Is(err, services.ErrMFASetupRequired):
+		return c.Status(403).JSON(types.AuthResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+	case err != nil:
+		return c.Status(500).JSON(types.AuthResponse{
+			Success: false,
+			Message: "Failed to refresh token",
+		})
+	}
+
+	return c.JSON(types.AuthResponse{
+		Success:      true,
+		Message:      "Token refreshed successfully",
+		Token:        tokens.AccessToken,
+		ExpiresAt:    &tokens.ExpiresAt,
+		RefreshToken: tokens.RefreshToken,
+		User:         authUserResponse(user),
+	})
+}
+
+// Logout - POST /api/v1/auth/logout
+// A kérést küldő session visszavonása; az access token azonnal, a refresh token is érvényét veszti
+func (h *AuthHandler) Logout(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+	sessionID := c.Locals("sessionID").(uint)
+
+	err := h.sessionService.RevokeUserSession(userID, sessionID, services.SessionRevokedLogout)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return c.Status(500).JSON(types.AuthResponse{
+			Success: false,
+			Message: "Failed to log out",
 		})
 	}
 
 	return c.JSON(types.AuthResponse{
 		Success: true,
-		Message: "Token refreshed successfully",
-		Token:   newToken,
+		Message: "Logged out successfully",
+	})
+}
+
+// GetSessions - GET /api/v1/auth/sessions
+// Saját aktív sessionök eszköz, IP és user agent adatokkal; a current jelzi az aktuálisat
+func (h *AuthHandler) GetSessions(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+	sessionID := c.Locals("sessionID").(uint)
+
+	sessions, err := h.sessionService.ListActiveSessions(userID)
+	if err != nil {
+		return c.Status(500).JSON(models.SessionListResponse{
+			Success: false,
+			Message: "Error fetching sessions",
+		})
+	}
+
+	responses := make([]models.SessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		responses = append(responses, models.SessionResponse{
+			Session: session,
+			Current: session.ID == sessionID,
+		})
+	}
+
+	return c.JSON(models.SessionListResponse{
+		Success:  true,
+		Message:  "Sessions retrieved successfully",
+		Sessions: responses,
+		Count:    len(responses),
+	})
+}
+
+// RevokeSession - DELETE /api/v1/auth/sessions/:id
+// Saját session távoli visszavonása (pl. elveszett eszköz)
+func (h *AuthHandler) RevokeSession(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+
+	sessionID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(400).JSON(models.SessionListResponse{
+			Success: false,
+			Message: "Invalid session ID",
+		})
+	}
+
+	err = h.sessionService.RevokeUserSession(userID, sessionID, services.SessionRevokedByUser)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return c.Status(404).JSON(models.SessionListResponse{
+			Success: false,
+			Message: "Session not found",
+		})
+	}
+	if err != nil {
+		return c.Status(500).JSON(models.SessionListResponse{
+			Success: false,
+			Message: "Failed to revoke session",
+		})
+	}
+
+	return c.JSON(models.SessionListResponse{
+		Success: true,
+		Message: "Session revoked successfully",
 	})
 }
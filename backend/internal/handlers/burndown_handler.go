@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"dev-bridge-manager/internal/database"
+	"dev-bridge-manager/internal/models"
+	"dev-bridge-manager/internal/services"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// maxReportDays a napi bontású riportok leghosszabb időszaka
+const maxReportDays = 366
+
+type BurndownHandler struct {
+	permissionService *services.PermissionService
+	sprintService     *services.SprintService
+	snapshotService   *services.SnapshotService
+}
+
+func NewBurndownHandler() *BurndownHandler {
+	return &BurndownHandler{
+		permissionService: services.NewPermissionService(),
+		sprintService:     services.NewSprintService(),
+		snapshotService:   services.NewSnapshotService(),
+	}
+}
+
+// parseDateRange - startDate/endDate (YYYY-MM-DD) query paraméterek; alapból a mai nappal záródó defaultDays napos időszak
+func parseDateRange(c *fiber.Ctx, defaultDays int) (time.Time, time.Time, error) {
+	to := services.SnapshotDay(time.Now())
+	if endDate := c.Query("endDate"); endDate != "" {
+		date, err := time.Parse(models.TimeEntryDateLayout, endDate)
+		if err != nil {
+			return time.Time{}, time.Time{}, fiber.NewError(400, "endDate must be in YYYY-MM-DD format")
+		}
+		to = date
+	}
+
+	from := to.AddDate(0, 0, -(defaultDays - 1))
+	if startDate := c.Query("startDate"); startDate != "" {
+		date, err := time.Parse(models.TimeEntryDateLayout, startDate)
+		if err != nil {
+			return time.Time{}, time.Time{}, fiber.NewError(400, "startDate must be in YYYY-MM-DD format")
+		}
+		from = date
+	}
+
+	if from.After(to) {
+		return time.Time{}, time.Time{}, fiber.NewError(400, "startDate cannot be after endDate")
+	}
+	if to.Sub(from) > maxReportDays*24*time.Hour {
+		return time.Time{}, time.Time{}, fiber.NewError(400, "The date range can be at most 366 days")
+	}
+	return from, to, nil
+}
+
+// GetSprintBurndown - GET /api/v1/projects/:id/sprints/:sprintId/burndown
+// Napi hátralévő becslés és taskszám a sprint kezdetétől, az ideális vonallal
+func (h *BurndownHandler) GetSprintBurndown(c *fiber.Ctx) error {
+	currentUserID := c.Locals("userID").(uint)
+
+	projectID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(400).JSON(models.BurndownResponse{
+			Success: false,
+			Message: "Invalid project ID",
+		})
+	}
+
+	sprintID, err := parseIDParam(c, "sprintId")
+	if err != nil {
+		return c.Status(400).JSON(models.BurndownResponse{
+			Success: false,
+			Message: "Invalid sprint ID",
+		})
+	}
+
+	if err := requireProjectRole(h.permissionService, currentUserID, projectID, "viewer"); err != nil {
+		return err
+	}
+
+	sprint, err := h.sprintService.LoadSprint(database.GetDB(), projectID, sprintID)
+	if err != nil {
+		return sprintError(err)
+	}
+
+	points, err := h.snapshotService.SprintBurndown(sprint)
+	if err != nil {
+		return c.Status(500).JSON(models.BurndownResponse{
+			Success: false,
+			Message: "Error building burndown data",
+		})
+	}
+
+	response := sprint.ToResponse()
+	return c.JSON(models.BurndownResponse{
+		Success: true,
+		Message: "Burndown data retrieved successfully",
+		Sprint:  &response,
+		Points:  points,
+	})
+}
+
+// GetProjectBurnup - GET /api/v1/projects/:id/burnup
+// Napi teljes scope és elkészült munka; startDate/endDate (YYYY-MM-DD), alapból az utolsó 30 nap
+func (h *BurndownHandler) GetProjectBurnup(c *fiber.Ctx) error {
+	currentUserID := c.Locals("userID").(uint)
+
+	projectID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(400).JSON(models.BurnupResponse{
+			Success: false,
+			Message: "Invalid project ID",
+		})
+	}
+
+	if err := requireProjectRole(h.permissionService, currentUserID, projectID, "viewer"); err != nil {
+		return err
+	}
+
+	from, to, err := parseDateRange(c, services.BurnupDefaultDays)
+	if err != nil {
+		return err
+	}
+
+	points, err := h.snapshotService.ProjectBurnup(projectID, from, to)
+	if err != nil {
+		return c.Status(500).JSON(models.BurnupResponse{
+			Success: false,
+			Message: "Error building burnup data",
+		})
+	}
+
+	return c.JSON(models.BurnupResponse{
+		Success: true,
+		Message: "Burnup data retrieved successfully",
+		From:    from.Format(models.TimeEntryDateLayout),
+		To:      to.Format(models.TimeEntryDateLayout),
+		Points:  points,
+	})
+}
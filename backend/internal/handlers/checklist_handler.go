@@ -0,0 +1,223 @@
+package handlers
+
+import (
+	"dev-bridge-manager/internal/models"
+	"dev-bridge-manager/internal/services"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ChecklistHandler struct {
+	permissionService *services.PermissionService
+	taskService       *services.TaskService
+	checklistService  *services.ChecklistService
+}
+
+func NewChecklistHandler() *ChecklistHandler {
+	return &ChecklistHandler{
+		permissionService: services.NewPermissionService(),
+		taskService:       services.NewTaskService(),
+		checklistService:  services.NewChecklistService(),
+	}
+}
+
+// validateChecklistTitle - a checklist elem címe nem lehet üres és legfeljebb 255 karakter
+func validateChecklistTitle(title string) error {
+	if strings.TrimSpace(title) == "" {
+		return fiber.NewError(400, "Checklist item title is required")
+	}
+	if len(title) > 255 {
+		return fiber.NewError(400, "Checklist item title must be less than 255 characters")
+	}
+	return nil
+}
+
+// loadChecklistTask - projekt és task azonosító beolvasása, jogosultság ellenőrzése, task betöltése
+func (h *ChecklistHandler) loadChecklistTask(c *fiber.Ctx, minRole string) (*models.Task, error) {
+	currentUserID := c.Locals("userID").(uint)
+
+	projectID, err := parseIDParam(c, "id")
+	if err != nil {
+		return nil, fiber.NewError(400, "Invalid project ID")
+	}
+
+	taskID, err := parseIDParam(c, "taskId")
+	if err != nil {
+		return nil, fiber.NewError(400, "Invalid task ID")
+	}
+
+	if err := requireProjectRole(h.permissionService, currentUserID, projectID, minRole); err != nil {
+		return nil, err
+	}
+
+	task, err := findProjectTask(projectID, taskID)
+	if err != nil {
+		return nil, fiber.NewError(404, "Task not found")
+	}
+	return task, nil
+}
+
+// loadChecklistItem - a task checklist elemének betöltése az :itemId paraméter alapján
+func (h *ChecklistHandler) loadChecklistItem(c *fiber.Ctx, task *models.Task) (*models.TaskChecklistItem, error) {
+	itemID, err := parseIDParam(c, "itemId")
+	if err != nil {
+		return nil, fiber.NewError(400, "Invalid checklist item ID")
+	}
+
+	item, err := h.checklistService.LoadItem(task.ID, itemID)
+	if err != nil {
+		return nil, fiber.NewError(404, "Checklist item not found")
+	}
+	return item, nil
+}
+
+// taskProgress - a task frissített haladása a válaszhoz
+func (h *ChecklistHandler) taskProgress(task *models.Task) *models.TaskProgress {
+	progress, err := h.taskService.Progress([]models.Task{*task})
+	if err != nil {
+		return nil
+	}
+	return progress[task.ID]
+}
+
+// GetChecklist - GET /api/v1/projects/:id/tasks/:taskId/checklist
+func (h *ChecklistHandler) GetChecklist(c *fiber.Ctx) error {
+	task, err := h.loadChecklistTask(c, "viewer")
+	if err != nil {
+		return err
+	}
+
+	items, err := h.checklistService.ListItems(task.ID)
+	if err != nil {
+		return c.Status(500).JSON(models.ChecklistListResponse{
+			Success: false,
+			Message: "Error fetching checklist",
+		})
+	}
+
+	return c.JSON(models.ChecklistListResponse{
+		Success:  true,
+		Message:  "Checklist retrieved successfully",
+		Items:    items,
+		Count:    len(items),
+		Progress: h.taskProgress(task),
+	})
+}
+
+// CreateChecklistItem - POST /api/v1/projects/:id/tasks/:taskId/checklist
+// Pozíció nélkül a lista végére kerül
+func (h *ChecklistHandler) CreateChecklistItem(c *fiber.Ctx) error {
+	currentUserID := c.Locals("userID").(uint)
+
+	task, err := h.loadChecklistTask(c, "member")
+	if err != nil {
+		return err
+	}
+
+	var req models.ChecklistItemCreateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(models.ChecklistListResponse{
+			Success: false,
+			Message: "Invalid request body",
+		})
+	}
+
+	// Validáció
+	if err := validateChecklistTitle(req.Title); err != nil {
+		return err
+	}
+
+	item := models.TaskChecklistItem{
+		TaskID:    task.ID,
+		Title:     strings.TrimSpace(req.Title),
+		CreatedBy: currentUserID,
+	}
+
+	if err := h.checklistService.CreateItem(&item, req.Position); err != nil {
+		return c.Status(500).JSON(models.ChecklistListResponse{
+			Success: false,
+			Message: "Error creating checklist item",
+		})
+	}
+
+	return c.Status(201).JSON(models.ChecklistListResponse{
+		Success:  true,
+		Message:  "Checklist item created successfully",
+		Item:     &item,
+		Progress: h.taskProgress(task),
+	})
+}
+
+// UpdateChecklistItem - PUT /api/v1/projects/:id/tasks/:taskId/checklist/:itemId
+// Cím, kész jelző és pozíció módosítása
+func (h *ChecklistHandler) UpdateChecklistItem(c *fiber.Ctx) error {
+	currentUserID := c.Locals("userID").(uint)
+
+	task, err := h.loadChecklistTask(c, "member")
+	if err != nil {
+		return err
+	}
+
+	item, err := h.loadChecklistItem(c, task)
+	if err != nil {
+		return err
+	}
+
+	var req models.ChecklistItemUpdateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(models.ChecklistListResponse{
+			Success: false,
+			Message: "Invalid request body",
+		})
+	}
+
+	// Validáció
+	if req.Title != nil {
+		if err := validateChecklistTitle(*req.Title); err != nil {
+			return err
+		}
+		title := strings.TrimSpace(*req.Title)
+		req.Title = &title
+	}
+
+	if err := h.checklistService.UpdateItem(item, &req, currentUserID); err != nil {
+		return c.Status(500).JSON(models.ChecklistListResponse{
+			Success: false,
+			Message: "Error updating checklist item",
+		})
+	}
+
+	return c.JSON(models.ChecklistListResponse{
+		Success:  true,
+		Message:  "Checklist item updated successfully",
+		Item:     item,
+		Progress: h.taskProgress(task),
+	})
+}
+
+// DeleteChecklistItem - DELETE /api/v1/projects/:id/tasks/:taskId/checklist/:itemId
+func (h *ChecklistHandler) DeleteChecklistItem(c *fiber.Ctx) error {
+	task, err := h.loadChecklistTask(c, "member")
+	if err != nil {
+		return err
+	}
+
+	item, err := h.loadChecklistItem(c, task)
+	if err != nil {
+		return err
+	}
+
+	if err := h.checklistService.DeleteItem(item); err != nil {
+		return c.Status(500).JSON(models.ChecklistListResponse{
+			Success: false,
+			Message: "Error deleting checklist item",
+		})
+	}
+
+	return c.JSON(models.ChecklistListResponse{
+		Success:  true,
+		Message:  "Checklist item deleted successfully",
+		Progress: h.taskProgress(task),
+	})
+}
@@ -0,0 +1,369 @@
+package handlers
+
+import (
+	"dev-bridge-manager/internal/database"
+	"dev-bridge-manager/internal/models"
+	"dev-bridge-manager/internal/sanitize"
+	"dev-bridge-manager/internal/services"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type CommentHandler struct {
+	permissionService *services.PermissionService
+	kanbanService     *services.KanbanService
+	commentService    *services.CommentService
+}
+
+func NewCommentHandler() *CommentHandler {
+	return &CommentHandler{
+		permissionService: services.NewPermissionService(),
+		kanbanService:     services.NewKanbanService(),
+		commentService:    services.NewCommentService(),
+	}
+}
+
+// requireComments - ellenőrzi, hogy a projekt board-ján engedélyezettek-e a kommentek
+func requireComments(ks *services.KanbanService, projectID uint) error {
+	board, err := ks.GetOrCreateBoard(projectID)
+	if err != nil {
+		return fiber.NewError(500, "Error loading board settings")
+	}
+	if !board.Settings.EnableComments {
+		return fiber.NewError(409, "Comments are disabled for this project")
+	}
+	return nil
+}
+
+// validateCommentContent - a komment tartalma nem lehet üres
+func validateCommentContent(content string) error {
+	if strings.TrimSpace(content) == "" {
+		return fiber.NewError(400, "Comment content is required")
+	}
+	return nil
+}
+
+// loadProjectComment - projekt és komment azonosító beolvasása, a komment betöltése
+func (h *CommentHandler) loadProjectComment(c *fiber.Ctx, minRole string) (*models.TaskComment, error) {
+	currentUserID := c.Locals("userID").(uint)
+
+	projectID, err := parseIDParam(c, "id")
+	if err != nil {
+		return nil, fiber.NewError(400, "Invalid project ID")
+	}
+
+	commentID, err := parseIDParam(c, "commentId")
+	if err != nil {
+		return nil, fiber.NewError(400, "Invalid comment ID")
+	}
+
+	if err := requireProjectRole(h.permissionService, currentUserID, projectID, minRole); err != nil {
+		return nil, err
+	}
+
+	comment, err := h.commentService.LoadComment(projectID, commentID)
+	if err != nil {
+		return nil, fiber.NewError(404, "Comment not found")
+	}
+	return comment, nil
+}
+
+// commentResult - a frissített komment újratöltése és visszaadása
+func (h *CommentHandler) commentResult(c *fiber.Ctx, comment *models.TaskComment, message string) error {
+	currentUserID := c.Locals("userID").(uint)
+
+	updated, err := h.commentService.LoadComment(comment.ProjectID, comment.ID)
+	if err != nil {
+		return c.Status(500).JSON(models.CommentListResponse{
+			Success: false,
+			Message: "Comment saved but failed to load details",
+		})
+	}
+
+	response := updated.ToResponse(currentUserID)
+	return c.JSON(models.CommentListResponse{
+		Success: true,
+		Message: message,
+		Comment: &response,
+	})
+}
+
+// GetTaskComments - GET /api/v1/projects/:id/tasks/:taskId/comments
+// Kitűzött kommentek elöl, utána időrendben
+func (h *CommentHandler) GetTaskComments(c *fiber.Ctx) error {
+	currentUserID := c.Locals("userID").(uint)
+
+	projectID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(400).JSON(models.CommentListResponse{
+			Success: false,
+			Message: "Invalid project ID",
+		})
+	}
+
+	taskID, err := parseIDParam(c, "taskId")
+	if err != nil {
+		return c.Status(400).JSON(models.CommentListResponse{
+			Success: false,
+			Message: "Invalid task ID",
+		})
+	}
+
+	if err := requireProjectRole(h.permissionService, currentUserID, projectID, "viewer"); err != nil {
+		return err
+	}
+
+	comments, err := h.commentService.ListTaskComments(projectID, taskID)
+	if err != nil {
+		return c.Status(500).JSON(models.CommentListResponse{
+			Success: false,
+			Message: "Error fetching comments",
+		})
+	}
+
+	response := make([]models.CommentResponse, 0, len(comments))
+	for i := range comments {
+		response = append(response, comments[i].ToResponse(currentUserID))
+	}
+
+	return c.JSON(models.CommentListResponse{
+		Success:  true,
+		Message:  "Comments retrieved successfully",
+		Comments: response,
+		Count:    len(response),
+	})
+}
+
+// CreateComment - POST /api/v1/projects/:id/tasks/:taskId/comments
+func (h *CommentHandler) CreateComment(c *fiber.Ctx) error {
+	currentUserID := c.Locals("userID").(uint)
+
+	projectID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(400).JSON(models.CommentListResponse{
+			Success: false,
+			Message: "Invalid project ID",
+		})
+	}
+
+	taskID, err := parseIDParam(c, "taskId")
+	if err != nil {
+		return c.Status(400).JSON(models.CommentListResponse{
+			Success: false,
+			Message: "Invalid task ID",
+		})
+	}
+
+	if err := requireProjectRole(h.permissionService, currentUserID, projectID, "member"); err != nil {
+		return err
+	}
+	if err := requireComments(h.kanbanService, projectID); err != nil {
+		return err
+	}
+
+	var req models.CommentCreateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(models.CommentListResponse{
+			Success: false,
+			Message: "Invalid request body",
+		})
+	}
+
+	// A HTML tartalom tisztítása, a plain text tartalom belőle származik
+	htmlContent, content := sanitize.RichText(req.HTMLContent, req.Content)
+
+	// Validáció
+	if err := validateCommentContent(content); err != nil {
+		return err
+	}
+
+	// Task létezésének ellenőrzése
+	var task models.Task
+	if err := database.GetDB().Where("id = ? AND project_id = ?", taskID, projectID).First(&task).Error; err != nil {
+		return c.Status(404).JSON(models.CommentListResponse{
+			Success: false,
+			Message: "Task not found",
+		})
+	}
+
+	comment := models.TaskComment{
+		TaskID:      taskID,
+		ProjectID:   projectID,
+		UserID:      currentUserID,
+		Content:     content,
+		HTMLContent: htmlContent,
+	}
+
+	if err := h.commentService.CreateComment(&comment); err != nil {
+		return c.Status(500).JSON(models.CommentListResponse{
+			Success: false,
+			Message: "Error creating comment",
+		})
+	}
+
+	c.Status(201)
+	return h.commentResult(c, &comment, "Comment created successfully")
+}
+
+// UpdateComment - PUT /api/v1/projects/:id/comments/:commentId
+// Csak a szerző szerkesztheti, az előző változat megmarad
+func (h *CommentHandler) UpdateComment(c *fiber.Ctx) error {
+	currentUserID := c.Locals("userID").(uint)
+
+	comment, err := h.loadProjectComment(c, "member")
+	if err != nil {
+		return err
+	}
+
+	if comment.UserID != currentUserID {
+		return c.Status(403).JSON(models.CommentListResponse{
+			Success: false,
+			Message: "Only the author can edit this comment",
+		})
+	}
+
+	var req models.CommentUpdateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(models.CommentListResponse{
+			Success: false,
+			Message: "Invalid request body",
+		})
+	}
+
+	htmlContent, content := sanitize.RichText(req.HTMLContent, req.Content)
+	if err := validateCommentContent(content); err != nil {
+		return err
+	}
+
+	if err := h.commentService.UpdateComment(comment, content, htmlContent, currentUserID); err != nil {
+		return c.Status(500).JSON(models.CommentListResponse{
+			Success: false,
+			Message: "Error updating comment",
+		})
+	}
+
+	return h.commentResult(c, comment, "Comment updated successfully")
+}
+
+// DeleteComment - DELETE /api/v1/projects/:id/comments/:commentId
+// A szerző vagy a projekt manager/owner törölheti
+func (h *CommentHandler) DeleteComment(c *fiber.Ctx) error {
+	currentUserID := c.Locals("userID").(uint)
+
+	comment, err := h.loadProjectComment(c, "viewer")
+	if err != nil {
+		return err
+	}
+
+	if comment.UserID != currentUserID {
+		if err := requireProjectRole(h.permissionService, currentUserID, comment.ProjectID, "manager"); err != nil {
+			return err
+		}
+	}
+
+	if err := h.commentService.DeleteComment(comment.ID); err != nil {
+		return c.Status(500).JSON(models.CommentListResponse{
+			Success: false,
+			Message: "Error deleting comment",
+		})
+	}
+
+	return c.JSON(models.CommentListResponse{
+		Success: true,
+		Message: "Comment deleted successfully",
+	})
+}
+
+// GetCommentRevisions - GET /api/v1/projects/:id/comments/:commentId/revisions
+func (h *CommentHandler) GetCommentRevisions(c *fiber.Ctx) error {
+	comment, err := h.loadProjectComment(c, "viewer")
+	if err != nil {
+		return err
+	}
+
+	revisions, err := h.commentService.GetRevisions(comment.ID)
+	if err != nil {
+		return c.Status(500).JSON(models.CommentListResponse{
+			Success: false,
+			Message: "Error fetching comment revisions",
+		})
+	}
+
+	return c.JSON(models.CommentListResponse{
+		Success:   true,
+		Message:   "Comment revisions retrieved successfully",
+		Revisions: revisions,
+		Count:     len(revisions),
+	})
+}
+
+// ReactToComment - POST /api/v1/projects/:id/comments/:commentId/react
+// Emoji reakció váltogatása; a frontend like/unlike értéke a 👍 reakciót kezeli
+func (h *CommentHandler) ReactToComment(c *fiber.Ctx) error {
+	currentUserID := c.Locals("userID").(uint)
+
+	comment, err := h.loadProjectComment(c, "member")
+	if err != nil {
+		return err
+	}
+
+	var req models.CommentReactRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(models.CommentListResponse{
+			Success: false,
+			Message: "Invalid request body",
+		})
+	}
+
+	emoji := strings.TrimSpace(req.Emoji)
+	var add *bool
+	switch req.Reaction {
+	case "":
+	case "like", "unlike":
+		value := req.Reaction == "like"
+		add = &value
+		if emoji == "" {
+			emoji = models.LikeEmoji
+		}
+	default:
+		return fiber.NewError(400, "Reaction must be 'like' or 'unlike'")
+	}
+
+	if !services.ValidEmoji(emoji) {
+		return fiber.NewError(400, "A single emoji is required")
+	}
+
+	if err := h.commentService.SetReaction(comment.ID, currentUserID, emoji, add); err != nil {
+		return c.Status(500).JSON(models.CommentListResponse{
+			Success: false,
+			Message: "Error saving reaction",
+		})
+	}
+
+	return h.commentResult(c, comment, "Reaction updated successfully")
+}
+
+// TogglePinComment - PUT /api/v1/projects/:id/comments/:commentId/pin
+// Kitűzés/levétel - projekt manager jogosultság szükséges
+func (h *CommentHandler) TogglePinComment(c *fiber.Ctx) error {
+	currentUserID := c.Locals("userID").(uint)
+
+	comment, err := h.loadProjectComment(c, "manager")
+	if err != nil {
+		return err
+	}
+
+	if err := h.commentService.TogglePin(comment, currentUserID); err != nil {
+		return c.Status(500).JSON(models.CommentListResponse{
+			Success: false,
+			Message: "Error updating comment pin",
+		})
+	}
+
+	message := "Comment pinned successfully"
+	if comment.IsPinned {
+		message = "Comment unpinned successfully"
+	}
+	return h.commentResult(c, comment, message)
+}
@@ -0,0 +1,638 @@
+package handlers
+
+import (
+	"dev-bridge-manager/internal/database"
+	"dev-bridge-manager/internal/models"
+	"dev-bridge-manager/internal/services"
+	"slices"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+type KanbanHandler struct {
+	permissionService *services.PermissionService
+	kanbanService     *services.KanbanService
+	taskService       *services.TaskService
+}
+
+func NewKanbanHandler() *KanbanHandler {
+	return &KanbanHandler{
+		permissionService: services.NewPermissionService(),
+		kanbanService:     services.NewKanbanService(),
+		taskService:       services.NewTaskService(),
+	}
+}
+
+// validateColumnCreateRequest - egyszerű validáció validator csomag nélkül
+func (h *KanbanHandler) validateColumnCreateRequest(req *models.KanbanColumnCreateRequest) error {
+	if strings.TrimSpace(req.Title) == "" {
+		return fiber.NewError(400, "Column title is required")
+	}
+	if len(req.Title) > 100 {
+		return fiber.NewError(400, "Column title must be less than 100 characters")
+	}
+	if req.Position != nil && *req.Position < 0 {
+		return fiber.NewError(400, "Position cannot be negative")
+	}
+	if req.MaxTasks != nil && *req.MaxTasks < 0 {
+		return fiber.NewError(400, "Max tasks cannot be negative")
+	}
+	return validateColumnCategory(req.Category)
+}
+
+// validateColumnUpdateRequest - egyszerű validáció validator csomag nélkül
+func (h *KanbanHandler) validateColumnUpdateRequest(req *models.KanbanColumnUpdateRequest) error {
+	if req.Title != nil && strings.TrimSpace(*req.Title) == "" {
+		return fiber.NewError(400, "Column title cannot be empty")
+	}
+	if req.Title != nil && len(*req.Title) > 100 {
+		return fiber.NewError(400, "Column title must be less than 100 characters")
+	}
+	if req.Position != nil && *req.Position < 0 {
+		return fiber.NewError(400, "Position cannot be negative")
+	}
+	if req.MaxTasks != nil && *req.MaxTasks < 0 {
+		return fiber.NewError(400, "Max tasks cannot be negative")
+	}
+	if req.Category != nil {
+		return validateColumnCategory(*req.Category)
+	}
+	return nil
+}
+
+// validateColumnCategory - a kategória üres (alapértelmezett) vagy a TaskStatus értékek egyike
+func validateColumnCategory(category string) error {
+	if category != "" && !slices.Contains(models.ValidColumnCategories, category) {
+		return fiber.NewError(400, "Category must be one of: todo, in_progress, review, done")
+	}
+	return nil
+}
+
+// renumberColumns - az oszlopok pozícióinak újraszámozása a megadott sorrendben
+func renumberColumns(tx *gorm.DB, columns []models.KanbanColumn) error {
+	for i := range columns {
+		if columns[i].Position == i {
+			continue
+		}
+		columns[i].Position = i
+		if err := tx.Model(&columns[i]).Update("position", i).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadBoardColumns - a board oszlopai pozíció szerint rendezve
+func loadBoardColumns(tx *gorm.DB, boardID uint) ([]models.KanbanColumn, error) {
+	var columns []models.KanbanColumn
+	err := tx.Where("board_id = ?", boardID).
+		Order("position ASC, id ASC").
+		Find(&columns).Error
+	return columns, err
+}
+
+// moveColumnTo - az oszlopot a megadott indexre helyezi és újraszámozza a board-ot
+func moveColumnTo(tx *gorm.DB, boardID, columnID uint, position int) error {
+	columns, err := loadBoardColumns(tx, boardID)
+	if err != nil {
+		return err
+	}
+
+	index := slices.IndexFunc(columns, func(col models.KanbanColumn) bool { return col.ID == columnID })
+	if index < 0 {
+		return gorm.ErrRecordNotFound
+	}
+	moved := columns[index]
+	columns = slices.Delete(columns, index, index+1)
+
+	if position > len(columns) {
+		position = len(columns)
+	}
+	columns = slices.Insert(columns, position, moved)
+
+	return renumberColumns(tx, columns)
+}
+
+// buildBoardResponse - board összeállítása oszlopokkal és a hozzájuk tartozó taskokkal
+func buildBoardResponse(ts *services.TaskService, board *models.KanbanBoard) (*models.KanbanBoardResponse, error) {
+	var tasks []models.Task
+	err := database.GetDB().Preload("Assignee").Preload("Tags").
+		Where("project_id = ? AND archived_at IS NULL", board.ProjectID).
+		Order("column_id ASC, position ASC").
+		Find(&tasks).Error
+	if err != nil {
+		return nil, err
+	}
+
+	responses, err := taskResponses(ts, tasks)
+	if err != nil {
+		return nil, err
+	}
+
+	tasksByColumn := make(map[uint][]models.TaskResponse)
+	for _, task := range responses {
+		tasksByColumn[task.ColumnID] = append(tasksByColumn[task.ColumnID], task)
+	}
+
+	columns := make([]models.KanbanColumnResponse, 0, len(board.Columns))
+	for i := range board.Columns {
+		columns = append(columns, board.Columns[i].ToResponse(tasksByColumn[board.Columns[i].ID]))
+	}
+
+	return &models.KanbanBoardResponse{
+		ID:        board.ID,
+		ProjectID: board.ProjectID,
+		Columns:   columns,
+		Settings:  board.Settings,
+		CreatedAt: board.CreatedAt,
+		UpdatedAt: board.UpdatedAt,
+	}, nil
+}
+
+// GetBoard - GET /api/v1/projects/:id/kanban
+func (h *KanbanHandler) GetBoard(c *fiber.Ctx) error {
+	currentUserID := c.Locals("userID").(uint)
+
+	projectID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(400).JSON(models.KanbanResponse{
+			Success: false,
+			Message: "Invalid project ID",
+		})
+	}
+
+	if err := requireProjectRole(h.permissionService, currentUserID, projectID, "viewer"); err != nil {
+		return err
+	}
+
+	// Projekt létezésének ellenőrzése
+	var project models.Project
+	if err := database.GetDB().First(&project, projectID).Error; err != nil {
+		return c.Status(404).JSON(models.KanbanResponse{
+			Success: false,
+			Message: "Project not found",
+		})
+	}
+
+	board, err := h.kanbanService.GetOrCreateBoard(projectID)
+	if err != nil {
+		return c.Status(500).JSON(models.KanbanResponse{
+			Success: false,
+			Message: "Error fetching kanban board",
+		})
+	}
+
+	response, err := buildBoardResponse(h.taskService, board)
+	if err != nil {
+		return c.Status(500).JSON(models.KanbanResponse{
+			Success: false,
+			Message: "Error fetching board tasks",
+		})
+	}
+
+	return c.JSON(models.KanbanResponse{
+		Success: true,
+		Message: "Kanban board retrieved successfully",
+		Board:   response,
+	})
+}
+
+// UpdateBoard - PUT /api/v1/projects/:id/kanban
+// Jelenleg a board beállításai módosíthatók
+func (h *KanbanHandler) UpdateBoard(c *fiber.Ctx) error {
+	currentUserID := c.Locals("userID").(uint)
+
+	projectID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(400).JSON(models.KanbanResponse{
+			Success: false,
+			Message: "Invalid project ID",
+		})
+	}
+
+	if err := requireProjectRole(h.permissionService, currentUserID, projectID, "manager"); err != nil {
+		return err
+	}
+
+	var req models.KanbanBoardUpdateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(models.KanbanResponse{
+			Success: false,
+			Message: "Invalid request body",
+		})
+	}
+
+	board, err := h.kanbanService.GetOrCreateBoard(projectID)
+	if err != nil {
+		return c.Status(404).JSON(models.KanbanResponse{
+			Success: false,
+			Message: "Kanban board not found",
+		})
+	}
+
+	// Csak a megadott beállítások frissítése
+	updates := make(map[string]interface{})
+	if settings := req.Settings; settings != nil {
+		if settings.EnableWipLimits != nil {
+			updates["enable_wip_limits"] = *settings.EnableWipLimits
+		}
+		if settings.EnableTimeTracking != nil {
+			updates["enable_time_tracking"] = *settings.EnableTimeTracking
+		}
+		if settings.EnableComments != nil {
+			updates["enable_comments"] = *settings.EnableComments
+		}
+		if settings.EnablePriorities != nil {
+			updates["enable_priorities"] = *settings.EnablePriorities
+		}
+		if settings.EnableTags != nil {
+			updates["enable_tags"] = *settings.EnableTags
+		}
+		if settings.DefaultEstimateUnit != nil {
+			if !slices.Contains(models.ValidEstimateUnits, *settings.DefaultEstimateUnit) {
+				return fiber.NewError(400, "Default estimate unit must be one of: hours, days, points")
+			}
+			updates["default_estimate_unit"] = *settings.DefaultEstimateUnit
+		}
+		if settings.DefaultBillable != nil {
+			updates["default_billable"] = *settings.DefaultBillable
+		}
+		if settings.EnforceWorkflow != nil {
+			updates["enforce_workflow"] = *settings.EnforceWorkflow
+		}
+	}
+
+	if len(updates) > 0 {
+		if err := database.GetDB().Model(board).Updates(updates).Error; err != nil {
+			return c.Status(500).JSON(models.KanbanResponse{
+				Success: false,
+				Message: "Error updating kanban board",
+			})
+		}
+	}
+
+	// Frissített board visszatöltése
+	board, err = h.kanbanService.GetOrCreateBoard(projectID)
+	if err != nil {
+		return c.Status(500).JSON(models.KanbanResponse{
+			Success: false,
+			Message: "Board updated but failed to load details",
+		})
+	}
+
+	response, err := buildBoardResponse(h.taskService, board)
+	if err != nil {
+		return c.Status(500).JSON(models.KanbanResponse{
+			Success: false,
+			Message: "Error fetching board tasks",
+		})
+	}
+
+	return c.JSON(models.KanbanResponse{
+		Success: true,
+		Message: "Kanban board updated successfully",
+		Board:   response,
+	})
+}
+
+// CreateColumn - POST /api/v1/projects/:id/kanban/columns
+func (h *KanbanHandler) CreateColumn(c *fiber.Ctx) error {
+	currentUserID := c.Locals("userID").(uint)
+
+	projectID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(400).JSON(models.KanbanResponse{
+			Success: false,
+			Message: "Invalid project ID",
+		})
+	}
+
+	if err := requireProjectRole(h.permissionService, currentUserID, projectID, "manager"); err != nil {
+		return err
+	}
+
+	var req models.KanbanColumnCreateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(models.KanbanResponse{
+			Success: false,
+			Message: "Invalid request body",
+		})
+	}
+
+	// Validáció
+	if err := h.validateColumnCreateRequest(&req); err != nil {
+		return err
+	}
+
+	board, err := h.kanbanService.GetOrCreateBoard(projectID)
+	if err != nil {
+		return c.Status(404).JSON(models.KanbanResponse{
+			Success: false,
+			Message: "Kanban board not found",
+		})
+	}
+
+	// Default szín és kategória beállítása
+	if req.Color == "" {
+		req.Color = "#6B7280"
+	}
+	if req.Category == "" {
+		req.Category = "todo"
+	}
+
+	column := models.KanbanColumn{
+		BoardID:  board.ID,
+		Title:    strings.TrimSpace(req.Title),
+		Color:    req.Color,
+		Position: len(board.Columns),
+		Category: req.Category,
+	}
+	if req.MaxTasks != nil && *req.MaxTasks > 0 {
+		column.MaxTasks = req.MaxTasks
+	}
+
+	err = database.GetDB().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&column).Error; err != nil {
+			return err
+		}
+		// Alapértelmezetten a board végére kerül
+		if req.Position != nil && *req.Position < column.Position {
+			return moveColumnTo(tx, board.ID, column.ID, *req.Position)
+		}
+		return nil
+	})
+	if err != nil {
+		return c.Status(500).JSON(models.KanbanResponse{
+			Success: false,
+			Message: "Error creating column",
+		})
+	}
+
+	database.GetDB().First(&column, column.ID)
+	response := column.ToResponse(nil)
+
+	return c.Status(201).JSON(models.KanbanResponse{
+		Success: true,
+		Message: "Column created successfully",
+		Column:  &response,
+	})
+}
+
+// UpdateColumn - PUT /api/v1/projects/:id/kanban/columns/:columnId
+func (h *KanbanHandler) UpdateColumn(c *fiber.Ctx) error {
+	currentUserID := c.Locals("userID").(uint)
+
+	projectID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(400).JSON(models.KanbanResponse{
+			Success: false,
+			Message: "Invalid project ID",
+		})
+	}
+
+	columnID, err := parseIDParam(c, "columnId")
+	if err != nil {
+		return c.Status(400).JSON(models.KanbanResponse{
+			Success: false,
+			Message: "Invalid column ID",
+		})
+	}
+
+	if err := requireProjectRole(h.permissionService, currentUserID, projectID, "manager"); err != nil {
+		return err
+	}
+
+	var req models.KanbanColumnUpdateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(models.KanbanResponse{
+			Success: false,
+			Message: "Invalid request body",
+		})
+	}
+
+	// Validáció
+	if err := h.validateColumnUpdateRequest(&req); err != nil {
+		return err
+	}
+
+	column, err := h.kanbanService.GetProjectColumn(database.GetDB(), projectID, columnID)
+	if err != nil {
+		return c.Status(404).JSON(models.KanbanResponse{
+			Success: false,
+			Message: "Column not found",
+		})
+	}
+
+	// Csak a megadott mezők frissítése
+	updates := make(map[string]interface{})
+	if req.Title != nil {
+		updates["title"] = strings.TrimSpace(*req.Title)
+	}
+	if req.Color != nil {
+		updates["color"] = *req.Color
+	}
+	if req.Category != nil && *req.Category != "" {
+		updates["category"] = *req.Category
+	}
+	if req.MaxTasks != nil {
+		// 0 = WIP limit törlése
+		if *req.MaxTasks == 0 {
+			updates["max_tasks"] = nil
+		} else {
+			updates["max_tasks"] = *req.MaxTasks
+		}
+	}
+
+	err = database.GetDB().Transaction(func(tx *gorm.DB) error {
+		if len(updates) > 0 {
+			if err := tx.Model(column).Updates(updates).Error; err != nil {
+				return err
+			}
+		}
+		// A task státusza az oszlop kategóriáját követi
+		if category, ok := updates["category"]; ok {
+			err := tx.Model(&models.Task{}).
+				Where("column_id = ?", column.ID).
+				UpdateColumn("status", category).Error
+			if err != nil {
+				return err
+			}
+		}
+		if req.Position != nil && *req.Position != column.Position {
+			return moveColumnTo(tx, column.BoardID, column.ID, *req.Position)
+		}
+		return nil
+	})
+	if err != nil {
+		return c.Status(500).JSON(models.KanbanResponse{
+			Success: false,
+			Message: "Error updating column",
+		})
+	}
+
+	// Frissített oszlop visszatöltése
+	var updatedColumn models.KanbanColumn
+	database.GetDB().First(&updatedColumn, columnID)
+	response := updatedColumn.ToResponse(nil)
+
+	return c.JSON(models.KanbanResponse{
+		Success: true,
+		Message: "Column updated successfully",
+		Column:  &response,
+	})
+}
+
+// DeleteColumn - DELETE /api/v1/projects/:id/kanban/columns/:columnId
+// Csak üres oszlop törölhető
+func (h *KanbanHandler) DeleteColumn(c *fiber.Ctx) error {
+	currentUserID := c.Locals("userID").(uint)
+
+	projectID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(400).JSON(models.KanbanResponse{
+			Success: false,
+			Message: "Invalid project ID",
+		})
+	}
+
+	columnID, err := parseIDParam(c, "columnId")
+	if err != nil {
+		return c.Status(400).JSON(models.KanbanResponse{
+			Success: false,
+			Message: "Invalid column ID",
+		})
+	}
+
+	if err := requireProjectRole(h.permissionService, currentUserID, projectID, "manager"); err != nil {
+		return err
+	}
+
+	column, err := h.kanbanService.GetProjectColumn(database.GetDB(), projectID, columnID)
+	if err != nil {
+		return c.Status(404).JSON(models.KanbanResponse{
+			Success: false,
+			Message: "Column not found",
+		})
+	}
+
+	var taskCount int64
+	if err := database.GetDB().Model(&models.Task{}).Where("column_id = ?", columnID).Count(&taskCount).Error; err != nil {
+		return c.Status(500).JSON(models.KanbanResponse{
+			Success: false,
+			Message: "Error checking column tasks",
+		})
+	}
+	if taskCount > 0 {
+		return c.Status(409).JSON(models.KanbanResponse{
+			Success: false,
+			Message: "Column still contains tasks (including archived ones); move or purge them before deleting the column",
+		})
+	}
+
+	err = database.GetDB().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(column).Error; err != nil {
+			return err
+		}
+		columns, err := loadBoardColumns(tx, column.BoardID)
+		if err != nil {
+			return err
+		}
+		return renumberColumns(tx, columns)
+	})
+	if err != nil {
+		return c.Status(500).JSON(models.KanbanResponse{
+			Success: false,
+			Message: "Error deleting column",
+		})
+	}
+
+	return c.JSON(models.KanbanResponse{
+		Success: true,
+		Message: "Column deleted successfully",
+	})
+}
+
+// ReorderColumns - PUT /api/v1/projects/:id/kanban/columns/reorder
+func (h *KanbanHandler) ReorderColumns(c *fiber.Ctx) error {
+	currentUserID := c.Locals("userID").(uint)
+
+	projectID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(400).JSON(models.KanbanResponse{
+			Success: false,
+			Message: "Invalid project ID",
+		})
+	}
+
+	if err := requireProjectRole(h.permissionService, currentUserID, projectID, "manager"); err != nil {
+		return err
+	}
+
+	var req models.KanbanColumnReorderRequest
+	if err := c.BodyParser(&req); err != nil || len(req.Orders) == 0 {
+		return c.Status(400).JSON(models.KanbanResponse{
+			Success: false,
+			Message: "Invalid request body",
+		})
+	}
+
+	board, err := h.kanbanService.GetOrCreateBoard(projectID)
+	if err != nil {
+		return c.Status(404).JSON(models.KanbanResponse{
+			Success: false,
+			Message: "Kanban board not found",
+		})
+	}
+
+	// A kért pozíciók szerint rendezzük, a nem említett oszlopok a végére kerülnek
+	requested := make(map[uint]int, len(req.Orders))
+	for _, order := range req.Orders {
+		requested[order.ColumnID] = order.Position
+	}
+	for columnID := range requested {
+		if !slices.ContainsFunc(board.Columns, func(col models.KanbanColumn) bool { return col.ID == columnID }) {
+			return c.Status(400).JSON(models.KanbanResponse{
+				Success: false,
+				Message: "Column does not belong to this board",
+			})
+		}
+	}
+
+	columns := slices.Clone(board.Columns)
+	slices.SortStableFunc(columns, func(a, b models.KanbanColumn) int {
+		posA, okA := requested[a.ID]
+		posB, okB := requested[b.ID]
+		switch {
+		case okA && okB:
+			return posA - posB
+		case okA:
+			return -1
+		case okB:
+			return 1
+		}
+		return a.Position - b.Position
+	})
+
+	err = database.GetDB().Transaction(func(tx *gorm.DB) error {
+		return renumberColumns(tx, columns)
+	})
+	if err != nil {
+		return c.Status(500).JSON(models.KanbanResponse{
+			Success: false,
+			Message: "Error reordering columns",
+		})
+	}
+
+	response := make([]models.KanbanColumnResponse, 0, len(columns))
+	for i := range columns {
+		response = append(response, columns[i].ToResponse(nil))
+	}
+
+	return c.JSON(models.KanbanResponse{
+		Success: true,
+		Message: "Columns reordered successfully",
+		Columns: response,
+	})
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"dev-bridge-manager/internal/models"
+	"dev-bridge-manager/internal/services"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type LoginLockoutHandler struct {
+	loginAttemptService *services.LoginAttemptService
+	permissionService   *services.PermissionService
+}
+
+func NewLoginLockoutHandler() *LoginLockoutHandler {
+	return &LoginLockoutHandler{
+		loginAttemptService: services.NewLoginAttemptService(),
+		permissionService:   services.NewPermissionService(),
+	}
+}
+
+// loadUser - a :id paraméter userének betöltése
+func (h *LoginLockoutHandler) loadUser(c *fiber.Ctx) (*models.User, error) {
+	userID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return nil, fiber.NewError(400, "Invalid user ID")
+	}
+
+	user, err := h.permissionService.GetUserWithPermissions(uint(userID))
+	if err != nil {
+		return nil, fiber.NewError(404, "User not found")
+	}
+	return user, nil
+}
+
+// GetUserLockouts - GET /api/v1/users/:id/lockouts
+// A fiók aktuális zárolási állapota és a legutóbbi zárolási események (fiók és IP hatókörrel)
+func (h *LoginLockoutHandler) GetUserLockouts(c *fiber.Ctx) error {
+	user, err := h.loadUser(c)
+	if err != nil {
+		return err
+	}
+
+	until, err := h.loginAttemptService.AccountLockedUntil(user, time.Now())
+	if err != nil {
+		return c.Status(500).JSON(models.LoginLockoutListResponse{
+			Success: false,
+			Message: "Error checking account lockout",
+		})
+	}
+
+	lockouts, err := h.loginAttemptService.ListLockouts(user.ID)
+	if err != nil {
+		return c.Status(500).JSON(models.LoginLockoutListResponse{
+			Success: false,
+			Message: "Error fetching lockouts",
+		})
+	}
+
+	response := models.LoginLockoutListResponse{
+		Success:  true,
+		Message:  "Lockouts retrieved successfully",
+		Locked:   !until.IsZero(),
+		Lockouts: lockouts,
+		Count:    len(lockouts),
+	}
+	if response.Locked {
+		response.LockedUntil = &until
+	}
+	return c.JSON(response)
+}
+
+// UnlockUser - POST /api/v1/users/:id/unlock
+// Admin feloldás: a fiók zárolása és a várakozási idő növekedése is nullázódik.
+// Az IP címek zárolását nem érinti.
+func (h *LoginLockoutHandler) UnlockUser(c *fiber.Ctx) error {
+	currentUserID := c.Locals("userID").(uint)
+
+	user, err := h.loadUser(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.loginAttemptService.Unlock(user, currentUserID, time.Now()); err != nil {
+		return c.Status(500).JSON(models.LoginLockoutListResponse{
+			Success: false,
+			Message: "Error unlocking account",
+		})
+	}
+
+	return c.JSON(models.LoginLockoutListResponse{
+		Success: true,
+		Message: "Account unlocked successfully",
+	})
+}
@@ -0,0 +1,371 @@
+package handlers
+
+import (
+	"dev-bridge-manager/internal/models"
+	"dev-bridge-manager/internal/services"
+	"dev-bridge-manager/internal/types"
+	"errors"
+	"log"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type MFAHandler struct {
+	authService         *services.AuthService
+	loginAttemptService *services.LoginAttemptService
+	mfaService          *services.MFAService
+	permissionService   *services.PermissionService
+	sessionService      *services.SessionService
+}
+
+// MFACodeRequest - TOTP kód vagy helyreállító kód; a challenge végpontoknál a belépéskor kapott mfa_token is
+type MFACodeRequest struct {
+	MFAToken     string `json:"mfa_token"`
+	Code         string `json:"code"`
+	RecoveryCode string `json:"recovery_code"`
+}
+
+type MFADisableRequest struct {
+	Password     string `json:"password" validate:"required"`
+	Code         string `json:"code"`
+	RecoveryCode string `json:"recovery_code"`
+}
+
+func NewMFAHandler() *MFAHandler {
+	return &MFAHandler{
+		authService:         services.NewAuthService(),
+		loginAttemptService: services.NewLoginAttemptService(),
+		mfaService:          services.NewMFAService(),
+		permissionService:   services.NewPermissionService(),
+		sessionService:      services.NewSessionService(),
+	}
+}
+
+// mfaError - a szolgáltatás hibáinak HTTP státusza
+func mfaError(c *fiber.Ctx, err error, fallback string) error {
+	status := 500
+	message := fallback
+	switch {
+	case errors.Is(err, services.ErrInvalidMFACode):
+		status, message = 401, err.Error()
+	case errors.Is(err, services.ErrInvalidMFAChallenge):
+		status, message = 401, err.Error()
+	case errors.Is(err, services.ErrAccountDeactivated):
+		status, message = 403, "Account is deactivated"
+	case errors.Is(err, services.ErrMFARequiredByRole):
+		status, message = 403, err.Error()
+	case errors.Is(err, services.ErrMFAAlreadyEnabled),
+		errors.Is(err, services.ErrMFANotEnabled),
+		errors.Is(err, services.ErrMFANoPendingSetup):
+		status, message = 409, err.Error()
+	case errors.Is(err, services.ErrMFANotConfigured):
+		status, message = 503, err.Error()
+	}
+	return c.Status(status).JSON(types.MFAResponse{
+		Success: false,
+		Message: message,
+	})
+}
+
+// verifyCode - a kódot ellenőrző végpontok közös védelme a találgatás ellen: a kísérlet az ellenőrzés előtt
+// számít a sikertelen belépésekkel együtt, zárolt fióknál vagy IP-nél 429, a hibás kód sikertelen kísérlet,
+// a helyes kód nullázza a fiók számlálóját. true, ha a hibaválasz már elküldve.
+func (h *MFAHandler) verifyCode(c *fiber.Ctx, user *models.User, fallback string, verify func() error) (bool, error) {
+	attempt, err := beginLogin(c, h.loginAttemptService, user.Email)
+	if attempt == nil {
+		return true, err
+	}
+
+	err = verify()
+	if errors.Is(err, services.ErrInvalidMFACode) {
+		return true, loginFailed(c, attempt, &user.ID, err.Error())
+	}
+	if err != nil {
+		// Nem találgatás volt, hanem a kód ellenőrzése nem sikerült
+		if refundErr := attempt.Refund(); refundErr != nil {
+			log.Printf("⚠️  Failed to refund login attempt of user %d: %v", user.ID, refundErr)
+		}
+		return true, mfaError(c, err, fallback)
+	}
+
+	// A kód már elfogadva (és esetleg felhasználva), ezért a számláló hibája nem buktatja el a kérést
+	if err := attempt.Succeed(); err != nil {
+		log.Printf("⚠️  Failed to reset login attempts of user %d: %v", user.ID, err)
+	}
+	return false, nil
+}
+
+// currentUser - a bejelentkezett user role-lal és jogosultságokkal
+func (h *MFAHandler) currentUser(c *fiber.Ctx) (*models.User, error) {
+	user, err := h.permissionService.GetUserWithPermissions(c.Locals("userID").(uint))
+	if err != nil {
+		return nil, fiber.NewError(404, "User not found")
+	}
+	return user, nil
+}
+
+// GetMFAStatus - GET /api/v1/auth/mfa
+// Saját MFA állapot: bekapcsolva, a szerepkör megköveteli-e, hátralévő helyreállító kódok
+func (h *MFAHandler) GetMFAStatus(c *fiber.Ctx) error {
+	user, err := h.currentUser(c)
+	if err != nil {
+		return err
+	}
+
+	remaining, err := h.mfaService.RemainingRecoveryCodes(user.ID)
+	if err != nil {
+		return mfaError(c, err, "Error counting recovery codes")
+	}
+
+	return c.JSON(types.MFAResponse{
+		Success:             true,
+		Message:             "MFA status retrieved",
+		Enabled:             user.MFAEnabled,
+		Required:            user.Role.MFARequired,
+		RemainingRecoveries: remaining,
+	})
+}
+
+// SetupMFA - POST /api/v1/auth/mfa/setup
+// Új TOTP titok és otpauth:// provisioning URI (QR kódként megjeleníthető); az /enable megerősítésig nem aktív
+func (h *MFAHandler) SetupMFA(c *fiber.Ctx) error {
+	user, err := h.currentUser(c)
+	if err != nil {
+		return err
+	}
+	return h.beginSetup(c, user)
+}
+
+// beginSetup - függő titok generálása és visszaadása
+func (h *MFAHandler) beginSetup(c *fiber.Ctx, user *models.User) error {
+	secret, uri, err := h.mfaService.BeginSetup(user)
+	if err != nil {
+		return mfaError(c, err, "Failed to start two-factor setup")
+	}
+
+	return c.JSON(types.MFAResponse{
+		Success:         true,
+		Message:         "Scan the provisioning URI with an authenticator app, then confirm with a code",
+		Required:        user.Role.MFARequired,
+		Secret:          secret,
+		ProvisioningURI: uri,
+	})
+}
+
+// EnableMFA - POST /api/v1/auth/mfa/enable
+// A beállítás megerősítése egy érvényes kóddal; a helyreállító kódok csak ebben a válaszban látszanak
+func (h *MFAHandler) EnableMFA(c *fiber.Ctx) error {
+	user, err := h.currentUser(c)
+	if err != nil {
+		return err
+	}
+
+	var req MFACodeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(types.MFAResponse{
+			Success: false,
+			Message: "Invalid request body",
+		})
+	}
+
+	var codes []string
+	if failed, err := h.verifyCode(c, user, "Failed to enable two-factor authentication", func() (err error) {
+		codes, err = h.mfaService.ConfirmSetup(user, req.Code)
+		return err
+	}); failed {
+		return err
+	}
+
+	return c.JSON(types.MFAResponse{
+		Success:             true,
+		Message:             "Two-factor authentication enabled. Store the recovery codes in a safe place.",
+		Enabled:             true,
+		Required:            user.Role.MFARequired,
+		RecoveryCodes:       codes,
+		RemainingRecoveries: int64(len(codes)),
+	})
+}
+
+// DisableMFA - POST /api/v1/auth/mfa/disable
+// Jelszó és kód (vagy helyreállító kód) szükséges; kötelező MFA-s szerepkörnél nem kapcsolható ki
+func (h *MFAHandler) DisableMFA(c *fiber.Ctx) error {
+	user, err := h.currentUser(c)
+	if err != nil {
+		return err
+	}
+
+	var req MFADisableRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(types.MFAResponse{
+			Success: false,
+			Message: "Invalid request body",
+		})
+	}
+
+	if !user.CheckPassword(req.Password) {
+		return c.Status(400).JSON(types.MFAResponse{
+			Success: false,
+			Message: "Current password is incorrect",
+		})
+	}
+	if user.Role.MFARequired {
+		return mfaError(c, services.ErrMFARequiredByRole, "")
+	}
+	if failed, err := h.verifyCode(c, user, "Failed to verify code", func() error {
+		return h.mfaService.VerifySecondFactor(user, req.Code, req.RecoveryCode)
+	}); failed {
+		return err
+	}
+
+	if err := h.mfaService.Disable(user, false); err != nil {
+		return mfaError(c, err, "Failed to disable two-factor authentication")
+	}
+
+	return c.JSON(types.MFAResponse{
+		Success: true,
+		Message: "Two-factor authentication disabled",
+	})
+}
+
+// RegenerateRecoveryCodes - POST /api/v1/auth/mfa/recovery-codes
+// Új helyreállító kódok egy érvényes TOTP kóddal; a korábbiak érvényüket vesztik
+func (h *MFAHandler) RegenerateRecoveryCodes(c *fiber.Ctx) error {
+	user, err := h.currentUser(c)
+	if err != nil {
+		return err
+	}
+
+	var req MFACodeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(types.MFAResponse{
+			Success: false,
+			Message: "Invalid request body",
+		})
+	}
+
+	if failed, err := h.verifyCode(c, user, "Failed to verify code", func() error {
+		return h.mfaService.VerifyCode(user, req.Code)
+	}); failed {
+		return err
+	}
+
+	codes, err := h.mfaService.RegenerateRecoveryCodes(user)
+	if err != nil {
+		return mfaError(c, err, "Failed to generate recovery codes")
+	}
+
+	return c.JSON(types.MFAResponse{
+		Success:             true,
+		Message:             "Recovery codes regenerated",
+		Enabled:             true,
+		Required:            user.Role.MFARequired,
+		RecoveryCodes:       codes,
+		RemainingRecoveries: int64(len(codes)),
+	})
+}
+
+// VerifyMFALogin - POST /api/v1/auth/mfa/verify
+// A belépés második lépése: mfa_token és TOTP kód (vagy helyreállító kód) után új session és tokenek.
+// A hibás kódok a sikertelen belépésekkel együtt számítanak a fiók és az IP zárolásába (lásd verifyCode).
+func (h *MFAHandler) VerifyMFALogin(c *fiber.Ctx) error {
+	var req MFACodeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(types.AuthResponse{
+			Success: false,
+			Message: "Invalid request body",
+		})
+	}
+
+	user, device, err := h.authService.ValidateMFAChallenge(req.MFAToken, services.MFAChallengeVerify)
+	if err != nil {
+		return mfaError(c, err, "Failed to verify MFA challenge")
+	}
+
+	if failed, err := h.verifyCode(c, user, "Failed to verify code", func() error {
+		return h.mfaService.VerifySecondFactor(user, req.Code, req.RecoveryCode)
+	}); failed {
+		return err
+	}
+
+	return startSession(c, h.authService, user, device, nil, 200, "Login successful")
+}
+
+// EnrollMFA - POST /api/v1/auth/mfa/enroll
+// Kötelező MFA-s szerepkör első belépése: a beállító mfa_token-nel új TOTP titok és provisioning URI
+func (h *MFAHandler) EnrollMFA(c *fiber.Ctx) error {
+	var req MFACodeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(types.MFAResponse{
+			Success: false,
+			Message: "Invalid request body",
+		})
+	}
+
+	user, _, err := h.authService.ValidateMFAChallenge(req.MFAToken, services.MFAChallengeSetup)
+	if err != nil {
+		return mfaError(c, err, "Failed to verify MFA challenge")
+	}
+	return h.beginSetup(c, user)
+}
+
+// ConfirmMFAEnrollment - POST /api/v1/auth/mfa/enroll/confirm
+// A kötelező beállítás megerősítése: bekapcsolja az MFA-t, majd új sessiont és a helyreállító kódokat adja vissza
+func (h *MFAHandler) ConfirmMFAEnrollment(c *fiber.Ctx) error {
+	var req MFACodeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(types.AuthResponse{
+			Success: false,
+			Message: "Invalid request body",
+		})
+	}
+
+	user, device, err := h.authService.ValidateMFAChallenge(req.MFAToken, services.MFAChallengeSetup)
+	if err != nil {
+		return mfaError(c, err, "Failed to verify MFA challenge")
+	}
+
+	var codes []string
+	if failed, err := h.verifyCode(c, user, "Failed to enable two-factor authentication", func() (err error) {
+		codes, err = h.mfaService.ConfirmSetup(user, req.Code)
+		return err
+	}); failed {
+		return err
+	}
+
+	return startSession(c, h.authService, user, device, codes, 200, "Two-factor authentication enabled, login successful")
+}
+
+// ResetUserMFA - DELETE /api/v1/users/:id/mfa
+// Admin visszaállítás elveszett eszköz és helyreállító kódok esetén; a user sessionjei is visszavonódnak.
+// Kötelező MFA-s szerepkörnél a következő belépéskor újra be kell állítani.
+func (h *MFAHandler) ResetUserMFA(c *fiber.Ctx) error {
+	userID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(400).JSON(types.MFAResponse{
+			Success: false,
+			Message: "Invalid user ID",
+		})
+	}
+
+	user, err := h.permissionService.GetUserWithPermissions(uint(userID))
+	if err != nil {
+		return c.Status(404).JSON(types.MFAResponse{
+			Success: false,
+			Message: "User not found",
+		})
+	}
+
+	if err := h.mfaService.Disable(user, true); err != nil {
+		return mfaError(c, err, "Failed to reset two-factor authentication")
+	}
+	if err := h.sessionService.InvalidateUserTokens(user.ID, services.SessionRevokedMFAReset); err != nil {
+		return mfaError(c, err, "Two-factor authentication reset but failed to revoke sessions")
+	}
+
+	return c.JSON(types.MFAResponse{
+		Success:  true,
+		Message:  "Two-factor authentication reset",
+		Required: user.Role.MFARequired,
+	})
+}
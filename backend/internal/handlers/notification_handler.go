@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"dev-bridge-manager/internal/models"
+	"dev-bridge-manager/internal/services"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+type NotificationHandler struct {
+	notificationService *services.NotificationService
+}
+
+func NewNotificationHandler() *NotificationHandler {
+	return &NotificationHandler{
+		notificationService: services.NewNotificationService(),
+	}
+}
+
+// GetNotifications - GET /api/v1/notifications
+// Saját értesítések a legújabbal kezdve; unread=true csak az olvasatlanok, limit alapból és legfeljebb 100
+func (h *NotificationHandler) GetNotifications(c *fiber.Ctx) error {
+	currentUserID := c.Locals("userID").(uint)
+
+	limit := c.QueryInt("limit", services.NotificationListLimit)
+	if limit <= 0 {
+		return c.Status(400).JSON(models.NotificationListResponse{
+			Success: false,
+			Message: "Limit must be a positive number",
+		})
+	}
+
+	notifications, err := h.notificationService.ListNotifications(currentUserID, c.Query("unread") == "true", limit)
+	if err != nil {
+		return c.Status(500).JSON(models.NotificationListResponse{
+			Success: false,
+			Message: "Error fetching notifications",
+		})
+	}
+
+	unread, err := h.notificationService.UnreadCount(currentUserID)
+	if err != nil {
+		return c.Status(500).JSON(models.NotificationListResponse{
+			Success: false,
+			Message: "Error counting unread notifications",
+		})
+	}
+
+	return c.JSON(models.NotificationListResponse{
+		Success:       true,
+		Message:       "Notifications retrieved successfully",
+		Notifications: notifications,
+		Count:         len(notifications),
+		UnreadCount:   unread,
+	})
+}
+
+// MarkNotificationRead - PUT /api/v1/notifications/:notificationId/read
+func (h *NotificationHandler) MarkNotificationRead(c *fiber.Ctx) error {
+	currentUserID := c.Locals("userID").(uint)
+
+	notificationID, err := parseIDParam(c, "notificationId")
+	if err != nil {
+		return c.Status(400).JSON(models.NotificationListResponse{
+			Success: false,
+			Message: "Invalid notification ID",
+		})
+	}
+
+	err = h.notificationService.MarkRead(currentUserID, notificationID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return c.Status(404).JSON(models.NotificationListResponse{
+			Success: false,
+			Message: "Notification not found",
+		})
+	}
+	if err != nil {
+		return c.Status(500).JSON(models.NotificationListResponse{
+			Success: false,
+			Message: "Error updating notification",
+		})
+	}
+
+	unread, _ := h.notificationService.UnreadCount(currentUserID)
+	return c.JSON(models.NotificationListResponse{
+		Success:     true,
+		Message:     "Notification marked as read",
+		UnreadCount: unread,
+	})
+}
+
+// MarkAllNotificationsRead - PUT /api/v1/notifications/read-all
+func (h *NotificationHandler) MarkAllNotificationsRead(c *fiber.Ctx) error {
+	currentUserID := c.Locals("userID").(uint)
+
+	updated, err := h.notificationService.MarkAllRead(currentUserID)
+	if err != nil {
+		return c.Status(500).JSON(models.NotificationListResponse{
+			Success: false,
+			Message: "Error updating notifications",
+		})
+	}
+
+	return c.JSON(models.NotificationListResponse{
+		Success: true,
+		Message: "All notifications marked as read",
+		Count:   int(updated),
+	})
+}
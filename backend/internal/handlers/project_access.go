@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"dev-bridge-manager/internal/services"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// requireProjectRole - ellenőrzi, hogy a user legalább minRole szerepkörrel rendelkezik-e a projektben
+func requireProjectRole(permissionService *services.PermissionService, userID, projectID uint, minRole string) error {
+	ok, err := permissionService.HasProjectRole(userID, projectID, minRole)
+	if err != nil {
+		return fiber.NewError(500, "Error checking project permissions")
+	}
+	if !ok {
+		return fiber.NewError(403, "Insufficient project permissions")
+	}
+	return nil
+}
+
+// parseIDParam - route paraméter beolvasása pozitív egész ID-ként
+func parseIDParam(c *fiber.Ctx, name string) (uint, error) {
+	id, err := strconv.ParseUint(c.Params(name), 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	if id == 0 {
+		return 0, strconv.ErrRange
+	}
+	return uint(id), nil
+}
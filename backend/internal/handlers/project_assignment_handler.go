@@ -5,6 +5,7 @@ import (
 	"dev-bridge-manager/internal/database"
 	"dev-bridge-manager/internal/models"
 	"dev-bridge-manager/internal/services"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -26,8 +27,8 @@ func (h *ProjectAssignmentHandler) validateAssignmentCreateRequest(req *models.P
 	if req.UserID == 0 {
 		return fiber.NewError(400, "User ID is required")
 	}
-	if req.Role != "" && req.Role != "owner" && req.Role != "manager" && req.Role != "member" && req.Role != "viewer" {
-		return fiber.NewError(400, "Role must be one of: owner, manager, member, viewer")
+	if req.Role != "" && !slices.Contains(models.ValidProjectRoles, req.Role) {
+		return fiber.NewError(400, "Role must be one of: owner, manager, reviewer, member, viewer")
 	}
 	return nil
 }
@@ -37,8 +38,8 @@ func (h *ProjectAssignmentHandler) validateAssignmentUpdateRequest(req *models.P
 	if strings.TrimSpace(req.Role) == "" {
 		return fiber.NewError(400, "Role is required")
 	}
-	if req.Role != "owner" && req.Role != "manager" && req.Role != "member" && req.Role != "viewer" {
-		return fiber.NewError(400, "Role must be one of: owner, manager, member, viewer")
+	if !slices.Contains(models.ValidProjectRoles, req.Role) {
+		return fiber.NewError(400, "Role must be one of: owner, manager, reviewer, member, viewer")
 	}
 	return nil
 }
@@ -8,15 +8,20 @@ import (
 	"strings"
 
 	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
 )
 
 type ProjectHandler struct {
 	permissionService *services.PermissionService
+	kanbanService     *services.KanbanService
+	projectService    *services.ProjectService
 }
 
 func NewProjectHandler() *ProjectHandler {
 	return &ProjectHandler{
 		permissionService: services.NewPermissionService(),
+		kanbanService:     services.NewKanbanService(),
+		projectService:    services.NewProjectService(),
 	}
 }
 
@@ -183,7 +188,15 @@ func (h *ProjectHandler) CreateProject(c *fiber.Ctx) error {
 		CreatedBy:   currentUserID,
 	}
 
-	if err := database.GetDB().Create(&project).Error; err != nil {
+	// Projekt és default kanban board létrehozása egy tranzakcióban
+	err = database.GetDB().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&project).Error; err != nil {
+			return err
+		}
+		_, err := h.kanbanService.CreateDefaultBoard(tx, project.ID)
+		return err
+	})
+	if err != nil {
 		return c.Status(500).JSON(models.ProjectListResponse{
 			Success: false,
 			Message: "Error creating project",
@@ -365,8 +378,8 @@ func (h *ProjectHandler) DeleteProject(c *fiber.Ctx) error {
 		})
 	}
 
-	// Projekt törlése
-	if err := database.GetDB().Delete(&project).Error; err != nil {
+	// Projekt törlése (a csatolmány fájlok a commit után törlődnek)
+	if err := h.projectService.DeleteProject(c.Context(), project.ID); err != nil {
 		return c.Status(500).JSON(models.ProjectListResponse{
 			Success: false,
 			Message: "Error deleting project",
@@ -0,0 +1,356 @@
+package handlers
+
+import (
+	"dev-bridge-manager/internal/database"
+	"dev-bridge-manager/internal/models"
+	"dev-bridge-manager/internal/sanitize"
+	"dev-bridge-manager/internal/schedule"
+	"dev-bridge-manager/internal/services"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// upcomingRunCount - ennyi következő futási időpont kerül a válaszba
+const upcomingRunCount = 5
+
+type RecurringTaskHandler struct {
+	permissionService    *services.PermissionService
+	kanbanService        *services.KanbanService
+	recurringTaskService *services.RecurringTaskService
+}
+
+func NewRecurringTaskHandler() *RecurringTaskHandler {
+	return &RecurringTaskHandler{
+		permissionService:    services.NewPermissionService(),
+		kanbanService:        services.NewKanbanService(),
+		recurringTaskService: services.NewRecurringTaskService(),
+	}
+}
+
+// scheduleError - hibás cron kifejezés, időzóna vagy soha nem futó ütemezés 400-as hibája
+func scheduleError(err error) error {
+	if errors.Is(err, schedule.ErrInvalidSchedule) ||
+		errors.Is(err, services.ErrInvalidTimezone) ||
+		errors.Is(err, services.ErrScheduleNeverRuns) {
+		return fiber.NewError(400, err.Error())
+	}
+	return err
+}
+
+// validateRecurringTask - a közös mezők egyszerű validációja validator csomag nélkül
+func validateRecurringTask(title, priority, scheduleExpr string, estimatedHours float64) error {
+	if strings.TrimSpace(title) == "" {
+		return fiber.NewError(400, "Task title is required")
+	}
+	if len(title) > 255 {
+		return fiber.NewError(400, "Task title must be less than 255 characters")
+	}
+	if priority != "" && !slices.Contains(models.ValidTaskPriorities, priority) {
+		return fiber.NewError(400, "Priority must be one of: low, medium, high, urgent")
+	}
+	if estimatedHours < 0 {
+		return fiber.NewError(400, "Estimated hours cannot be negative")
+	}
+	if strings.TrimSpace(scheduleExpr) == "" {
+		return fiber.NewError(400, "Schedule is required")
+	}
+	if len(scheduleExpr) > 100 {
+		return fiber.NewError(400, "Schedule must be less than 100 characters")
+	}
+	return nil
+}
+
+// validateTarget - az oszlopnak a projekt board-jához, az assignee-nek a projekt tagjai közé kell tartoznia
+func (h *RecurringTaskHandler) validateTarget(projectID uint, columnID, assigneeID *uint) error {
+	if columnID != nil && *columnID != 0 {
+		if _, err := h.kanbanService.GetProjectColumn(database.GetDB(), projectID, *columnID); err != nil {
+			return fiber.NewError(400, "Column does not belong to this project")
+		}
+	}
+	if assigneeID != nil && *assigneeID != 0 {
+		ok, err := h.permissionService.HasProjectRole(*assigneeID, projectID, "member")
+		if err != nil {
+			return fiber.NewError(400, "Assignee not found")
+		}
+		if !ok {
+			return fiber.NewError(400, "Assignee must be a member of the project")
+		}
+	}
+	return nil
+}
+
+// optionalID - 0 = nincs érték
+func optionalID(id *uint) *uint {
+	if id == nil || *id == 0 {
+		return nil
+	}
+	return id
+}
+
+// loadTemplate - projekt és sablon azonosító beolvasása, jogosultság ellenőrzés, sablon betöltése
+func (h *RecurringTaskHandler) loadTemplate(c *fiber.Ctx, role string) (*models.RecurringTaskTemplate, error) {
+	currentUserID := c.Locals("userID").(uint)
+
+	projectID, err := parseIDParam(c, "id")
+	if err != nil {
+		return nil, fiber.NewError(400, "Invalid project ID")
+	}
+	templateID, err := parseIDParam(c, "templateId")
+	if err != nil {
+		return nil, fiber.NewError(400, "Invalid template ID")
+	}
+
+	if err := requireProjectRole(h.permissionService, currentUserID, projectID, role); err != nil {
+		return nil, err
+	}
+
+	template, err := h.recurringTaskService.LoadTemplate(projectID, templateID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fiber.NewError(404, "Recurring task template not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// GetRecurringTasks - GET /api/v1/projects/:id/recurring-tasks
+// A projekt ismétlődő task sablonjai a következő futási időpontokkal
+func (h *RecurringTaskHandler) GetRecurringTasks(c *fiber.Ctx) error {
+	currentUserID := c.Locals("userID").(uint)
+
+	projectID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(400).JSON(models.RecurringTaskListResponse{
+			Success: false,
+			Message: "Invalid project ID",
+		})
+	}
+
+	if err := requireProjectRole(h.permissionService, currentUserID, projectID, "viewer"); err != nil {
+		return err
+	}
+
+	templates, err := h.recurringTaskService.ListTemplates(projectID)
+	if err != nil {
+		return c.Status(500).JSON(models.RecurringTaskListResponse{
+			Success: false,
+			Message: "Error fetching recurring tasks",
+		})
+	}
+
+	responses := make([]models.RecurringTaskResponse, 0, len(templates))
+	for i := range templates {
+		responses = append(responses, h.recurringTaskService.ToResponse(&templates[i], upcomingRunCount))
+	}
+
+	return c.JSON(models.RecurringTaskListResponse{
+		Success:   true,
+		Message:   "Recurring tasks retrieved successfully",
+		Templates: responses,
+		Count:     len(responses),
+	})
+}
+
+// GetRecurringTask - GET /api/v1/projects/:id/recurring-tasks/:templateId
+func (h *RecurringTaskHandler) GetRecurringTask(c *fiber.Ctx) error {
+	template, err := h.loadTemplate(c, "viewer")
+	if err != nil {
+		return err
+	}
+
+	response := h.recurringTaskService.ToResponse(template, upcomingRunCount)
+	return c.JSON(models.RecurringTaskListResponse{
+		Success:  true,
+		Message:  "Recurring task retrieved successfully",
+		Template: &response,
+	})
+}
+
+// CreateRecurringTask - POST /api/v1/projects/:id/recurring-tasks
+// Cron kifejezés (pl. "0 9 * * mon") vagy @daily/@weekly/@monthly a megadott időzónában (alapból UTC)
+func (h *RecurringTaskHandler) CreateRecurringTask(c *fiber.Ctx) error {
+	currentUserID := c.Locals("userID").(uint)
+
+	projectID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(400).JSON(models.RecurringTaskListResponse{
+			Success: false,
+			Message: "Invalid project ID",
+		})
+	}
+
+	if err := requireProjectRole(h.permissionService, currentUserID, projectID, "manager"); err != nil {
+		return err
+	}
+
+	var req models.RecurringTaskCreateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(models.RecurringTaskListResponse{
+			Success: false,
+			Message: "Invalid request body",
+		})
+	}
+
+	// Validáció
+	if err := validateRecurringTask(req.Title, req.Priority, req.Schedule, req.EstimatedHours); err != nil {
+		return err
+	}
+	if err := h.validateTarget(projectID, req.ColumnID, req.AssigneeID); err != nil {
+		return err
+	}
+
+	// Default priority beállítása
+	if req.Priority == "" {
+		req.Priority = "medium"
+	}
+
+	template := models.RecurringTaskTemplate{
+		ProjectID:      projectID,
+		ColumnID:       optionalID(req.ColumnID),
+		Title:          strings.TrimSpace(req.Title),
+		Description:    sanitize.PlainText(req.Description),
+		Priority:       req.Priority,
+		AssigneeID:     optionalID(req.AssigneeID),
+		EstimatedHours: req.EstimatedHours,
+		Schedule:       strings.TrimSpace(req.Schedule),
+		Timezone:       strings.TrimSpace(req.Timezone),
+		IsActive:       true,
+		CreatedBy:      currentUserID,
+	}
+	if req.DueInDays != nil && *req.DueInDays >= 0 {
+		template.DueInDays = req.DueInDays
+	}
+	if req.IsActive != nil {
+		template.IsActive = *req.IsActive
+	}
+
+	if err := h.recurringTaskService.CreateTemplate(&template); err != nil {
+		if scheduleErr := scheduleError(err); scheduleErr != err {
+			return scheduleErr
+		}
+		return c.Status(500).JSON(models.RecurringTaskListResponse{
+			Success: false,
+			Message: "Error creating recurring task",
+		})
+	}
+
+	response := h.recurringTaskService.ToResponse(&template, upcomingRunCount)
+	return c.Status(201).JSON(models.RecurringTaskListResponse{
+		Success:  true,
+		Message:  "Recurring task created successfully",
+		Template: &response,
+	})
+}
+
+// UpdateRecurringTask - PUT /api/v1/projects/:id/recurring-tasks/:templateId
+// Az ütemezés vagy időzóna módosítása, illetve újraaktiválás a mostani időponttól számolja a következő futást
+func (h *RecurringTaskHandler) UpdateRecurringTask(c *fiber.Ctx) error {
+	template, err := h.loadTemplate(c, "manager")
+	if err != nil {
+		return err
+	}
+
+	var req models.RecurringTaskUpdateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(models.RecurringTaskListResponse{
+			Success: false,
+			Message: "Invalid request body",
+		})
+	}
+
+	reschedule := false
+	if req.Title != nil {
+		template.Title = strings.TrimSpace(*req.Title)
+	}
+	if req.Description != nil {
+		template.Description = sanitize.PlainText(*req.Description)
+	}
+	if req.Priority != nil {
+		template.Priority = *req.Priority
+	}
+	if req.EstimatedHours != nil {
+		template.EstimatedHours = *req.EstimatedHours
+	}
+	if req.Schedule != nil && strings.TrimSpace(*req.Schedule) != template.Schedule {
+		template.Schedule = strings.TrimSpace(*req.Schedule)
+		reschedule = true
+	}
+	if req.Timezone != nil && strings.TrimSpace(*req.Timezone) != template.Timezone {
+		template.Timezone = strings.TrimSpace(*req.Timezone)
+		reschedule = true
+	}
+
+	// Validáció
+	if req.Priority != nil && *req.Priority == "" {
+		return fiber.NewError(400, "Priority must be one of: low, medium, high, urgent")
+	}
+	if err := validateRecurringTask(template.Title, template.Priority, template.Schedule, template.EstimatedHours); err != nil {
+		return err
+	}
+	if err := h.validateTarget(template.ProjectID, req.ColumnID, req.AssigneeID); err != nil {
+		return err
+	}
+
+	if req.ColumnID != nil {
+		template.ColumnID = optionalID(req.ColumnID)
+	}
+	if req.AssigneeID != nil {
+		template.AssigneeID = optionalID(req.AssigneeID)
+	}
+	if req.DueInDays != nil {
+		if *req.DueInDays < 0 {
+			template.DueInDays = nil
+		} else {
+			template.DueInDays = req.DueInDays
+		}
+	}
+	if req.IsActive != nil {
+		if *req.IsActive && !template.IsActive {
+			reschedule = true
+		}
+		template.IsActive = *req.IsActive
+	}
+
+	if err := h.recurringTaskService.UpdateTemplate(template, reschedule); err != nil {
+		if scheduleErr := scheduleError(err); scheduleErr != err {
+			return scheduleErr
+		}
+		return c.Status(500).JSON(models.RecurringTaskListResponse{
+			Success: false,
+			Message: "Error updating recurring task",
+		})
+	}
+
+	response := h.recurringTaskService.ToResponse(template, upcomingRunCount)
+	return c.JSON(models.RecurringTaskListResponse{
+		Success:  true,
+		Message:  "Recurring task updated successfully",
+		Template: &response,
+	})
+}
+
+// DeleteRecurringTask - DELETE /api/v1/projects/:id/recurring-tasks/:templateId
+// A sablonból korábban létrehozott taskok megmaradnak
+func (h *RecurringTaskHandler) DeleteRecurringTask(c *fiber.Ctx) error {
+	template, err := h.loadTemplate(c, "manager")
+	if err != nil {
+		return err
+	}
+
+	if err := h.recurringTaskService.DeleteTemplate(template); err != nil {
+		return c.Status(500).JSON(models.RecurringTaskListResponse{
+			Success: false,
+			Message: "Error deleting recurring task",
+		})
+	}
+
+	return c.JSON(models.RecurringTaskListResponse{
+		Success: true,
+		Message: "Recurring task deleted successfully",
+	})
+}
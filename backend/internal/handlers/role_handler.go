@@ -16,6 +16,7 @@ type CreateRoleRequest struct {
 	Name          string `json:"name" validate:"required"`
 	DisplayName   string `json:"display_name" validate:"required"`
 	Description   string `json:"description"`
+	MFARequired   bool   `json:"mfa_required"` // A szerepkör userei csak MFA-val léphetnek be
 	PermissionIDs []uint `json:"permission_ids"`
 }
 
@@ -23,6 +24,7 @@ type UpdateRoleRequest struct {
 	DisplayName   string `json:"display_name"`
 	Description   string `json:"description"`
 	IsActive      *bool  `json:"is_active"`
+	MFARequired   *bool  `json:"mfa_required"`
 	PermissionIDs []uint `json:"permission_ids"`
 }
 
@@ -66,11 +68,19 @@ func (h *RoleHandler) CreateRole(c *fiber.Ctx) error {
 		})
 	}
 
+	if req.MFARequired && !services.MFAConfigured() {
+		return c.Status(409).JSON(RoleResponse{
+			Success: false,
+			Message: "Set MFA_ENCRYPTION_KEY before requiring two-factor authentication",
+		})
+	}
+
 	role := models.Role{
 		Name:        req.Name,
 		DisplayName: req.DisplayName,
 		Description: req.Description,
 		IsActive:    true,
+		MFARequired: req.MFARequired,
 	}
 
 	if err := h.permissionService.CreateRole(&role); err != nil {
@@ -125,6 +135,16 @@ func (h *RoleHandler) UpdateRole(c *fiber.Ctx) error {
 	if req.IsActive != nil {
 		updates["is_active"] = *req.IsActive
 	}
+	if req.MFARequired != nil {
+		// MFA_ENCRYPTION_KEY nélkül a szerepkör userei nem tudnák beállítani az MFA-t
+		if *req.MFARequired && !services.MFAConfigured() {
+			return c.Status(409).JSON(RoleResponse{
+				Success: false,
+				Message: "Set MFA_ENCRYPTION_KEY before requiring two-factor authentication",
+			})
+		}
+		updates["mfa_required"] = *req.MFARequired
+	}
 
 	if err := h.permissionService.UpdateRole(uint(roleID), updates); err != nil {
 		return c.Status(500).JSON(RoleResponse{
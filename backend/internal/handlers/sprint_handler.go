@@ -0,0 +1,453 @@
+package handlers
+
+import (
+	"dev-bridge-manager/internal/database"
+	"dev-bridge-manager/internal/models"
+	"dev-bridge-manager/internal/services"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type SprintHandler struct {
+	permissionService *services.PermissionService
+	sprintService     *services.SprintService
+}
+
+func NewSprintHandler() *SprintHandler {
+	return &SprintHandler{
+		permissionService: services.NewPermissionService(),
+		sprintService:     services.NewSprintService(),
+	}
+}
+
+// sprintError - a sprint műveletek hibájának HTTP hibává alakítása
+func sprintError(err error) error {
+	switch {
+	case errors.Is(err, services.ErrSprintNotFound):
+		return fiber.NewError(404, "Sprint not found")
+	case errors.Is(err, services.ErrSprintClosed):
+		return fiber.NewError(409, "Closed sprints cannot be changed")
+	case errors.Is(err, services.ErrSprintNotPlanned):
+		return fiber.NewError(409, "Only planned sprints can be started or deleted")
+	case errors.Is(err, services.ErrSprintNotActive):
+		return fiber.NewError(409, "Only the active sprint can be closed")
+	case errors.Is(err, services.ErrActiveSprintExists):
+		return fiber.NewError(409, "The project already has an active sprint")
+	case errors.Is(err, services.ErrNoNextSprint):
+		return fiber.NewError(409, "There is no planned sprint to move unfinished tasks to")
+	}
+	return err
+}
+
+// parseSprintDate - YYYY-MM-DD formátumú sprint dátum beolvasása
+func parseSprintDate(value, field string) (time.Time, error) {
+	date, err := time.Parse(models.TimeEntryDateLayout, strings.TrimSpace(value))
+	if err != nil {
+		return time.Time{}, fiber.NewError(400, field+" must be in YYYY-MM-DD format")
+	}
+	return date, nil
+}
+
+// validateSprintName - a sprint név nem lehet üres és legfeljebb 100 karakter
+func validateSprintName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return fiber.NewError(400, "Sprint name is required")
+	}
+	if len(strings.TrimSpace(name)) > 100 {
+		return fiber.NewError(400, "Sprint name must be less than 100 characters")
+	}
+	return nil
+}
+
+// parseProjectSprint - projekt és sprint azonosító beolvasása, jogosultság ellenőrzése
+func (h *SprintHandler) parseProjectSprint(c *fiber.Ctx, minRole string) (uint, uint, error) {
+	currentUserID := c.Locals("userID").(uint)
+
+	projectID, err := parseIDParam(c, "id")
+	if err != nil {
+		return 0, 0, fiber.NewError(400, "Invalid project ID")
+	}
+
+	sprintID, err := parseIDParam(c, "sprintId")
+	if err != nil {
+		return 0, 0, fiber.NewError(400, "Invalid sprint ID")
+	}
+
+	if err := requireProjectRole(h.permissionService, currentUserID, projectID, minRole); err != nil {
+		return 0, 0, err
+	}
+	return projectID, sprintID, nil
+}
+
+// sprintResult - a sprint újratöltése statisztikával és visszaadása
+func (h *SprintHandler) sprintResult(c *fiber.Ctx, status int, projectID, sprintID uint, message string) error {
+	sprint, err := h.sprintService.LoadSprint(database.GetDB(), projectID, sprintID)
+	if err != nil {
+		return c.Status(500).JSON(models.SprintListResponse{
+			Success: false,
+			Message: "Sprint saved but failed to load details",
+		})
+	}
+
+	stats, err := h.sprintService.Stats([]uint{sprint.ID})
+	if err != nil {
+		return c.Status(500).JSON(models.SprintListResponse{
+			Success: false,
+			Message: "Sprint saved but failed to load details",
+		})
+	}
+
+	response := sprint.ToResponse()
+	response.Stats = stats[sprint.ID]
+	return c.Status(status).JSON(models.SprintListResponse{
+		Success: true,
+		Message: message,
+		Sprint:  &response,
+	})
+}
+
+// GetProjectSprints - GET /api/v1/projects/:id/sprints
+// A sprintek időrendben, a taskok összesítésével
+func (h *SprintHandler) GetProjectSprints(c *fiber.Ctx) error {
+	currentUserID := c.Locals("userID").(uint)
+
+	projectID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(400).JSON(models.SprintListResponse{
+			Success: false,
+			Message: "Invalid project ID",
+		})
+	}
+
+	if err := requireProjectRole(h.permissionService, currentUserID, projectID, "viewer"); err != nil {
+		return err
+	}
+
+	sprints, err := h.sprintService.ListSprints(projectID)
+	if err != nil {
+		return c.Status(500).JSON(models.SprintListResponse{
+			Success: false,
+			Message: "Error fetching sprints",
+		})
+	}
+
+	ids := make([]uint, 0, len(sprints))
+	for i := range sprints {
+		ids = append(ids, sprints[i].ID)
+	}
+	stats, err := h.sprintService.Stats(ids)
+	if err != nil {
+		return c.Status(500).JSON(models.SprintListResponse{
+			Success: false,
+			Message: "Error fetching sprint statistics",
+		})
+	}
+
+	response := make([]models.SprintResponse, 0, len(sprints))
+	for i := range sprints {
+		item := sprints[i].ToResponse()
+		item.Stats = stats[sprints[i].ID]
+		response = append(response, item)
+	}
+
+	return c.JSON(models.SprintListResponse{
+		Success: true,
+		Message: "Sprints retrieved successfully",
+		Sprints: response,
+		Count:   len(response),
+	})
+}
+
+// GetSprint - GET /api/v1/projects/:id/sprints/:sprintId
+func (h *SprintHandler) GetSprint(c *fiber.Ctx) error {
+	projectID, sprintID, err := h.parseProjectSprint(c, "viewer")
+	if err != nil {
+		return err
+	}
+
+	if _, err := h.sprintService.LoadSprint(database.GetDB(), projectID, sprintID); err != nil {
+		return sprintError(err)
+	}
+
+	return h.sprintResult(c, 200, projectID, sprintID, "Sprint retrieved successfully")
+}
+
+// CreateSprint - POST /api/v1/projects/:id/sprints
+// Az új sprint tervezett állapotban jön létre
+func (h *SprintHandler) CreateSprint(c *fiber.Ctx) error {
+	currentUserID := c.Locals("userID").(uint)
+
+	projectID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(400).JSON(models.SprintListResponse{
+			Success: false,
+			Message: "Invalid project ID",
+		})
+	}
+
+	if err := requireProjectRole(h.permissionService, currentUserID, projectID, "manager"); err != nil {
+		return err
+	}
+
+	var req models.SprintCreateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(models.SprintListResponse{
+			Success: false,
+			Message: "Invalid request body",
+		})
+	}
+
+	// Validáció
+	if err := validateSprintName(req.Name); err != nil {
+		return err
+	}
+	startDate, err := parseSprintDate(req.StartDate, "Start date")
+	if err != nil {
+		return err
+	}
+	endDate, err := parseSprintDate(req.EndDate, "End date")
+	if err != nil {
+		return err
+	}
+	if endDate.Before(startDate) {
+		return fiber.NewError(400, "End date cannot be before start date")
+	}
+
+	sprint := models.Sprint{
+		ProjectID: projectID,
+		Name:      strings.TrimSpace(req.Name),
+		Goal:      strings.TrimSpace(req.Goal),
+		StartDate: startDate,
+		EndDate:   endDate,
+		CreatedBy: currentUserID,
+	}
+
+	if err := h.sprintService.CreateSprint(&sprint); err != nil {
+		return c.Status(500).JSON(models.SprintListResponse{
+			Success: false,
+			Message: "Error creating sprint",
+		})
+	}
+
+	return h.sprintResult(c, 201, projectID, sprint.ID, "Sprint created successfully")
+}
+
+// UpdateSprint - PUT /api/v1/projects/:id/sprints/:sprintId
+// Név, cél és dátumok módosítása; lezárt sprint nem módosítható
+func (h *SprintHandler) UpdateSprint(c *fiber.Ctx) error {
+	projectID, sprintID, err := h.parseProjectSprint(c, "manager")
+	if err != nil {
+		return err
+	}
+
+	var req models.SprintUpdateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(models.SprintListResponse{
+			Success: false,
+			Message: "Invalid request body",
+		})
+	}
+
+	sprint, err := h.sprintService.LoadSprint(database.GetDB(), projectID, sprintID)
+	if err != nil {
+		return sprintError(err)
+	}
+
+	updates := map[string]interface{}{}
+	if req.Name != nil {
+		if err := validateSprintName(*req.Name); err != nil {
+			return err
+		}
+		updates["name"] = strings.TrimSpace(*req.Name)
+	}
+	if req.Goal != nil {
+		updates["goal"] = strings.TrimSpace(*req.Goal)
+	}
+
+	// A dátumokat a meglévő értékekkel együtt ellenőrizzük
+	startDate, endDate := sprint.StartDate, sprint.EndDate
+	if req.StartDate != nil {
+		if startDate, err = parseSprintDate(*req.StartDate, "Start date"); err != nil {
+			return err
+		}
+		updates["start_date"] = startDate
+	}
+	if req.EndDate != nil {
+		if endDate, err = parseSprintDate(*req.EndDate, "End date"); err != nil {
+			return err
+		}
+		updates["end_date"] = endDate
+	}
+	if endDate.Before(startDate) {
+		return fiber.NewError(400, "End date cannot be before start date")
+	}
+
+	if len(updates) == 0 {
+		return c.Status(400).JSON(models.SprintListResponse{
+			Success: false,
+			Message: "Nothing to update",
+		})
+	}
+
+	if _, err := h.sprintService.UpdateSprint(projectID, sprintID, updates); err != nil {
+		if errors.Is(err, services.ErrSprintNotFound) || errors.Is(err, services.ErrSprintClosed) {
+			return sprintError(err)
+		}
+		return c.Status(500).JSON(models.SprintListResponse{
+			Success: false,
+			Message: "Error updating sprint",
+		})
+	}
+
+	return h.sprintResult(c, 200, projectID, sprintID, "Sprint updated successfully")
+}
+
+// DeleteSprint - DELETE /api/v1/projects/:id/sprints/:sprintId
+// Csak tervezett sprint törölhető, a taskjai visszakerülnek a backlogba
+func (h *SprintHandler) DeleteSprint(c *fiber.Ctx) error {
+	projectID, sprintID, err := h.parseProjectSprint(c, "manager")
+	if err != nil {
+		return err
+	}
+
+	err = h.sprintService.DeleteSprint(projectID, sprintID)
+	if errors.Is(err, services.ErrSprintNotFound) || errors.Is(err, services.ErrSprintNotPlanned) {
+		return sprintError(err)
+	}
+	if err != nil {
+		return c.Status(500).JSON(models.SprintListResponse{
+			Success: false,
+			Message: "Error deleting sprint",
+		})
+	}
+
+	return c.JSON(models.SprintListResponse{
+		Success: true,
+		Message: "Sprint deleted successfully",
+	})
+}
+
+// StartSprint - POST /api/v1/projects/:id/sprints/:sprintId/start
+// Projektenként egyszerre egy aktív sprint lehet
+func (h *SprintHandler) StartSprint(c *fiber.Ctx) error {
+	projectID, sprintID, err := h.parseProjectSprint(c, "manager")
+	if err != nil {
+		return err
+	}
+
+	if _, err := h.sprintService.StartSprint(projectID, sprintID); err != nil {
+		if errors.Is(err, services.ErrSprintNotFound) ||
+			errors.Is(err, services.ErrSprintNotPlanned) ||
+			errors.Is(err, services.ErrActiveSprintExists) {
+			return sprintError(err)
+		}
+		return c.Status(500).JSON(models.SprintListResponse{
+			Success: false,
+			Message: "Error starting sprint",
+		})
+	}
+
+	return h.sprintResult(c, 200, projectID, sprintID, "Sprint started successfully")
+}
+
+// CloseSprint - POST /api/v1/projects/:id/sprints/:sprintId/close
+// A befejezetlen taskok a következő sprintbe vagy a backlogba kerülnek, a válasz a lezárási riport
+func (h *SprintHandler) CloseSprint(c *fiber.Ctx) error {
+	currentUserID := c.Locals("userID").(uint)
+
+	projectID, sprintID, err := h.parseProjectSprint(c, "manager")
+	if err != nil {
+		return err
+	}
+
+	var req models.SprintCloseRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(400).JSON(models.SprintListResponse{
+				Success: false,
+				Message: "Invalid request body",
+			})
+		}
+	}
+
+	switch req.MoveTo {
+	case "":
+		req.MoveTo = "backlog"
+		if req.TargetSprintID != nil {
+			req.MoveTo = "next"
+		}
+	case "backlog", "next":
+	default:
+		return fiber.NewError(400, "moveTo must be 'backlog' or 'next'")
+	}
+	if req.MoveTo == "backlog" && req.TargetSprintID != nil {
+		return fiber.NewError(400, "targetSprintId can only be used with moveTo 'next'")
+	}
+
+	report, err := h.sprintService.CloseSprint(projectID, sprintID, currentUserID, req.MoveTo, req.TargetSprintID)
+	if err != nil {
+		if errors.Is(err, services.ErrSprintNotFound) ||
+			errors.Is(err, services.ErrSprintClosed) ||
+			errors.Is(err, services.ErrSprintNotActive) ||
+			errors.Is(err, services.ErrNoNextSprint) {
+			return sprintError(err)
+		}
+		return c.Status(500).JSON(models.SprintListResponse{
+			Success: false,
+			Message: "Error closing sprint",
+		})
+	}
+
+	sprint, err := h.sprintService.LoadSprint(database.GetDB(), projectID, sprintID)
+	if err != nil {
+		return c.Status(500).JSON(models.SprintListResponse{
+			Success: false,
+			Message: "Sprint closed but failed to load details",
+		})
+	}
+
+	response := sprint.ToResponse()
+	return c.JSON(models.SprintListResponse{
+		Success:     true,
+		Message:     "Sprint closed successfully",
+		Sprint:      &response,
+		CloseReport: report,
+	})
+}
+
+// GetSprintScopeChanges - GET /api/v1/projects/:id/sprints/:sprintId/scope-changes
+// A futás közben hozzáadott és kivett taskok időrendben
+func (h *SprintHandler) GetSprintScopeChanges(c *fiber.Ctx) error {
+	projectID, sprintID, err := h.parseProjectSprint(c, "viewer")
+	if err != nil {
+		return err
+	}
+
+	sprint, err := h.sprintService.LoadSprint(database.GetDB(), projectID, sprintID)
+	if err != nil {
+		return sprintError(err)
+	}
+
+	changes, err := h.sprintService.ScopeChanges(database.GetDB(), sprint.ID)
+	if err != nil {
+		return c.Status(500).JSON(models.SprintListResponse{
+			Success: false,
+			Message: "Error fetching scope changes",
+		})
+	}
+
+	response := make([]models.SprintScopeChangeResponse, 0, len(changes))
+	for i := range changes {
+		response = append(response, changes[i].ToResponse())
+	}
+
+	return c.JSON(models.SprintListResponse{
+		Success:      true,
+		Message:      "Scope changes retrieved successfully",
+		ScopeChanges: response,
+		Count:        len(response),
+	})
+}
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"dev-bridge-manager/internal/services"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// errorCode returns the HTTP status of a fiber error, 0 for other errors
+func errorCode(err error) int {
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return fiberErr.Code
+	}
+	return 0
+}
+
+func TestSprintError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{services.ErrSprintNotFound, 404},
+		{services.ErrSprintClosed, 409},
+		{services.ErrSprintNotPlanned, 409},
+		{services.ErrSprintNotActive, 409},
+		{services.ErrActiveSprintExists, 409},
+		{services.ErrNoNextSprint, 409},
+		{fmt.Errorf("close: %w", services.ErrSprintNotActive), 409},
+		{errors.New("db down"), 0},
+	}
+
+	for _, tt := range tests {
+		if got := errorCode(sprintError(tt.err)); got != tt.want {
+			t.Errorf("sprintError(%v) status = %d, want %d", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestParseSprintDate(t *testing.T) {
+	date, err := parseSprintDate(" 2026-02-28 ", "Start date")
+	if err != nil || !date.Equal(time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("parseSprintDate = %s, %v", date, err)
+	}
+
+	for _, value := range []string{"", "2026-02-30", "28.02.2026", "2026-2-28", "2026-02-28T10:00:00Z"} {
+		_, err := parseSprintDate(value, "End date")
+		if errorCode(err) != 400 || !strings.HasPrefix(err.Error(), "End date") {
+			t.Errorf("parseSprintDate(%q) = %v, want a 400 naming the field", value, err)
+		}
+	}
+}
+
+func TestValidateSprintName(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{"Sprint 1", false},
+		{strings.Repeat("a", 100), false},
+		{strings.Repeat("a", 101), true},
+		{"", true},
+		{"   ", true},
+	}
+
+	for _, tt := range tests {
+		if err := validateSprintName(tt.name); (err != nil) != tt.wantErr {
+			t.Errorf("validateSprintName(%q) = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,315 @@
+package handlers
+
+import (
+	"dev-bridge-manager/internal/models"
+	"dev-bridge-manager/internal/services"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+type TagHandler struct {
+	permissionService *services.PermissionService
+	tagService        *services.TagService
+}
+
+func NewTagHandler() *TagHandler {
+	return &TagHandler{
+		permissionService: services.NewPermissionService(),
+		tagService:        services.NewTagService(),
+	}
+}
+
+// validateTagName - a tag név nem lehet üres és legfeljebb 50 karakter
+func validateTagName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return fiber.NewError(400, "Tag name is required")
+	}
+	if utf8.RuneCountInString(strings.TrimSpace(name)) > models.MaxTagNameLength {
+		return fiber.NewError(400, fmt.Sprintf("Tag name must be at most %d characters", models.MaxTagNameLength))
+	}
+	return nil
+}
+
+// validateTagNames - a taskhoz megadott tag nevek ellenőrzése (az üresek kimaradnak)
+func validateTagNames(names []string) error {
+	for _, name := range names {
+		if strings.TrimSpace(name) == "" {
+			continue
+		}
+		if err := validateTagName(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateTagColor - a szín hossza a color oszlophoz igazodik
+func validateTagColor(color string) error {
+	if len(color) > 20 {
+		return fiber.NewError(400, "Tag color must be at most 20 characters")
+	}
+	return nil
+}
+
+// parseProjectTag - projekt és tag azonosító beolvasása, jogosultság ellenőrzése
+func (h *TagHandler) parseProjectTag(c *fiber.Ctx, minRole string) (uint, uint, error) {
+	currentUserID := c.Locals("userID").(uint)
+
+	projectID, err := parseIDParam(c, "id")
+	if err != nil {
+		return 0, 0, fiber.NewError(400, "Invalid project ID")
+	}
+
+	tagID, err := parseIDParam(c, "tagId")
+	if err != nil {
+		return 0, 0, fiber.NewError(400, "Invalid tag ID")
+	}
+
+	if err := requireProjectRole(h.permissionService, currentUserID, projectID, minRole); err != nil {
+		return 0, 0, err
+	}
+	return projectID, tagID, nil
+}
+
+// tagResult - a tag újratöltése a használati számmal és visszaadása
+func (h *TagHandler) tagResult(c *fiber.Ctx, status int, projectID, tagID uint, message string) error {
+	tag, err := h.tagService.LoadTag(projectID, tagID)
+	if err != nil {
+		return c.Status(500).JSON(models.TagListResponse{
+			Success: false,
+			Message: "Tag saved but failed to load details",
+		})
+	}
+
+	return c.Status(status).JSON(models.TagListResponse{
+		Success: true,
+		Message: message,
+		Tag:     tag,
+	})
+}
+
+// GetProjectTags - GET /api/v1/projects/:id/tags
+// A tagek a használó taskok számával együtt (taskCount = 0: nem használt tag)
+func (h *TagHandler) GetProjectTags(c *fiber.Ctx) error {
+	currentUserID := c.Locals("userID").(uint)
+
+	projectID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(400).JSON(models.TagListResponse{
+			Success: false,
+			Message: "Invalid project ID",
+		})
+	}
+
+	if err := requireProjectRole(h.permissionService, currentUserID, projectID, "viewer"); err != nil {
+		return err
+	}
+
+	tags, err := h.tagService.ListTags(projectID)
+	if err != nil {
+		return c.Status(500).JSON(models.TagListResponse{
+			Success: false,
+			Message: "Error fetching tags",
+		})
+	}
+
+	return c.JSON(models.TagListResponse{
+		Success: true,
+		Message: "Tags retrieved successfully",
+		Tags:    tags,
+		Count:   len(tags),
+	})
+}
+
+// CreateTag - POST /api/v1/projects/:id/tags
+func (h *TagHandler) CreateTag(c *fiber.Ctx) error {
+	currentUserID := c.Locals("userID").(uint)
+
+	projectID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(400).JSON(models.TagListResponse{
+			Success: false,
+			Message: "Invalid project ID",
+		})
+	}
+
+	if err := requireProjectRole(h.permissionService, currentUserID, projectID, "member"); err != nil {
+		return err
+	}
+
+	var req models.TagCreateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(models.TagListResponse{
+			Success: false,
+			Message: "Invalid request body",
+		})
+	}
+
+	// Validáció
+	if err := validateTagName(req.Name); err != nil {
+		return err
+	}
+	if err := validateTagColor(req.Color); err != nil {
+		return err
+	}
+	if req.Color == "" {
+		req.Color = models.DefaultTagColor
+	}
+
+	tag := models.Tag{
+		ProjectID: projectID,
+		Name:      strings.TrimSpace(req.Name),
+		Color:     req.Color,
+	}
+
+	err = h.tagService.CreateTag(&tag)
+	if errors.Is(err, services.ErrTagExists) {
+		return c.Status(409).JSON(models.TagListResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+	}
+	if err != nil {
+		return c.Status(500).JSON(models.TagListResponse{
+			Success: false,
+			Message: "Error creating tag",
+		})
+	}
+
+	return h.tagResult(c, 201, projectID, tag.ID, "Tag created successfully")
+}
+
+// UpdateTag - PUT /api/v1/projects/:id/tags/:tagId
+// Átnevezés vagy átszínezés; a taskok a tag azonosítóra hivatkoznak, így mindegyiknél azonnal látszik
+func (h *TagHandler) UpdateTag(c *fiber.Ctx) error {
+	projectID, tagID, err := h.parseProjectTag(c, "manager")
+	if err != nil {
+		return err
+	}
+
+	var req models.TagUpdateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(models.TagListResponse{
+			Success: false,
+			Message: "Invalid request body",
+		})
+	}
+
+	updates := map[string]interface{}{}
+	if req.Name != nil {
+		if err := validateTagName(*req.Name); err != nil {
+			return err
+		}
+		updates["name"] = strings.TrimSpace(*req.Name)
+	}
+	if req.Color != nil {
+		if err := validateTagColor(*req.Color); err != nil {
+			return err
+		}
+		updates["color"] = *req.Color
+	}
+	if len(updates) == 0 {
+		return c.Status(400).JSON(models.TagListResponse{
+			Success: false,
+			Message: "Nothing to update",
+		})
+	}
+
+	err = h.tagService.UpdateTag(projectID, tagID, updates)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return c.Status(404).JSON(models.TagListResponse{
+			Success: false,
+			Message: "Tag not found",
+		})
+	case errors.Is(err, services.ErrTagExists):
+		return c.Status(409).JSON(models.TagListResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+	case err != nil:
+		return c.Status(500).JSON(models.TagListResponse{
+			Success: false,
+			Message: "Error updating tag",
+		})
+	}
+
+	return h.tagResult(c, 200, projectID, tagID, "Tag updated successfully")
+}
+
+// MergeTag - POST /api/v1/projects/:id/tags/:tagId/merge
+// A tag minden taskja a cél taghez kerül, majd a tag törlődik
+func (h *TagHandler) MergeTag(c *fiber.Ctx) error {
+	projectID, tagID, err := h.parseProjectTag(c, "manager")
+	if err != nil {
+		return err
+	}
+
+	var req models.TagMergeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(models.TagListResponse{
+			Success: false,
+			Message: "Invalid request body",
+		})
+	}
+	if req.TargetTagID == 0 {
+		return c.Status(400).JSON(models.TagListResponse{
+			Success: false,
+			Message: "Target tag ID is required",
+		})
+	}
+
+	err = h.tagService.MergeTags(projectID, tagID, req.TargetTagID)
+	switch {
+	case errors.Is(err, services.ErrTagMergeSelf):
+		return c.Status(400).JSON(models.TagListResponse{
+			Success: false,
+			Message: "A tag cannot be merged into itself",
+		})
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return c.Status(404).JSON(models.TagListResponse{
+			Success: false,
+			Message: "Tag not found",
+		})
+	case err != nil:
+		return c.Status(500).JSON(models.TagListResponse{
+			Success: false,
+			Message: "Error merging tags",
+		})
+	}
+
+	return h.tagResult(c, 200, projectID, req.TargetTagID, "Tags merged successfully")
+}
+
+// DeleteTag - DELETE /api/v1/projects/:id/tags/:tagId
+// A tag minden taskról lekerül
+func (h *TagHandler) DeleteTag(c *fiber.Ctx) error {
+	projectID, tagID, err := h.parseProjectTag(c, "manager")
+	if err != nil {
+		return err
+	}
+
+	err = h.tagService.DeleteTag(projectID, tagID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return c.Status(404).JSON(models.TagListResponse{
+			Success: false,
+			Message: "Tag not found",
+		})
+	}
+	if err != nil {
+		return c.Status(500).JSON(models.TagListResponse{
+			Success: false,
+			Message: "Error deleting tag",
+		})
+	}
+
+	return c.JSON(models.TagListResponse{
+		Success: true,
+		Message: "Tag deleted successfully",
+	})
+}
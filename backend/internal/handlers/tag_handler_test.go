@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"dev-bridge-manager/internal/models"
+	"strings"
+	"testing"
+)
+
+func TestValidateTagName(t *testing.T) {
+	tests := []struct {
+		name    string
+		tag     string
+		wantErr bool
+	}{
+		{"simple", "bug", false},
+		{"surrounding spaces are not counted", "  bug  ", false},
+		{"maximum length", strings.Repeat("a", models.MaxTagNameLength), false},
+		{"maximum length in runes", strings.Repeat("ő", models.MaxTagNameLength), false},
+		{"too long", strings.Repeat("a", models.MaxTagNameLength+1), true},
+		{"empty", "", true},
+		{"whitespace only", " \t ", true},
+	}
+
+	for _, tt := range tests {
+		if err := validateTagName(tt.tag); (err != nil) != tt.wantErr {
+			t.Errorf("%s: validateTagName(%q) = %v, wantErr %v", tt.name, tt.tag, err, tt.wantErr)
+		}
+	}
+}
+
+func TestValidateTagNamesSkipsEmpty(t *testing.T) {
+	if err := validateTagNames([]string{"", "  ", "bug"}); err != nil {
+		t.Errorf("validateTagNames with empty names = %v", err)
+	}
+	if err := validateTagNames([]string{"bug", strings.Repeat("x", models.MaxTagNameLength+1)}); err == nil {
+		t.Error("validateTagNames accepted a too long name")
+	}
+}
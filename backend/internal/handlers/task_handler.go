@@ -0,0 +1,1174 @@
+package handlers
+
+import (
+	"dev-bridge-manager/internal/database"
+	"dev-bridge-manager/internal/models"
+	"dev-bridge-manager/internal/sanitize"
+	"dev-bridge-manager/internal/services"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+type TaskHandler struct {
+	permissionService *services.PermissionService
+	kanbanService     *services.KanbanService
+	taskService       *services.TaskService
+	tagService        *services.TagService
+	taskLinkService   *services.TaskLinkService
+	sprintService     *services.SprintService
+}
+
+func NewTaskHandler() *TaskHandler {
+	return &TaskHandler{
+		permissionService: services.NewPermissionService(),
+		kanbanService:     services.NewKanbanService(),
+		taskService:       services.NewTaskService(),
+		tagService:        services.NewTagService(),
+		taskLinkService:   services.NewTaskLinkService(),
+		sprintService:     services.NewSprintService(),
+	}
+}
+
+// validateTaskCreateRequest - egyszerű validáció validator csomag nélkül
+func (h *TaskHandler) validateTaskCreateRequest(req *models.TaskCreateRequest) error {
+	if strings.TrimSpace(req.Title) == "" {
+		return fiber.NewError(400, "Task title is required")
+	}
+	if len(req.Title) > 255 {
+		return fiber.NewError(400, "Task title must be less than 255 characters")
+	}
+	if req.ColumnID == 0 {
+		return fiber.NewError(400, "Column ID is required")
+	}
+	if req.Priority != "" && !slices.Contains(models.ValidTaskPriorities, req.Priority) {
+		return fiber.NewError(400, "Priority must be one of: low, medium, high, urgent")
+	}
+	if req.EstimatedHours < 0 {
+		return fiber.NewError(400, "Estimated hours cannot be negative")
+	}
+	return validateTagNames(req.Tags)
+}
+
+// validateTaskUpdateRequest - egyszerű validáció validator csomag nélkül
+func (h *TaskHandler) validateTaskUpdateRequest(req *models.TaskUpdateRequest) error {
+	if req.Title != nil && strings.TrimSpace(*req.Title) == "" {
+		return fiber.NewError(400, "Task title cannot be empty")
+	}
+	if req.Title != nil && len(*req.Title) > 255 {
+		return fiber.NewError(400, "Task title must be less than 255 characters")
+	}
+	if req.Priority != nil && !slices.Contains(models.ValidTaskPriorities, *req.Priority) {
+		return fiber.NewError(400, "Priority must be one of: low, medium, high, urgent")
+	}
+	if req.EstimatedHours != nil && *req.EstimatedHours < 0 {
+		return fiber.NewError(400, "Estimated hours cannot be negative")
+	}
+	return validateTagNames(req.Tags)
+}
+
+// validateAssignee - a hozzárendelt usernek a projekt tagjának kell lennie (0 = hozzárendelés törlése)
+func (h *TaskHandler) validateAssignee(projectID uint, assigneeID *uint) error {
+	if assigneeID == nil || *assigneeID == 0 {
+		return nil
+	}
+	ok, err := h.permissionService.HasProjectRole(*assigneeID, projectID, "member")
+	if err != nil {
+		return fiber.NewError(400, "Assignee not found")
+	}
+	if !ok {
+		return fiber.NewError(400, "Assignee must be a member of the project")
+	}
+	return nil
+}
+
+// taskUpdateMap - a megadott (nem nil) mezőkből összeállítja a frissítendő oszlopokat
+func taskUpdateMap(req *models.TaskUpdateRequest, userID uint) map[string]interface{} {
+	updates := map[string]interface{}{
+		"updated_by": userID,
+	}
+	if req.Title != nil {
+		updates["title"] = strings.TrimSpace(*req.Title)
+	}
+	// A HTML leírás tisztítva kerül mentésre, a plain text leírás belőle származik
+	if req.HTMLDescription != nil {
+		plain := ""
+		if req.Description != nil {
+			plain = *req.Description
+		}
+		updates["html_description"], updates["description"] = sanitize.RichText(*req.HTMLDescription, plain)
+	} else if req.Description != nil {
+		// A régi HTML már nem egyezne a leírással, ezért törlődik; a kliens a plain textet mutatja
+		updates["description"] = sanitize.PlainText(*req.Description)
+		updates["html_description"] = ""
+	}
+	if req.Priority != nil {
+		updates["priority"] = *req.Priority
+	}
+	if req.AssigneeID != nil {
+		// 0 = hozzárendelés törlése
+		if *req.AssigneeID == 0 {
+			updates["assignee_id"] = nil
+		} else {
+			updates["assignee_id"] = *req.AssigneeID
+		}
+	}
+	if req.EstimatedHours != nil {
+		updates["estimated_hours"] = *req.EstimatedHours
+	}
+	if req.DueDate != nil {
+		updates["due_date"] = *req.DueDate
+	}
+	if req.ParentID != nil {
+		// 0 = leválasztás a szülő taskról
+		if *req.ParentID == 0 {
+			updates["parent_id"] = nil
+		} else {
+			updates["parent_id"] = *req.ParentID
+		}
+	}
+	return updates
+}
+
+// parentError - a szülő task ellenőrzés hibájának HTTP hibává alakítása
+func parentError(err error) error {
+	switch {
+	case errors.Is(err, services.ErrParentNotFound):
+		return fiber.NewError(400, "Parent task not found in this project")
+	case errors.Is(err, services.ErrSubtaskDepth):
+		return fiber.NewError(400, "Subtasks can only be nested one level below a top-level task")
+	}
+	return err
+}
+
+// assignSprintError - a sprint hozzárendelés hibájának HTTP hibává alakítása
+func assignSprintError(err error) error {
+	switch {
+	case errors.Is(err, services.ErrSprintNotFound):
+		return fiber.NewError(400, "Sprint not found in this project")
+	case errors.Is(err, services.ErrSprintClosed):
+		return fiber.NewError(409, "Closed sprints cannot take new tasks")
+	}
+	return err
+}
+
+// applyTaskFilters - a frontend TaskFilters query paramétereinek alkalmazása
+func applyTaskFilters(c *fiber.Ctx, query *gorm.DB) *gorm.DB {
+	args := c.Context().QueryArgs()
+
+	if values := args.PeekMulti("assigneeId"); len(values) > 0 {
+		var ids []uint
+		for _, v := range values {
+			if id, err := strconv.ParseUint(string(v), 10, 32); err == nil {
+				ids = append(ids, uint(id))
+			}
+		}
+		query = query.Where("tasks.assignee_id IN ?", ids)
+	}
+
+	if values := args.PeekMulti("priority"); len(values) > 0 {
+		var priorities []string
+		for _, v := range values {
+			priorities = append(priorities, string(v))
+		}
+		query = query.Where("tasks.priority IN ?", priorities)
+	}
+
+	if values := args.PeekMulti("tag"); len(values) > 0 {
+		var tags []string
+		for _, v := range values {
+			tags = append(tags, strings.ToLower(strings.TrimSpace(string(v))))
+		}
+		query = query.Where(`EXISTS (
+			SELECT 1 FROM task_tags JOIN tags ON tags.id = task_tags.tag_id
+			WHERE task_tags.task_id = tasks.id AND LOWER(tags.name) IN ?)`, tags)
+	}
+
+	// parentId=none: csak a legfelső szintű taskok, parentId=<id>: az adott task subtaskjai
+	switch parentID := c.Query("parentId"); parentID {
+	case "":
+	case "none":
+		query = query.Where("tasks.parent_id IS NULL")
+	default:
+		if id, err := strconv.ParseUint(parentID, 10, 32); err == nil {
+			query = query.Where("tasks.parent_id = ?", uint(id))
+		}
+	}
+
+	// sprintId=none: a backlog, sprintId=<id>: az adott sprint taskjai
+	switch sprintID := c.Query("sprintId"); sprintID {
+	case "":
+	case "none":
+		query = query.Where("tasks.sprint_id IS NULL")
+	default:
+		if id, err := strconv.ParseUint(sprintID, 10, 32); err == nil {
+			query = query.Where("tasks.sprint_id = ?", uint(id))
+		}
+	}
+
+	if search := strings.TrimSpace(c.Query("search")); search != "" {
+		pattern := "%" + search + "%"
+		query = query.Where("(tasks.title ILIKE ? OR tasks.description ILIKE ?)", pattern, pattern)
+	}
+
+	switch c.Query("hasEstimate") {
+	case "true":
+		query = query.Where("tasks.estimated_hours > 0")
+	case "false":
+		query = query.Where("COALESCE(tasks.estimated_hours, 0) = 0")
+	}
+
+	switch c.Query("isOverdue") {
+	case "true":
+		query = query.Where("tasks.due_date < NOW() AND tasks.status <> ?", "done")
+	case "false":
+		query = query.Where("(tasks.due_date IS NULL OR tasks.due_date >= NOW() OR tasks.status = ?)", "done")
+	}
+
+	return query
+}
+
+// findProjectTask - a projekthez tartozó task betöltése assignee-vel és tagekkel együtt
+func findProjectTask(projectID, taskID uint) (*models.Task, error) {
+	var task models.Task
+	err := database.GetDB().Preload("Assignee").Preload("Tags").
+		Where("id = ? AND project_id = ?", taskID, projectID).
+		First(&task).Error
+	if err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+// taskResponses - taskok API formára alakítása a subtaskokból és checklistából összesített haladással
+func taskResponses(ts *services.TaskService, tasks []models.Task) ([]models.TaskResponse, error) {
+	progress, err := ts.Progress(tasks)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]models.TaskResponse, 0, len(tasks))
+	for i := range tasks {
+		item := tasks[i].ToResponse()
+		item.Progress = progress[tasks[i].ID]
+		response = append(response, item)
+	}
+	return response, nil
+}
+
+// taskResponse - egy task API formája haladással együtt
+func taskResponse(ts *services.TaskService, task *models.Task) (*models.TaskResponse, error) {
+	response, err := taskResponses(ts, []models.Task{*task})
+	if err != nil {
+		return nil, err
+	}
+	return &response[0], nil
+}
+
+// GetTasks - GET /api/v1/projects/:id/tasks
+func (h *TaskHandler) GetTasks(c *fiber.Ctx) error {
+	currentUserID := c.Locals("userID").(uint)
+
+	projectID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(400).JSON(models.TaskListResponse{
+			Success: false,
+			Message: "Invalid project ID",
+		})
+	}
+
+	if err := requireProjectRole(h.permissionService, currentUserID, projectID, "viewer"); err != nil {
+		return err
+	}
+
+	query := database.GetDB().Model(&models.Task{}).
+		Preload("Assignee").
+		Preload("Tags").
+		Where("tasks.project_id = ?", projectID)
+	query = applyTaskFilters(c, query)
+
+	// Archivált taskok: alapból rejtettek, archived=true csak azokat, archived=all mindet adja vissza
+	switch c.Query("archived") {
+	case "true":
+		query = query.Where("tasks.archived_at IS NOT NULL")
+	case "all":
+	default:
+		query = query.Where("tasks.archived_at IS NULL")
+	}
+
+	var tasks []models.Task
+	if err := query.Order("tasks.column_id ASC, tasks.position ASC").Find(&tasks).Error; err != nil {
+		return c.Status(500).JSON(models.TaskListResponse{
+			Success: false,
+			Message: "Error fetching tasks",
+		})
+	}
+
+	response, err := taskResponses(h.taskService, tasks)
+	if err != nil {
+		return c.Status(500).JSON(models.TaskListResponse{
+			Success: false,
+			Message: "Error fetching tasks",
+		})
+	}
+
+	return c.JSON(models.TaskListResponse{
+		Success: true,
+		Message: "Tasks retrieved successfully",
+		Tasks:   response,
+		Count:   len(response),
+	})
+}
+
+// GetTask - GET /api/v1/projects/:id/tasks/:taskId
+func (h *TaskHandler) GetTask(c *fiber.Ctx) error {
+	currentUserID := c.Locals("userID").(uint)
+
+	projectID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(400).JSON(models.TaskListResponse{
+			Success: false,
+			Message: "Invalid project ID",
+		})
+	}
+
+	taskID, err := parseIDParam(c, "taskId")
+	if err != nil {
+		return c.Status(400).JSON(models.TaskListResponse{
+			Success: false,
+			Message: "Invalid task ID",
+		})
+	}
+
+	if err := requireProjectRole(h.permissionService, currentUserID, projectID, "viewer"); err != nil {
+		return err
+	}
+
+	task, err := findProjectTask(projectID, taskID)
+	if err != nil {
+		return c.Status(404).JSON(models.TaskListResponse{
+			Success: false,
+			Message: "Task not found",
+		})
+	}
+
+	response, err := taskResponse(h.taskService, task)
+	if err != nil {
+		return c.Status(500).JSON(models.TaskListResponse{
+			Success: false,
+			Message: "Error loading task progress",
+		})
+	}
+
+	return c.JSON(models.TaskListResponse{
+		Success: true,
+		Message: "Task retrieved successfully",
+		Task:    response,
+	})
+}
+
+// CreateTask - POST /api/v1/projects/:id/tasks
+func (h *TaskHandler) CreateTask(c *fiber.Ctx) error {
+	currentUserID := c.Locals("userID").(uint)
+
+	projectID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(400).JSON(models.TaskListResponse{
+			Success: false,
+			Message: "Invalid project ID",
+		})
+	}
+
+	if err := requireProjectRole(h.permissionService, currentUserID, projectID, "member"); err != nil {
+		return err
+	}
+
+	var req models.TaskCreateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(models.TaskListResponse{
+			Success: false,
+			Message: "Invalid request body",
+		})
+	}
+
+	// Validáció
+	if err := h.validateTaskCreateRequest(&req); err != nil {
+		return err
+	}
+	if err := h.validateAssignee(projectID, req.AssigneeID); err != nil {
+		return err
+	}
+
+	// Projekt létezésének ellenőrzése
+	var project models.Project
+	if err := database.GetDB().First(&project, projectID).Error; err != nil {
+		return c.Status(404).JSON(models.TaskListResponse{
+			Success: false,
+			Message: "Project not found",
+		})
+	}
+
+	// Az oszlopnak a projekt board-jához kell tartoznia
+	if _, err := h.kanbanService.GetProjectColumn(database.GetDB(), projectID, req.ColumnID); err != nil {
+		return c.Status(400).JSON(models.TaskListResponse{
+			Success: false,
+			Message: "Column does not belong to this project",
+		})
+	}
+
+	// Subtask: a szülő a projekt egy legfelső szintű taskja kell legyen
+	if req.ParentID != nil && *req.ParentID == 0 {
+		req.ParentID = nil
+	}
+	if req.ParentID != nil {
+		if err := h.taskService.ValidateParent(database.GetDB(), projectID, 0, *req.ParentID); err != nil {
+			return parentError(err)
+		}
+	}
+
+	// Sprint: a projekt egy nem lezárt sprintje lehet, 0 = backlog
+	var sprint *models.Sprint
+	if req.SprintID != nil && *req.SprintID != 0 {
+		sprint, err = h.sprintService.ValidateSprint(database.GetDB(), projectID, *req.SprintID)
+		if err != nil {
+			return assignSprintError(err)
+		}
+	}
+
+	// Default priority beállítása
+	if req.Priority == "" {
+		req.Priority = "medium"
+	}
+
+	htmlDescription, description := sanitize.RichText(req.HTMLDescription, req.Description)
+
+	task := models.Task{
+		ProjectID:       projectID,
+		ColumnID:        req.ColumnID,
+		ParentID:        req.ParentID,
+		Title:           strings.TrimSpace(req.Title),
+		Description:     description,
+		HTMLDescription: htmlDescription,
+		Priority:        req.Priority,
+		AssigneeID:      req.AssigneeID,
+		EstimatedHours:  req.EstimatedHours,
+		DueDate:         req.DueDate,
+		CreatedBy:       currentUserID,
+		UpdatedBy:       currentUserID,
+	}
+
+	// A tagek név alapján kapcsolódnak, a hiányzók létrejönnek
+	tags, err := h.tagService.ResolveTags(database.GetDB(), projectID, req.Tags)
+	if err != nil {
+		return c.Status(500).JSON(models.TaskListResponse{
+			Success: false,
+			Message: "Error saving task tags",
+		})
+	}
+	task.Tags = tags
+	if sprint != nil {
+		task.SprintID = &sprint.ID
+	}
+
+	// Az új task az oszlop végére kerül, futó sprintnél a scope változással együtt
+	err = database.GetDB().Transaction(func(tx *gorm.DB) error {
+		if err := h.taskService.CreateTaskTx(tx, &task); err != nil {
+			return err
+		}
+		if sprint != nil && sprint.State == models.SprintActive {
+			return h.sprintService.RecordScopeChange(tx, sprint.ID, &task, models.ScopeAdded, currentUserID)
+		}
+		return nil
+	})
+	if err != nil {
+		return c.Status(500).JSON(models.TaskListResponse{
+			Success: false,
+			Message: "Error creating task",
+		})
+	}
+
+	// Visszatöltjük az assignee-vel együtt
+	created, err := findProjectTask(projectID, task.ID)
+	if err != nil {
+		return c.Status(500).JSON(models.TaskListResponse{
+			Success: false,
+			Message: "Task created but failed to load details",
+		})
+	}
+
+	response, err := taskResponse(h.taskService, created)
+	if err != nil {
+		return c.Status(500).JSON(models.TaskListResponse{
+			Success: false,
+			Message: "Task created but failed to load details",
+		})
+	}
+
+	return c.Status(201).JSON(models.TaskListResponse{
+		Success: true,
+		Message: "Task created successfully",
+		Task:    response,
+	})
+}
+
+// UpdateTask - PUT /api/v1/projects/:id/tasks/:taskId
+func (h *TaskHandler) UpdateTask(c *fiber.Ctx) error {
+	currentUserID := c.Locals("userID").(uint)
+
+	projectID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(400).JSON(models.TaskListResponse{
+			Success: false,
+			Message: "Invalid project ID",
+		})
+	}
+
+	taskID, err := parseIDParam(c, "taskId")
+	if err != nil {
+		return c.Status(400).JSON(models.TaskListResponse{
+			Success: false,
+			Message: "Invalid task ID",
+		})
+	}
+
+	if err := requireProjectRole(h.permissionService, currentUserID, projectID, "member"); err != nil {
+		return err
+	}
+
+	var req models.TaskUpdateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(models.TaskListResponse{
+			Success: false,
+			Message: "Invalid request body",
+		})
+	}
+
+	// Validáció
+	if err := h.validateTaskUpdateRequest(&req); err != nil {
+		return err
+	}
+
+	task, err := findProjectTask(projectID, taskID)
+	if err != nil {
+		return c.Status(404).JSON(models.TaskListResponse{
+			Success: false,
+			Message: "Task not found",
+		})
+	}
+
+	if err := h.validateAssignee(projectID, req.AssigneeID); err != nil {
+		return err
+	}
+
+	// Csak a megadott mezők frissítése
+	updates := taskUpdateMap(&req, currentUserID)
+
+	err = database.GetDB().Transaction(func(tx *gorm.DB) error {
+		if req.ParentID != nil && *req.ParentID != 0 {
+			if err := h.taskService.ValidateParent(tx, projectID, task.ID, *req.ParentID); err != nil {
+				return err
+			}
+		}
+		if err := tx.Model(task).Updates(updates).Error; err != nil {
+			return err
+		}
+		if req.Tags != nil {
+			if err := h.tagService.SetTaskTagNames(tx, task, req.Tags); err != nil {
+				return err
+			}
+		}
+		// A becslés frissítése után, hogy a scope változás az új óraszámot rögzítse
+		if req.SprintID != nil {
+			return h.sprintService.AssignTask(tx, task, *req.SprintID, currentUserID)
+		}
+		return nil
+	})
+	if errors.Is(err, services.ErrParentNotFound) || errors.Is(err, services.ErrSubtaskDepth) {
+		return parentError(err)
+	}
+	if errors.Is(err, services.ErrSprintNotFound) || errors.Is(err, services.ErrSprintClosed) {
+		return assignSprintError(err)
+	}
+	if err != nil {
+		return c.Status(500).JSON(models.TaskListResponse{
+			Success: false,
+			Message: "Error updating task",
+		})
+	}
+
+	// Frissített task visszatöltése
+	updated, err := findProjectTask(projectID, taskID)
+	if err != nil {
+		return c.Status(500).JSON(models.TaskListResponse{
+			Success: false,
+			Message: "Task updated but failed to load details",
+		})
+	}
+
+	response, err := taskResponse(h.taskService, updated)
+	if err != nil {
+		return c.Status(500).JSON(models.TaskListResponse{
+			Success: false,
+			Message: "Task updated but failed to load details",
+		})
+	}
+
+	return c.JSON(models.TaskListResponse{
+		Success: true,
+		Message: "Task updated successfully",
+		Task:    response,
+	})
+}
+
+// DeleteTask - DELETE /api/v1/projects/:id/tasks/:taskId
+func (h *TaskHandler) DeleteTask(c *fiber.Ctx) error {
+	currentUserID := c.Locals("userID").(uint)
+
+	projectID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(400).JSON(models.TaskListResponse{
+			Success: false,
+			Message: "Invalid project ID",
+		})
+	}
+
+	taskID, err := parseIDParam(c, "taskId")
+	if err != nil {
+		return c.Status(400).JSON(models.TaskListResponse{
+			Success: false,
+			Message: "Invalid task ID",
+		})
+	}
+
+	if err := requireProjectRole(h.permissionService, currentUserID, projectID, "member"); err != nil {
+		return err
+	}
+
+	task, err := findProjectTask(projectID, taskID)
+	if err != nil {
+		return c.Status(404).JSON(models.TaskListResponse{
+			Success: false,
+			Message: "Task not found",
+		})
+	}
+
+	if err := h.taskService.DeleteTask(c.Context(), task); err != nil {
+		return c.Status(500).JSON(models.TaskListResponse{
+			Success: false,
+			Message: "Error deleting task",
+		})
+	}
+
+	return c.JSON(models.TaskListResponse{
+		Success: true,
+		Message: "Task deleted successfully",
+	})
+}
+
+// MoveTask - PUT /api/v1/projects/:id/tasks/:taskId/move
+// A mozgatás és a pozíciók újraszámozása egy tranzakcióban történik
+func (h *TaskHandler) MoveTask(c *fiber.Ctx) error {
+	currentUserID := c.Locals("userID").(uint)
+
+	projectID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(400).JSON(models.TaskListResponse{
+			Success: false,
+			Message: "Invalid project ID",
+		})
+	}
+
+	taskID, err := parseIDParam(c, "taskId")
+	if err != nil {
+		return c.Status(400).JSON(models.TaskListResponse{
+			Success: false,
+			Message: "Invalid task ID",
+		})
+	}
+
+	if err := requireProjectRole(h.permissionService, currentUserID, projectID, "member"); err != nil {
+		return err
+	}
+
+	var req models.TaskMoveRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(models.TaskListResponse{
+			Success: false,
+			Message: "Invalid request body",
+		})
+	}
+
+	if req.ColumnID == 0 {
+		return fiber.NewError(400, "Column ID is required")
+	}
+	if req.Position < 0 {
+		return fiber.NewError(400, "Position cannot be negative")
+	}
+
+	task, err := h.taskService.MoveTask(projectID, taskID, req.ColumnID, req.Position, currentUserID, req.Force)
+	if err != nil {
+		var wipErr *services.WipLimitError
+		var openErr *services.OpenSubtasksError
+		var transitionErr *services.TransitionError
+		switch {
+		case errors.As(err, &transitionErr):
+			return c.Status(422).JSON(fiber.Map{
+				"success":        false,
+				"message":        transitionErr.Error(),
+				"fromColumnId":   transitionErr.From.ID,
+				"toColumnId":     transitionErr.To.ID,
+				"requiredRole":   transitionErr.RequiredRole,
+				"allowedTargets": transitionErr.Allowed,
+			})
+		case errors.As(err, &openErr):
+			return c.Status(409).JSON(fiber.Map{
+				"success":      false,
+				"message":      openErr.Error(),
+				"openSubtasks": openErr.OpenCount,
+			})
+		case errors.As(err, &wipErr):
+			return c.Status(409).JSON(fiber.Map{
+				"success":  false,
+				"message":  wipErr.Error(),
+				"columnId": wipErr.ColumnID,
+				"column":   wipErr.ColumnTitle,
+				"maxTasks": wipErr.MaxTasks,
+			})
+		case errors.Is(err, services.ErrConcurrentMove):
+			return c.Status(409).JSON(models.TaskListResponse{
+				Success: false,
+				Message: err.Error(),
+			})
+		case errors.Is(err, services.ErrTaskArchived):
+			return c.Status(409).JSON(models.TaskListResponse{
+				Success: false,
+				Message: "Archived tasks cannot be moved; restore the task first",
+			})
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return c.Status(404).JSON(models.TaskListResponse{
+				Success: false,
+				Message: "Task or column not found",
+			})
+		}
+		return c.Status(500).JSON(models.TaskListResponse{
+			Success: false,
+			Message: "Error moving task",
+		})
+	}
+
+	response, err := taskResponse(h.taskService, task)
+	if err != nil {
+		return c.Status(500).JSON(models.TaskListResponse{
+			Success: false,
+			Message: "Task moved but failed to load details",
+		})
+	}
+
+	// Megoldatlan blokkolók esetén a mozgatás megtörténik, de figyelmeztetést kap a kliens
+	warning, err := h.blockerWarning(currentUserID, task)
+	if err != nil {
+		return c.Status(500).JSON(models.TaskListResponse{
+			Success: false,
+			Message: "Task moved but failed to check blockers",
+		})
+	}
+
+	return c.JSON(models.TaskListResponse{
+		Success: true,
+		Message: "Task moved successfully",
+		Task:    response,
+		Warning: warning,
+	})
+}
+
+// blockerWarning - figyelmeztetés, ha a task "in progress" oszlopba került, de még blokkolják.
+// A nem látható projektek blokkolói csak a darabszámban szerepelnek.
+func (h *TaskHandler) blockerWarning(userID uint, task *models.Task) (*models.BlockerWarning, error) {
+	column, err := h.kanbanService.GetProjectColumn(database.GetDB(), task.ProjectID, task.ColumnID)
+	if err != nil {
+		return nil, err
+	}
+	if column.Category != "in_progress" {
+		return nil, nil
+	}
+
+	blockers, err := h.taskLinkService.UnresolvedBlockers(task.ID)
+	if err != nil || len(blockers) == 0 {
+		return nil, err
+	}
+
+	projectIDs := make([]uint, 0, len(blockers))
+	for i := range blockers {
+		projectIDs = append(projectIDs, blockers[i].ProjectID)
+	}
+	visible, err := h.permissionService.VisibleProjects(userID, projectIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	warning := &models.BlockerWarning{
+		Message:  fmt.Sprintf("Task is blocked by %d unresolved task(s)", len(blockers)),
+		Blockers: []models.LinkedTaskResponse{},
+	}
+	for i := range blockers {
+		if visible[blockers[i].ProjectID] {
+			warning.Blockers = append(warning.Blockers, blockers[i].ToLinkedResponse())
+		}
+	}
+	return warning, nil
+}
+
+// maxBulkTaskUpdates - egy bulk kérésben módosítható taskok maximális száma
+const maxBulkTaskUpdates = 500
+
+// errBulkRejected - legalább egy tétel hibás volt, a tranzakció visszagörgetve
+var errBulkRejected = errors.New("bulk update rejected")
+
+// applyBulkItem - egy bulk tétel ellenőrzése és alkalmazása a tranzakción belül.
+// Tétel szintű hiba esetén üzenetet ad vissza, adatbázis hiba esetén error-t.
+func (h *TaskHandler) applyBulkItem(tx *gorm.DB, projectID, userID uint, item *models.TaskBulkUpdateItem) (string, error) {
+	if item.TaskID == 0 {
+		return "Task ID is required", nil
+	}
+
+	var task models.Task
+	err := tx.Where("id = ? AND project_id = ?", item.TaskID, projectID).First(&task).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "Task not found in this project", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if err := h.validateTaskUpdateRequest(&item.Data.TaskUpdateRequest); err != nil {
+		return err.Error(), nil
+	}
+	if err := h.validateAssignee(projectID, item.Data.AssigneeID); err != nil {
+		return err.Error(), nil
+	}
+	if item.Data.ParentID != nil && *item.Data.ParentID != 0 {
+		err := h.taskService.ValidateParent(tx, projectID, task.ID, *item.Data.ParentID)
+		if errors.Is(err, services.ErrParentNotFound) || errors.Is(err, services.ErrSubtaskDepth) {
+			return parentError(err).Error(), nil
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if err := tx.Model(&task).Updates(taskUpdateMap(&item.Data.TaskUpdateRequest, userID)).Error; err != nil {
+		return "", err
+	}
+	if item.Data.Tags != nil {
+		if err := h.tagService.SetTaskTagNames(tx, &task, item.Data.Tags); err != nil {
+			return "", err
+		}
+	}
+	if item.Data.SprintID != nil {
+		err := h.sprintService.AssignTask(tx, &task, *item.Data.SprintID, userID)
+		if errors.Is(err, services.ErrSprintNotFound) || errors.Is(err, services.ErrSprintClosed) {
+			return assignSprintError(err).Error(), nil
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+
+	// Oszlop váltás: a cél oszlop végére kerül, WIP limit ellenőrzéssel
+	if item.Data.ColumnID != nil && *item.Data.ColumnID != task.ColumnID {
+		err := h.taskService.MoveTaskTx(tx, projectID, task.ID, *item.Data.ColumnID, math.MaxInt32, userID, item.Data.Force)
+		var wipErr *services.WipLimitError
+		var openErr *services.OpenSubtasksError
+		var transitionErr *services.TransitionError
+		switch {
+		case errors.As(err, &transitionErr):
+			return transitionErr.Error(), nil
+		case errors.As(err, &wipErr):
+			return wipErr.Error(), nil
+		case errors.As(err, &openErr):
+			return openErr.Error(), nil
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return "Column does not belong to this project", nil
+		case errors.Is(err, services.ErrTaskArchived):
+			return "Archived tasks cannot be moved; restore the task first", nil
+		case err != nil:
+			return "", err
+		}
+	}
+
+	return "", nil
+}
+
+// BulkUpdateTasks - PUT /api/v1/projects/:id/tasks/bulk
+// Minden tétel egy tranzakcióban fut: ha bármelyik hibás, semmi sem módosul
+func (h *TaskHandler) BulkUpdateTasks(c *fiber.Ctx) error {
+	currentUserID := c.Locals("userID").(uint)
+
+	projectID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(400).JSON(models.TaskBulkUpdateResponse{
+			Success: false,
+			Message: "Invalid project ID",
+		})
+	}
+
+	if err := requireProjectRole(h.permissionService, currentUserID, projectID, "member"); err != nil {
+		return err
+	}
+
+	var req models.TaskBulkUpdateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(models.TaskBulkUpdateResponse{
+			Success: false,
+			Message: "Invalid request body",
+		})
+	}
+
+	if len(req.Updates) == 0 {
+		return fiber.NewError(400, "At least one update is required")
+	}
+	if len(req.Updates) > maxBulkTaskUpdates {
+		return fiber.NewError(400, "Too many updates in one request (max "+strconv.Itoa(maxBulkTaskUpdates)+")")
+	}
+
+	var itemErrors []models.TaskBulkItemError
+	taskIDs := make([]uint, 0, len(req.Updates))
+
+	err = database.GetDB().Transaction(func(tx *gorm.DB) error {
+		// Az érintett oszlopok zárolása előre, ID sorrendben, hogy párhuzamos kérésekkel ne legyen deadlock
+		columnIDs := []uint{}
+		for _, item := range req.Updates {
+			taskIDs = append(taskIDs, item.TaskID)
+			if item.Data.ColumnID != nil {
+				columnIDs = append(columnIDs, *item.Data.ColumnID)
+			}
+		}
+		var currentColumnIDs []uint
+		if err := tx.Model(&models.Task{}).
+			Where("id IN ? AND project_id = ?", taskIDs, projectID).
+			Distinct().Pluck("column_id", &currentColumnIDs).Error; err != nil {
+			return err
+		}
+		if _, err := h.taskService.LockColumns(tx, append(columnIDs, currentColumnIDs...)...); err != nil {
+			return err
+		}
+
+		for i := range req.Updates {
+			item := &req.Updates[i]
+			message, err := h.applyBulkItem(tx, projectID, currentUserID, item)
+			if err != nil {
+				return err
+			}
+			if message != "" {
+				itemErrors = append(itemErrors, models.TaskBulkItemError{
+					Index:   i,
+					TaskID:  item.TaskID,
+					Message: message,
+				})
+			}
+		}
+
+		if len(itemErrors) > 0 {
+			return errBulkRejected
+		}
+		return nil
+	})
+
+	if errors.Is(err, errBulkRejected) {
+		return c.Status(422).JSON(models.TaskBulkUpdateResponse{
+			Success: false,
+			Message: "Bulk update rejected; no tasks were changed",
+			Errors:  itemErrors,
+		})
+	}
+	if err != nil {
+		return c.Status(500).JSON(models.TaskBulkUpdateResponse{
+			Success: false,
+			Message: "Error updating tasks",
+		})
+	}
+
+	// Frissített taskok visszatöltése
+	var tasks []models.Task
+	if err := database.GetDB().Preload("Assignee").Preload("Tags").
+		Where("id IN ?", taskIDs).
+		Order("column_id ASC, position ASC").
+		Find(&tasks).Error; err != nil {
+		return c.Status(500).JSON(models.TaskBulkUpdateResponse{
+			Success: false,
+			Message: "Tasks updated but failed to load details",
+		})
+	}
+
+	response, err := taskResponses(h.taskService, tasks)
+	if err != nil {
+		return c.Status(500).JSON(models.TaskBulkUpdateResponse{
+			Success: false,
+			Message: "Tasks updated but failed to load details",
+		})
+	}
+
+	return c.JSON(models.TaskBulkUpdateResponse{
+		Success: true,
+		Message: "Tasks updated successfully",
+		Tasks:   response,
+		Count:   len(response),
+	})
+}
+
+// runTaskAction - közös lebonyolítás a task életciklus végpontokhoz (duplicate, archive, restore)
+func (h *TaskHandler) runTaskAction(c *fiber.Ctx, successStatus int, successMessage string,
+	action func(projectID, taskID, userID uint) (*models.Task, error)) error {
+	currentUserID := c.Locals("userID").(uint)
+
+	projectID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(400).JSON(models.TaskListResponse{
+			Success: false,
+			Message: "Invalid project ID",
+		})
+	}
+
+	taskID, err := parseIDParam(c, "taskId")
+	if err != nil {
+		return c.Status(400).JSON(models.TaskListResponse{
+			Success: false,
+			Message: "Invalid task ID",
+		})
+	}
+
+	if err := requireProjectRole(h.permissionService, currentUserID, projectID, "member"); err != nil {
+		return err
+	}
+
+	task, err := action(projectID, taskID, currentUserID)
+	if err != nil {
+		var wipErr *services.WipLimitError
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return c.Status(404).JSON(models.TaskListResponse{
+				Success: false,
+				Message: "Task not found",
+			})
+		case errors.Is(err, services.ErrTaskArchived):
+			return c.Status(409).JSON(models.TaskListResponse{
+				Success: false,
+				Message: "Task is already archived",
+			})
+		case errors.Is(err, services.ErrTaskNotArchived):
+			return c.Status(409).JSON(models.TaskListResponse{
+				Success: false,
+				Message: "Task is not archived",
+			})
+		case errors.As(err, &wipErr):
+			return c.Status(409).JSON(fiber.Map{
+				"success":  false,
+				"message":  wipErr.Error(),
+				"columnId": wipErr.ColumnID,
+				"column":   wipErr.ColumnTitle,
+				"maxTasks": wipErr.MaxTasks,
+			})
+		}
+		return c.Status(500).JSON(models.TaskListResponse{
+			Success: false,
+			Message: "Error processing task",
+		})
+	}
+
+	response, err := taskResponse(h.taskService, task)
+	if err != nil {
+		return c.Status(500).JSON(models.TaskListResponse{
+			Success: false,
+			Message: "Error processing task",
+		})
+	}
+
+	return c.Status(successStatus).JSON(models.TaskListResponse{
+		Success: true,
+		Message: successMessage,
+		Task:    response,
+	})
+}
+
+// DuplicateTask - POST /api/v1/projects/:id/tasks/:taskId/duplicate
+// Leírás, tagek és becslés másolódik, időbejegyzések és kommentek nem
+func (h *TaskHandler) DuplicateTask(c *fiber.Ctx) error {
+	return h.runTaskAction(c, 201, "Task duplicated successfully", h.taskService.DuplicateTask)
+}
+
+// ArchiveTask - PUT /api/v1/projects/:id/tasks/:taskId/archive
+func (h *TaskHandler) ArchiveTask(c *fiber.Ctx) error {
+	return h.runTaskAction(c, 200, "Task archived successfully", h.taskService.ArchiveTask)
+}
+
+// RestoreTask - PUT /api/v1/projects/:id/tasks/:taskId/restore
+// Az eredeti oszlopba és pozícióra kerül vissza
+func (h *TaskHandler) RestoreTask(c *fiber.Ctx) error {
+	return h.runTaskAction(c, 200, "Task restored successfully", h.taskService.RestoreTask)
+}
+
+// GetSubtasks - GET /api/v1/projects/:id/tasks/:taskId/subtasks
+// A task (nem archivált) subtaskjai board sorrendben
+func (h *TaskHandler) GetSubtasks(c *fiber.Ctx) error {
+	currentUserID := c.Locals("userID").(uint)
+
+	projectID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(400).JSON(models.TaskListResponse{
+			Success: false,
+			Message: "Invalid project ID",
+		})
+	}
+
+	taskID, err := parseIDParam(c, "taskId")
+	if err != nil {
+		return c.Status(400).JSON(models.TaskListResponse{
+			Success: false,
+			Message: "Invalid task ID",
+		})
+	}
+
+	if err := requireProjectRole(h.permissionService, currentUserID, projectID, "viewer"); err != nil {
+		return err
+	}
+
+	if _, err := findProjectTask(projectID, taskID); err != nil {
+		return c.Status(404).JSON(models.TaskListResponse{
+			Success: false,
+			Message: "Task not found",
+		})
+	}
+
+	var tasks []models.Task
+	err = database.GetDB().Preload("Assignee").Preload("Tags").
+		Where("project_id = ? AND parent_id = ? AND archived_at IS NULL", projectID, taskID).
+		Order("column_id ASC, position ASC").
+		Find(&tasks).Error
+	if err != nil {
+		return c.Status(500).JSON(models.TaskListResponse{
+			Success: false,
+			Message: "Error fetching subtasks",
+		})
+	}
+
+	response, err := taskResponses(h.taskService, tasks)
+	if err != nil {
+		return c.Status(500).JSON(models.TaskListResponse{
+			Success: false,
+			Message: "Error fetching subtasks",
+		})
+	}
+
+	return c.JSON(models.TaskListResponse{
+		Success: true,
+		Message: "Subtasks retrieved successfully",
+		Tasks:   response,
+		Count:   len(response),
+	})
+}
@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"dev-bridge-manager/internal/models"
+	"testing"
+)
+
+func TestTaskUpdateMapDescription(t *testing.T) {
+	str := func(s string) *string { return &s }
+
+	tests := []struct {
+		name     string
+		req      models.TaskUpdateRequest
+		wantHTML interface{}
+		wantText interface{}
+	}{
+		{
+			name:     "rich text derives the plain text",
+			req:      models.TaskUpdateRequest{HTMLDescription: str(`<p>Hi <script>x()</script><b>there</b></p>`)},
+			wantHTML: `<p>Hi <b>there</b></p>`,
+			wantText: "Hi there",
+		},
+		{
+			name:     "description only clears the stale HTML",
+			req:      models.TaskUpdateRequest{Description: str("  new <b>text</b> ")},
+			wantHTML: "",
+			wantText: "new text",
+		},
+		{
+			name:     "neither leaves both untouched",
+			req:      models.TaskUpdateRequest{Title: str("title")},
+			wantHTML: nil,
+			wantText: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		updates := taskUpdateMap(&tt.req, 1)
+		if updates["html_description"] != tt.wantHTML || updates["description"] != tt.wantText {
+			t.Errorf("%s: html_description = %#v, description = %#v; want %#v, %#v",
+				tt.name, updates["html_description"], updates["description"], tt.wantHTML, tt.wantText)
+		}
+	}
+}
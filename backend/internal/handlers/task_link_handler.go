@@ -0,0 +1,364 @@
+package handlers
+
+import (
+	"dev-bridge-manager/internal/database"
+	"dev-bridge-manager/internal/models"
+	"dev-bridge-manager/internal/services"
+	"errors"
+	"slices"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type TaskLinkHandler struct {
+	permissionService *services.PermissionService
+	taskLinkService   *services.TaskLinkService
+}
+
+func NewTaskLinkHandler() *TaskLinkHandler {
+	return &TaskLinkHandler{
+		permissionService: services.NewPermissionService(),
+		taskLinkService:   services.NewTaskLinkService(),
+	}
+}
+
+// linkResponse - a kapcsolat a megadott task szemszögéből
+func linkResponse(link *models.TaskLink, taskID uint) models.TaskLinkResponse {
+	response := models.TaskLinkResponse{
+		ID:        link.ID,
+		Type:      link.LinkType,
+		CreatedBy: link.CreatedBy,
+		CreatedAt: link.CreatedAt,
+	}
+
+	other := link.TargetTask
+	if link.TargetTaskID == taskID {
+		other = link.SourceTask
+		if link.LinkType == models.TaskLinkBlocks {
+			response.Type = "blocked_by"
+		}
+	}
+	if other != nil {
+		response.Task = other.ToLinkedResponse()
+	}
+	return response
+}
+
+// linkedProjectIDs - a kapcsolatokban szereplő taskok projektjei
+func linkedProjectIDs(links []models.TaskLink) []uint {
+	var ids []uint
+	for _, link := range links {
+		for _, task := range []*models.Task{link.SourceTask, link.TargetTask} {
+			if task != nil && !slices.Contains(ids, task.ProjectID) {
+				ids = append(ids, task.ProjectID)
+			}
+		}
+	}
+	return ids
+}
+
+// visibleLinks - csak azok a kapcsolatok maradnak, amelyek mindkét végét láthatja a user
+func (h *TaskLinkHandler) visibleLinks(userID uint, links []models.TaskLink) ([]models.TaskLink, error) {
+	visible, err := h.permissionService.VisibleProjects(userID, linkedProjectIDs(links))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]models.TaskLink, 0, len(links))
+	for _, link := range links {
+		if link.SourceTask != nil && link.TargetTask != nil &&
+			visible[link.SourceTask.ProjectID] && visible[link.TargetTask.ProjectID] {
+			result = append(result, link)
+		}
+	}
+	return result, nil
+}
+
+// GetTaskLinks - GET /api/v1/projects/:id/tasks/:taskId/links
+func (h *TaskLinkHandler) GetTaskLinks(c *fiber.Ctx) error {
+	currentUserID := c.Locals("userID").(uint)
+
+	projectID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(400).JSON(models.TaskLinkListResponse{
+			Success: false,
+			Message: "Invalid project ID",
+		})
+	}
+
+	taskID, err := parseIDParam(c, "taskId")
+	if err != nil {
+		return c.Status(400).JSON(models.TaskLinkListResponse{
+			Success: false,
+			Message: "Invalid task ID",
+		})
+	}
+
+	if err := requireProjectRole(h.permissionService, currentUserID, projectID, "viewer"); err != nil {
+		return err
+	}
+
+	if _, err := findProjectTask(projectID, taskID); err != nil {
+		return c.Status(404).JSON(models.TaskLinkListResponse{
+			Success: false,
+			Message: "Task not found",
+		})
+	}
+
+	links, err := h.taskLinkService.TaskLinks(taskID)
+	if err == nil {
+		links, err = h.visibleLinks(currentUserID, links)
+	}
+	if err != nil {
+		return c.Status(500).JSON(models.TaskLinkListResponse{
+			Success: false,
+			Message: "Error fetching task links",
+		})
+	}
+
+	response := make([]models.TaskLinkResponse, 0, len(links))
+	for i := range links {
+		response = append(response, linkResponse(&links[i], taskID))
+	}
+
+	return c.JSON(models.TaskLinkListResponse{
+		Success: true,
+		Message: "Task links retrieved successfully",
+		Links:   response,
+		Count:   len(response),
+	})
+}
+
+// CreateTaskLink - POST /api/v1/projects/:id/tasks/:taskId/links
+// A kapcsolt task más projektben is lehet, ha a user látja azt a projektet
+func (h *TaskLinkHandler) CreateTaskLink(c *fiber.Ctx) error {
+	currentUserID := c.Locals("userID").(uint)
+
+	projectID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(400).JSON(models.TaskLinkListResponse{
+			Success: false,
+			Message: "Invalid project ID",
+		})
+	}
+
+	taskID, err := parseIDParam(c, "taskId")
+	if err != nil {
+		return c.Status(400).JSON(models.TaskLinkListResponse{
+			Success: false,
+			Message: "Invalid task ID",
+		})
+	}
+
+	if err := requireProjectRole(h.permissionService, currentUserID, projectID, "member"); err != nil {
+		return err
+	}
+
+	var req models.TaskLinkCreateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(models.TaskLinkListResponse{
+			Success: false,
+			Message: "Invalid request body",
+		})
+	}
+
+	// Validáció
+	if req.TargetTaskID == 0 {
+		return fiber.NewError(400, "Target task ID is required")
+	}
+	if !slices.Contains(models.ValidTaskLinkTypes, req.Type) {
+		return fiber.NewError(400, "Type must be one of: blocks, blocked_by, relates_to")
+	}
+
+	if _, err := findProjectTask(projectID, taskID); err != nil {
+		return c.Status(404).JSON(models.TaskLinkListResponse{
+			Success: false,
+			Message: "Task not found",
+		})
+	}
+
+	// A cél task projektjét is látnia kell a usernek; különben nem létezőként kezeljük
+	var target models.Task
+	if err := database.GetDB().First(&target, req.TargetTaskID).Error; err != nil {
+		return c.Status(404).JSON(models.TaskLinkListResponse{
+			Success: false,
+			Message: "Target task not found",
+		})
+	}
+	canView, err := h.permissionService.HasProjectRole(currentUserID, target.ProjectID, "viewer")
+	if err != nil {
+		return c.Status(500).JSON(models.TaskLinkListResponse{
+			Success: false,
+			Message: "Error checking project permissions",
+		})
+	}
+	if !canView {
+		return c.Status(404).JSON(models.TaskLinkListResponse{
+			Success: false,
+			Message: "Target task not found",
+		})
+	}
+
+	link := services.NewLink(taskID, target.ID, req.Type, currentUserID)
+	err = h.taskLinkService.CreateLink(&link)
+	switch {
+	case errors.Is(err, services.ErrLinkSelf):
+		return c.Status(400).JSON(models.TaskLinkListResponse{
+			Success: false,
+			Message: "A task cannot be linked to itself",
+		})
+	case errors.Is(err, services.ErrLinkExists):
+		return c.Status(409).JSON(models.TaskLinkListResponse{
+			Success: false,
+			Message: "Tasks are already linked this way",
+		})
+	case errors.Is(err, services.ErrLinkCycle):
+		return c.Status(409).JSON(models.TaskLinkListResponse{
+			Success: false,
+			Message: "Link would create a blocking cycle",
+		})
+	case err != nil:
+		return c.Status(500).JSON(models.TaskLinkListResponse{
+			Success: false,
+			Message: "Error creating task link",
+		})
+	}
+
+	created, err := h.taskLinkService.LoadLink(link.ID)
+	if err != nil {
+		return c.Status(500).JSON(models.TaskLinkListResponse{
+			Success: false,
+			Message: "Task link created but failed to load details",
+		})
+	}
+
+	response := linkResponse(created, taskID)
+	return c.Status(201).JSON(models.TaskLinkListResponse{
+		Success: true,
+		Message: "Task link created successfully",
+		Link:    &response,
+	})
+}
+
+// DeleteTaskLink - DELETE /api/v1/projects/:id/tasks/:taskId/links/:linkId
+func (h *TaskLinkHandler) DeleteTaskLink(c *fiber.Ctx) error {
+	currentUserID := c.Locals("userID").(uint)
+
+	projectID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(400).JSON(models.TaskLinkListResponse{
+			Success: false,
+			Message: "Invalid project ID",
+		})
+	}
+
+	taskID, err := parseIDParam(c, "taskId")
+	if err != nil {
+		return c.Status(400).JSON(models.TaskLinkListResponse{
+			Success: false,
+			Message: "Invalid task ID",
+		})
+	}
+
+	linkID, err := parseIDParam(c, "linkId")
+	if err != nil {
+		return c.Status(400).JSON(models.TaskLinkListResponse{
+			Success: false,
+			Message: "Invalid link ID",
+		})
+	}
+
+	if err := requireProjectRole(h.permissionService, currentUserID, projectID, "member"); err != nil {
+		return err
+	}
+
+	if _, err := findProjectTask(projectID, taskID); err != nil {
+		return c.Status(404).JSON(models.TaskLinkListResponse{
+			Success: false,
+			Message: "Task not found",
+		})
+	}
+
+	link, err := h.taskLinkService.LoadLink(linkID)
+	if err != nil || (link.SourceTaskID != taskID && link.TargetTaskID != taskID) {
+		return c.Status(404).JSON(models.TaskLinkListResponse{
+			Success: false,
+			Message: "Task link not found",
+		})
+	}
+
+	if err := h.taskLinkService.DeleteLink(link.ID); err != nil {
+		return c.Status(500).JSON(models.TaskLinkListResponse{
+			Success: false,
+			Message: "Error deleting task link",
+		})
+	}
+
+	return c.JSON(models.TaskLinkListResponse{
+		Success: true,
+		Message: "Task link deleted successfully",
+	})
+}
+
+// GetDependencyGraph - GET /api/v1/projects/:id/dependency-graph
+// A projekt kapcsolatban álló taskjai (node) és a kapcsolatok (edge); a más projektbeli taskok external jelölést kapnak
+func (h *TaskLinkHandler) GetDependencyGraph(c *fiber.Ctx) error {
+	currentUserID := c.Locals("userID").(uint)
+
+	projectID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(400).JSON(models.DependencyGraphResponse{
+			Success: false,
+			Message: "Invalid project ID",
+		})
+	}
+
+	if err := requireProjectRole(h.permissionService, currentUserID, projectID, "viewer"); err != nil {
+		return err
+	}
+
+	links, err := h.taskLinkService.ProjectLinks(projectID)
+	if err == nil {
+		links, err = h.visibleLinks(currentUserID, links)
+	}
+	if err != nil {
+		return c.Status(500).JSON(models.DependencyGraphResponse{
+			Success: false,
+			Message: "Error building dependency graph",
+		})
+	}
+
+	nodes := []models.DependencyGraphNode{}
+	edges := make([]models.DependencyGraphEdge, 0, len(links))
+	seen := make(map[uint]bool)
+	for _, link := range links {
+		for _, task := range []*models.Task{link.SourceTask, link.TargetTask} {
+			if seen[task.ID] {
+				continue
+			}
+			seen[task.ID] = true
+			nodes = append(nodes, models.DependencyGraphNode{
+				ID:        task.ID,
+				ProjectID: task.ProjectID,
+				Title:     task.Title,
+				Status:    task.Status,
+				ColumnID:  task.ColumnID,
+				Resolved:  task.IsResolved(),
+				External:  task.ProjectID != projectID,
+			})
+		}
+		edges = append(edges, models.DependencyGraphEdge{
+			ID:     link.ID,
+			Source: link.SourceTaskID,
+			Target: link.TargetTaskID,
+			Type:   link.LinkType,
+		})
+	}
+
+	return c.JSON(models.DependencyGraphResponse{
+		Success: true,
+		Message: "Dependency graph retrieved successfully",
+		Nodes:   nodes,
+		Edges:   edges,
+	})
+}
@@ -0,0 +1,472 @@
+package handlers
+
+import (
+	"dev-bridge-manager/internal/database"
+	"dev-bridge-manager/internal/models"
+	"dev-bridge-manager/internal/services"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+type TimeEntryHandler struct {
+	permissionService *services.PermissionService
+	kanbanService     *services.KanbanService
+	timeEntryService  *services.TimeEntryService
+}
+
+func NewTimeEntryHandler() *TimeEntryHandler {
+	return &TimeEntryHandler{
+		permissionService: services.NewPermissionService(),
+		kanbanService:     services.NewKanbanService(),
+		timeEntryService:  services.NewTimeEntryService(),
+	}
+}
+
+// parseEntryDate - YYYY-MM-DD dátum beolvasása, üres érték esetén a mai nap
+func parseEntryDate(value string) (time.Time, error) {
+	if value == "" {
+		value = time.Now().Format(models.TimeEntryDateLayout)
+	}
+	date, err := time.Parse(models.TimeEntryDateLayout, value)
+	if err != nil {
+		return time.Time{}, fiber.NewError(400, "Date must be in YYYY-MM-DD format")
+	}
+	return date, nil
+}
+
+// validateHours - a rögzített órák 0 és 24 között lehetnek
+func validateHours(hours float64) error {
+	if hours <= 0 || hours > 24 {
+		return fiber.NewError(400, "Hours must be greater than 0 and at most 24")
+	}
+	return nil
+}
+
+// requireTimeTracking - ellenőrzi, hogy a projekt board-ján engedélyezett-e az időkövetés
+func requireTimeTracking(ks *services.KanbanService, projectID uint) error {
+	board, err := ks.GetOrCreateBoard(projectID)
+	if err != nil {
+		return fiber.NewError(500, "Error loading board settings")
+	}
+	if !board.Settings.EnableTimeTracking {
+		return fiber.NewError(409, "Time tracking is disabled for this project")
+	}
+	return nil
+}
+
+// canModifyEntry - a bejegyzést csak a szerzője vagy a projekt manager módosíthatja.
+// A hívó előbb ellenőrzi a tagságot, így a projektből kikerült szerző már nem módosíthat.
+func (h *TimeEntryHandler) canModifyEntry(userID uint, entry *models.TimeEntry) error {
+	if entry.UserID == userID {
+		return nil
+	}
+	return requireProjectRole(h.permissionService, userID, entry.ProjectID, "manager")
+}
+
+// GetTaskTimeEntries - GET /api/v1/projects/:id/tasks/:taskId/time-entries
+func (h *TimeEntryHandler) GetTaskTimeEntries(c *fiber.Ctx) error {
+	currentUserID := c.Locals("userID").(uint)
+
+	projectID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(400).JSON(models.TimeEntryListResponse{
+			Success: false,
+			Message: "Invalid project ID",
+		})
+	}
+
+	taskID, err := parseIDParam(c, "taskId")
+	if err != nil {
+		return c.Status(400).JSON(models.TimeEntryListResponse{
+			Success: false,
+			Message: "Invalid task ID",
+		})
+	}
+
+	if err := requireProjectRole(h.permissionService, currentUserID, projectID, "viewer"); err != nil {
+		return err
+	}
+
+	var entries []models.TimeEntry
+	err = database.GetDB().Preload("User").
+		Where("project_id = ? AND task_id = ?", projectID, taskID).
+		Order("date DESC, created_at DESC").
+		Find(&entries).Error
+	if err != nil {
+		return c.Status(500).JSON(models.TimeEntryListResponse{
+			Success: false,
+			Message: "Error fetching time entries",
+		})
+	}
+
+	return c.JSON(buildTimeEntryList(entries))
+}
+
+// GetProjectTimeEntries - GET /api/v1/projects/:id/time-entries
+// Szűrők: userId, startDate, endDate, taskId (többször is megadható)
+func (h *TimeEntryHandler) GetProjectTimeEntries(c *fiber.Ctx) error {
+	currentUserID := c.Locals("userID").(uint)
+
+	projectID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(400).JSON(models.TimeEntryListResponse{
+			Success: false,
+			Message: "Invalid project ID",
+		})
+	}
+
+	if err := requireProjectRole(h.permissionService, currentUserID, projectID, "viewer"); err != nil {
+		return err
+	}
+
+	query := database.GetDB().Preload("User").Where("time_entries.project_id = ?", projectID)
+	query, err = applyTimeEntryFilters(c, query)
+	if err != nil {
+		return err
+	}
+
+	var entries []models.TimeEntry
+	if err := query.Order("date DESC, created_at DESC").Find(&entries).Error; err != nil {
+		return c.Status(500).JSON(models.TimeEntryListResponse{
+			Success: false,
+			Message: "Error fetching time entries",
+		})
+	}
+
+	return c.JSON(buildTimeEntryList(entries))
+}
+
+// GetTimeSummary - GET /api/v1/projects/:id/time-entries/summary
+// Összesített és számlázható órák, task és felhasználó szerinti bontásban (startDate, endDate, userId szűrők)
+func (h *TimeEntryHandler) GetTimeSummary(c *fiber.Ctx) error {
+	currentUserID := c.Locals("userID").(uint)
+
+	projectID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(400).JSON(models.TimeSummaryResponse{
+			Success: false,
+			Message: "Invalid project ID",
+		})
+	}
+
+	if err := requireProjectRole(h.permissionService, currentUserID, projectID, "viewer"); err != nil {
+		return err
+	}
+
+	query := database.GetDB().Where("time_entries.project_id = ?", projectID)
+	query, err = applyTimeEntryFilters(c, query)
+	if err != nil {
+		return err
+	}
+
+	summary, err := h.timeEntryService.Summarize(query)
+	if err != nil {
+		return c.Status(500).JSON(models.TimeSummaryResponse{
+			Success: false,
+			Message: "Error calculating time summary",
+		})
+	}
+
+	return c.JSON(summary)
+}
+
+// applyTimeEntryFilters - a frontend időbejegyzés szűrőinek alkalmazása
+func applyTimeEntryFilters(c *fiber.Ctx, query *gorm.DB) (*gorm.DB, error) {
+	if userID := c.Query("userId"); userID != "" {
+		id, err := strconv.ParseUint(userID, 10, 32)
+		if err != nil {
+			return nil, fiber.NewError(400, "Invalid user ID filter")
+		}
+		query = query.Where("time_entries.user_id = ?", id)
+	}
+
+	if startDate := c.Query("startDate"); startDate != "" {
+		date, err := time.Parse(models.TimeEntryDateLayout, startDate)
+		if err != nil {
+			return nil, fiber.NewError(400, "startDate must be in YYYY-MM-DD format")
+		}
+		query = query.Where("time_entries.date >= ?", date)
+	}
+
+	if endDate := c.Query("endDate"); endDate != "" {
+		date, err := time.Parse(models.TimeEntryDateLayout, endDate)
+		if err != nil {
+			return nil, fiber.NewError(400, "endDate must be in YYYY-MM-DD format")
+		}
+		query = query.Where("time_entries.date <= ?", date)
+	}
+
+	if values := c.Context().QueryArgs().PeekMulti("taskId"); len(values) > 0 {
+		var ids []uint
+		for _, v := range values {
+			if id, err := strconv.ParseUint(string(v), 10, 32); err == nil {
+				ids = append(ids, uint(id))
+			}
+		}
+		query = query.Where("time_entries.task_id IN ?", ids)
+	}
+
+	return query, nil
+}
+
+// buildTimeEntryList - lista válasz összeállítása összesített órákkal
+func buildTimeEntryList(entries []models.TimeEntry) models.TimeEntryListResponse {
+	response := make([]models.TimeEntryResponse, 0, len(entries))
+	var total float64
+	for i := range entries {
+		response = append(response, entries[i].ToResponse())
+		total += entries[i].Hours
+	}
+
+	return models.TimeEntryListResponse{
+		Success:     true,
+		Message:     "Time entries retrieved successfully",
+		TimeEntries: response,
+		Count:       len(response),
+		TotalHours:  total,
+	}
+}
+
+// CreateTimeEntry - POST /api/v1/projects/:id/tasks/:taskId/time-entries
+func (h *TimeEntryHandler) CreateTimeEntry(c *fiber.Ctx) error {
+	currentUserID := c.Locals("userID").(uint)
+
+	projectID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(400).JSON(models.TimeEntryListResponse{
+			Success: false,
+			Message: "Invalid project ID",
+		})
+	}
+
+	taskID, err := parseIDParam(c, "taskId")
+	if err != nil {
+		return c.Status(400).JSON(models.TimeEntryListResponse{
+			Success: false,
+			Message: "Invalid task ID",
+		})
+	}
+
+	if err := requireProjectRole(h.permissionService, currentUserID, projectID, "member"); err != nil {
+		return err
+	}
+	if err := requireTimeTracking(h.kanbanService, projectID); err != nil {
+		return err
+	}
+
+	var req models.TimeEntryCreateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(models.TimeEntryListResponse{
+			Success: false,
+			Message: "Invalid request body",
+		})
+	}
+
+	// Validáció
+	if err := validateHours(req.Hours); err != nil {
+		return err
+	}
+	date, err := parseEntryDate(req.Date)
+	if err != nil {
+		return err
+	}
+
+	// Task létezésének ellenőrzése
+	var task models.Task
+	if err := database.GetDB().Where("id = ? AND project_id = ?", taskID, projectID).First(&task).Error; err != nil {
+		return c.Status(404).JSON(models.TimeEntryListResponse{
+			Success: false,
+			Message: "Task not found",
+		})
+	}
+
+	// Számlázhatóság: a kérés értéke, különben a projekt alapértelmezése
+	var billable bool
+	if req.Billable != nil {
+		billable = *req.Billable
+	} else if billable, err = h.timeEntryService.DefaultBillable(database.GetDB(), projectID); err != nil {
+		return c.Status(500).JSON(models.TimeEntryListResponse{
+			Success: false,
+			Message: "Error loading project settings",
+		})
+	}
+
+	entry := models.TimeEntry{
+		TaskID:      taskID,
+		ProjectID:   projectID,
+		UserID:      currentUserID,
+		Hours:       req.Hours,
+		Description: req.Description,
+		Date:        date,
+		Billable:    billable,
+	}
+
+	if err := h.timeEntryService.CreateEntry(&entry); err != nil {
+		return c.Status(500).JSON(models.TimeEntryListResponse{
+			Success: false,
+			Message: "Error creating time entry",
+		})
+	}
+
+	created, err := h.timeEntryService.LoadEntry(projectID, entry.ID)
+	if err != nil {
+		return c.Status(500).JSON(models.TimeEntryListResponse{
+			Success: false,
+			Message: "Time entry created but failed to load details",
+		})
+	}
+
+	response := created.ToResponse()
+	return c.Status(201).JSON(models.TimeEntryListResponse{
+		Success:   true,
+		Message:   "Time entry created successfully",
+		TimeEntry: &response,
+	})
+}
+
+// UpdateTimeEntry - PUT /api/v1/projects/:id/time-entries/:entryId
+// Csak a szerző vagy a projekt manager módosíthatja
+func (h *TimeEntryHandler) UpdateTimeEntry(c *fiber.Ctx) error {
+	currentUserID := c.Locals("userID").(uint)
+
+	projectID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(400).JSON(models.TimeEntryListResponse{
+			Success: false,
+			Message: "Invalid project ID",
+		})
+	}
+
+	entryID, err := parseIDParam(c, "entryId")
+	if err != nil {
+		return c.Status(400).JSON(models.TimeEntryListResponse{
+			Success: false,
+			Message: "Invalid time entry ID",
+		})
+	}
+
+	// Jelenlegi projekt tagság kell, a saját bejegyzéshez is - a 404 csak ezután derülhet ki
+	if err := requireProjectRole(h.permissionService, currentUserID, projectID, "member"); err != nil {
+		return err
+	}
+
+	var req models.TimeEntryUpdateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(models.TimeEntryListResponse{
+			Success: false,
+			Message: "Invalid request body",
+		})
+	}
+
+	entry, err := h.timeEntryService.LoadEntry(projectID, entryID)
+	if err != nil {
+		return c.Status(404).JSON(models.TimeEntryListResponse{
+			Success: false,
+			Message: "Time entry not found",
+		})
+	}
+
+	if err := h.canModifyEntry(currentUserID, entry); err != nil {
+		return err
+	}
+
+	// Csak a megadott mezők frissítése
+	updates := make(map[string]interface{})
+	if req.Hours != nil {
+		if err := validateHours(*req.Hours); err != nil {
+			return err
+		}
+		updates["hours"] = *req.Hours
+	}
+	if req.Description != nil {
+		updates["description"] = *req.Description
+	}
+	if req.Date != nil {
+		date, err := parseEntryDate(*req.Date)
+		if err != nil {
+			return err
+		}
+		updates["date"] = date
+	}
+	if req.Billable != nil {
+		updates["billable"] = *req.Billable
+	}
+
+	if len(updates) > 0 {
+		if err := h.timeEntryService.UpdateEntry(entry, updates); err != nil {
+			return c.Status(500).JSON(models.TimeEntryListResponse{
+				Success: false,
+				Message: "Error updating time entry",
+			})
+		}
+	}
+
+	updated, err := h.timeEntryService.LoadEntry(projectID, entryID)
+	if err != nil {
+		return c.Status(500).JSON(models.TimeEntryListResponse{
+			Success: false,
+			Message: "Time entry updated but failed to load details",
+		})
+	}
+
+	response := updated.ToResponse()
+	return c.JSON(models.TimeEntryListResponse{
+		Success:   true,
+		Message:   "Time entry updated successfully",
+		TimeEntry: &response,
+	})
+}
+
+// DeleteTimeEntry - DELETE /api/v1/projects/:id/time-entries/:entryId
+// Csak a szerző vagy a projekt manager törölheti
+func (h *TimeEntryHandler) DeleteTimeEntry(c *fiber.Ctx) error {
+	currentUserID := c.Locals("userID").(uint)
+
+	projectID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(400).JSON(models.TimeEntryListResponse{
+			Success: false,
+			Message: "Invalid project ID",
+		})
+	}
+
+	entryID, err := parseIDParam(c, "entryId")
+	if err != nil {
+		return c.Status(400).JSON(models.TimeEntryListResponse{
+			Success: false,
+			Message: "Invalid time entry ID",
+		})
+	}
+
+	// Jelenlegi projekt tagság kell, a saját bejegyzéshez is - a 404 csak ezután derülhet ki
+	if err := requireProjectRole(h.permissionService, currentUserID, projectID, "member"); err != nil {
+		return err
+	}
+
+	entry, err := h.timeEntryService.LoadEntry(projectID, entryID)
+	if err != nil {
+		return c.Status(404).JSON(models.TimeEntryListResponse{
+			Success: false,
+			Message: "Time entry not found",
+		})
+	}
+
+	if err := h.canModifyEntry(currentUserID, entry); err != nil {
+		return err
+	}
+
+	if err := h.timeEntryService.DeleteEntry(entry); err != nil {
+		return c.Status(500).JSON(models.TimeEntryListResponse{
+			Success: false,
+			Message: "Error deleting time entry",
+		})
+	}
+
+	return c.JSON(models.TimeEntryListResponse{
+		Success: true,
+		Message: "Time entry deleted successfully",
+	})
+}
@@ -0,0 +1,194 @@
+package handlers
+
+import (
+	"dev-bridge-manager/internal/database"
+	"dev-bridge-manager/internal/models"
+	"dev-bridge-manager/internal/services"
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type TimerHandler struct {
+	permissionService *services.PermissionService
+	kanbanService     *services.KanbanService
+	timerService      *services.TimerService
+}
+
+func NewTimerHandler() *TimerHandler {
+	return &TimerHandler{
+		permissionService: services.NewPermissionService(),
+		kanbanService:     services.NewKanbanService(),
+		timerService:      services.NewTimerService(),
+	}
+}
+
+// timerParams - projekt és task azonosító beolvasása, jogosultság ellenőrzése
+func (h *TimerHandler) timerParams(c *fiber.Ctx) (projectID, taskID uint, err error) {
+	currentUserID := c.Locals("userID").(uint)
+
+	if projectID, err = parseIDParam(c, "id"); err != nil {
+		return 0, 0, fiber.NewError(400, "Invalid project ID")
+	}
+	if taskID, err = parseIDParam(c, "taskId"); err != nil {
+		return 0, 0, fiber.NewError(400, "Invalid task ID")
+	}
+	if err = requireProjectRole(h.permissionService, currentUserID, projectID, "member"); err != nil {
+		return 0, 0, err
+	}
+	return projectID, taskID, nil
+}
+
+// timerErrorResponse - a timer service hibáinak HTTP válaszra fordítása
+func timerErrorResponse(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, services.ErrNoActiveTimer):
+		return c.Status(404).JSON(models.TimerActionResponse{
+			Success: false,
+			Message: "No active timer for this task",
+		})
+	case errors.Is(err, services.ErrTimerExceeded):
+		return c.Status(409).JSON(models.TimerActionResponse{
+			Success: false,
+			Message: "The running timer exceeded the maximum duration. Stop it with explicit hours or discard it",
+		})
+	}
+	return c.Status(500).JSON(models.TimerActionResponse{
+		Success: false,
+		Message: "Error processing timer",
+	})
+}
+
+// StartTimer - POST /api/v1/projects/:id/tasks/:taskId/timer/start
+// A felhasználó másik taskon futó timere automatikusan leáll és időbejegyzés lesz belőle
+func (h *TimerHandler) StartTimer(c *fiber.Ctx) error {
+	currentUserID := c.Locals("userID").(uint)
+
+	projectID, taskID, err := h.timerParams(c)
+	if err != nil {
+		return err
+	}
+	if err := requireTimeTracking(h.kanbanService, projectID); err != nil {
+		return err
+	}
+
+	// Task létezésének ellenőrzése
+	var task models.Task
+	if err := database.GetDB().Where("id = ? AND project_id = ?", taskID, projectID).First(&task).Error; err != nil {
+		return c.Status(404).JSON(models.TimerActionResponse{
+			Success: false,
+			Message: "Task not found",
+		})
+	}
+	if task.IsArchived() {
+		return c.Status(409).JSON(models.TimerActionResponse{
+			Success: false,
+			Message: "Cannot start a timer on an archived task",
+		})
+	}
+
+	timer, stopped, err := h.timerService.StartTimer(projectID, taskID, currentUserID)
+	if err != nil {
+		return timerErrorResponse(c, err)
+	}
+
+	response := models.TimerActionResponse{
+		Success: true,
+		Message: "Timer started successfully",
+	}
+	timerResponse := timer.ToResponse(time.Now(), h.timerService.MaxDuration)
+	response.Timer = &timerResponse
+	if stopped != nil {
+		entryResponse := stopped.ToResponse()
+		response.TimeEntry = &entryResponse
+		response.Message = "Previous timer stopped and new timer started"
+	}
+
+	return c.JSON(response)
+}
+
+// StopTimer - POST /api/v1/projects/:id/tasks/:taskId/timer/stop
+// A maximális időtartamot túllépő timer csak explicit órákkal vagy eldobással állítható le
+func (h *TimerHandler) StopTimer(c *fiber.Ctx) error {
+	currentUserID := c.Locals("userID").(uint)
+
+	projectID, taskID, err := h.timerParams(c)
+	if err != nil {
+		return err
+	}
+
+	var req models.TimerStopRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(400).JSON(models.TimerActionResponse{
+				Success: false,
+				Message: "Invalid request body",
+			})
+		}
+	}
+	if req.Hours != nil {
+		if err := validateHours(*req.Hours); err != nil {
+			return err
+		}
+	}
+
+	entry, err := h.timerService.StopTimer(projectID, taskID, currentUserID, req)
+	if err != nil {
+		return timerErrorResponse(c, err)
+	}
+
+	if entry == nil {
+		return c.JSON(models.TimerActionResponse{
+			Success: true,
+			Message: "Timer stopped, no time was logged",
+		})
+	}
+
+	entryResponse := entry.ToResponse()
+	return c.JSON(models.TimerActionResponse{
+		Success:   true,
+		Message:   "Timer stopped and time logged successfully",
+		TimeEntry: &entryResponse,
+	})
+}
+
+// GetActiveTimer - GET /api/v1/projects/:id/timer/active
+// A bejelentkezett felhasználó projektben futó timere (vagy null)
+func (h *TimerHandler) GetActiveTimer(c *fiber.Ctx) error {
+	currentUserID := c.Locals("userID").(uint)
+
+	projectID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(400).JSON(models.TimerActionResponse{
+			Success: false,
+			Message: "Invalid project ID",
+		})
+	}
+
+	if err := requireProjectRole(h.permissionService, currentUserID, projectID, "viewer"); err != nil {
+		return err
+	}
+
+	timer, err := h.timerService.GetActiveTimer(projectID, currentUserID)
+	if err != nil {
+		return c.Status(500).JSON(models.TimerActionResponse{
+			Success: false,
+			Message: "Error fetching active timer",
+		})
+	}
+
+	if timer == nil {
+		return c.JSON(models.TimerActionResponse{
+			Success: true,
+			Message: "No active timer",
+		})
+	}
+
+	response := timer.ToResponse(time.Now(), h.timerService.MaxDuration)
+	return c.JSON(models.TimerActionResponse{
+		Success: true,
+		Message: "Active timer retrieved successfully",
+		Timer:   &response,
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"dev-bridge-manager/internal/services"
 	"dev-bridge-manager/internal/types"
 	"strconv"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -13,7 +14,9 @@ import (
 type UsersHandler struct{}
 
 type UserHandler struct {
+	accountService    *services.AccountService
 	permissionService *services.PermissionService
+	sessionService    *services.SessionService
 }
 
 type CreateUserRequest struct {
@@ -84,7 +87,9 @@ func (h *UsersHandler) GetUser(c *fiber.Ctx) error {
 
 func NewUserHandler() *UserHandler {
 	return &UserHandler{
+		accountService:    services.NewAccountService(),
 		permissionService: services.NewPermissionService(),
+		sessionService:    services.NewSessionService(),
 	}
 }
 
@@ -101,10 +106,11 @@ func (h *UserHandler) GetAllUsers(c *fiber.Ctx) error {
 	var userResponses []types.UserResponse
 	for _, user := range users {
 		userResponses = append(userResponses, types.UserResponse{
-			ID:       user.ID,
-			Name:     user.Name,
-			Email:    user.Email,
-			Position: user.Position,
+			ID:            user.ID,
+			Name:          user.Name,
+			Email:         user.Email,
+			EmailVerified: user.EmailVerified(),
+			Position:      user.Position,
 			Role: types.RoleInfo{
 				ID:          user.Role.ID,
 				Name:        user.Role.Name,
@@ -154,10 +160,11 @@ func (h *UserHandler) GetUser(c *fiber.Ctx) error {
 		Success: true,
 		Message: "User retrieved successfully",
 		User: &types.UserResponse{
-			ID:       user.ID,
-			Name:     user.Name,
-			Email:    user.Email,
-			Position: user.Position,
+			ID:            user.ID,
+			Name:          user.Name,
+			Email:         user.Email,
+			EmailVerified: user.EmailVerified(),
+			Position:      user.Position,
 			Role: types.RoleInfo{
 				ID:          user.Role.ID,
 				Name:        user.Role.Name,
@@ -237,14 +244,21 @@ func (h *UserHandler) CreateUser(c *fiber.Ctx) error {
 		})
 	}
 
+	// Megerősítő email az új usernek
+	message := "User created successfully"
+	if err := h.accountService.SendVerification(&user); err != nil {
+		message = "User created successfully, but the confirmation email could not be sent"
+	}
+
 	return c.Status(201).JSON(types.UserListResponse{
 		Success: true,
-		Message: "User created successfully",
+		Message: message,
 		User: &types.UserResponse{
-			ID:       user.ID,
-			Name:     user.Name,
-			Email:    user.Email,
-			Position: user.Position,
+			ID:            user.ID,
+			Name:          user.Name,
+			Email:         user.Email,
+			EmailVerified: user.EmailVerified(),
+			Position:      user.Position,
 			Role: types.RoleInfo{
 				ID:          user.Role.ID,
 				Name:        user.Role.Name,
@@ -292,6 +306,15 @@ func (h *UserHandler) UpdateUser(c *fiber.Ctx) error {
 		}
 	}
 
+	// A jelenlegi szerepkör kell a token érvénytelenítéshez
+	var currentUser models.User
+	if err := database.GetDB().First(&currentUser, userID).Error; err != nil {
+		return c.Status(404).JSON(types.UserListResponse{
+			Success: false,
+			Message: "User not found",
+		})
+	}
+
 	// Email egyediség ellenőrzése (ha új email van megadva)
 	if req.Email != "" {
 		var existingUser models.User
@@ -308,9 +331,14 @@ func (h *UserHandler) UpdateUser(c *fiber.Ctx) error {
 	if req.Name != "" {
 		updates["name"] = req.Name
 	}
+	// Új email cím: a megerősítés újraindul
+	emailChanged := req.Email != "" && !strings.EqualFold(req.Email, currentUser.Email)
 	if req.Email != "" {
 		updates["email"] = req.Email
 	}
+	if emailChanged {
+		updates["email_verified_at"] = nil
+	}
 	if req.Position != "" {
 		updates["position"] = req.Position
 	}
@@ -355,6 +383,22 @@ func (h *UserHandler) UpdateUser(c *fiber.Ctx) error {
 		})
 	}
 
+	// Szerepkör vagy jelszó változásakor a kiadott tokenek és sessionök érvényüket vesztik
+	revokeReason := ""
+	if roleID, ok := updates["role_id"]; ok && roleID != currentUser.RoleID {
+		revokeReason = services.SessionRevokedRoleChanged
+	} else if req.Password != "" {
+		revokeReason = services.SessionRevokedPasswordChanged
+	}
+	if revokeReason != "" {
+		if err := h.sessionService.InvalidateUserTokens(uint(userID), revokeReason); err != nil {
+			return c.Status(500).JSON(types.UserListResponse{
+				Success: false,
+				Message: "User updated but failed to revoke existing sessions",
+			})
+		}
+	}
+
 	// Frissített user lekérése role-lal együtt
 	var updatedUser models.User
 	if err := database.GetDB().Preload("Role").First(&updatedUser, userID).Error; err != nil {
@@ -364,14 +408,22 @@ func (h *UserHandler) UpdateUser(c *fiber.Ctx) error {
 		})
 	}
 
+	message := "User updated successfully"
+	if emailChanged {
+		if err := h.accountService.SendVerification(&updatedUser); err != nil {
+			message = "User updated successfully, but the confirmation email could not be sent"
+		}
+	}
+
 	return c.JSON(types.UserListResponse{
 		Success: true,
-		Message: "User updated successfully",
+		Message: message,
 		User: &types.UserResponse{
-			ID:       updatedUser.ID,
-			Name:     updatedUser.Name,
-			Email:    updatedUser.Email,
-			Position: updatedUser.Position,
+			ID:            updatedUser.ID,
+			Name:          updatedUser.Name,
+			Email:         updatedUser.Email,
+			EmailVerified: updatedUser.EmailVerified(),
+			Position:      updatedUser.Position,
 			Role: types.RoleInfo{
 				ID:          updatedUser.Role.ID,
 				Name:        updatedUser.Role.Name,
@@ -439,10 +491,11 @@ func (h *UserHandler) GetProfile(c *fiber.Ctx) error {
 		Success: true,
 		Message: "Profile retrieved successfully",
 		User: &types.UserResponse{
-			ID:       user.ID,
-			Name:     user.Name,
-			Email:    user.Email,
-			Position: user.Position,
+			ID:            user.ID,
+			Name:          user.Name,
+			Email:         user.Email,
+			EmailVerified: user.EmailVerified(),
+			Position:      user.Position,
 			Role: types.RoleInfo{
 				ID:          user.Role.ID,
 				Name:        user.Role.Name,
@@ -472,6 +525,14 @@ func (h *UserHandler) UpdateProfile(c *fiber.Ctx) error {
 		})
 	}
 
+	var currentUser models.User
+	if err := database.GetDB().First(&currentUser, currentUserID).Error; err != nil {
+		return c.Status(404).JSON(types.UserListResponse{
+			Success: false,
+			Message: "User not found",
+		})
+	}
+
 	if req.Email != "" {
 		var existingUser models.User
 		if err := database.GetDB().Where("email = ? AND id != ?", req.Email, currentUserID).First(&existingUser).Error; err == nil {
@@ -486,9 +547,14 @@ func (h *UserHandler) UpdateProfile(c *fiber.Ctx) error {
 	if req.Name != "" {
 		updates["name"] = req.Name
 	}
+	// Új email cím: a megerősítésig ismét korlátozott jogosultságok
+	emailChanged := req.Email != "" && !strings.EqualFold(req.Email, currentUser.Email)
 	if req.Email != "" {
 		updates["email"] = req.Email
 	}
+	if emailChanged {
+		updates["email_verified_at"] = nil
+	}
 	if req.Position != "" {
 		updates["position"] = req.Position
 	}
@@ -508,14 +574,23 @@ func (h *UserHandler) UpdateProfile(c *fiber.Ctx) error {
 		})
 	}
 
+	message := "Profile updated successfully"
+	if emailChanged {
+		message = "Profile updated successfully. Please confirm your new email address."
+		if err := h.accountService.SendVerification(user); err != nil {
+			message = "Profile updated successfully, but the confirmation email could not be sent. Please request a new one."
+		}
+	}
+
 	return c.JSON(types.UserListResponse{
 		Success: true,
-		Message: "Profile updated successfully",
+		Message: message,
 		User: &types.UserResponse{
-			ID:       user.ID,
-			Name:     user.Name,
-			Email:    user.Email,
-			Position: user.Position,
+			ID:            user.ID,
+			Name:          user.Name,
+			Email:         user.Email,
+			EmailVerified: user.EmailVerified(),
+			Position:      user.Position,
 			Role: types.RoleInfo{
 				ID:          user.Role.ID,
 				Name:        user.Role.Name,
@@ -581,6 +656,14 @@ func (h *UserHandler) ChangePassword(c *fiber.Ctx) error {
 		})
 	}
 
+	// Minden session és kiadott token érvényét veszti, az újbóli belépés kötelező
+	if err := h.sessionService.InvalidateUserTokens(currentUserID, services.SessionRevokedPasswordChanged); err != nil {
+		return c.Status(500).JSON(types.UserListResponse{
+			Success: false,
+			Message: "Password changed but failed to revoke existing sessions",
+		})
+	}
+
 	return c.JSON(types.UserListResponse{
 		Success: true,
 		Message: "Password changed successfully. Please log in again.",
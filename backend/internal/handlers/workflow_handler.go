@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"dev-bridge-manager/internal/models"
+	"dev-bridge-manager/internal/services"
+	"errors"
+	"slices"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type WorkflowHandler struct {
+	permissionService *services.PermissionService
+	kanbanService     *services.KanbanService
+	workflowService   *services.WorkflowService
+}
+
+func NewWorkflowHandler() *WorkflowHandler {
+	return &WorkflowHandler{
+		permissionService: services.NewPermissionService(),
+		kanbanService:     services.NewKanbanService(),
+		workflowService:   services.NewWorkflowService(),
+	}
+}
+
+// workflowResult - a board oszlopai és átmenetei
+func (h *WorkflowHandler) workflowResult(c *fiber.Ctx, projectID uint, message string) error {
+	board, err := h.kanbanService.GetOrCreateBoard(projectID)
+	if err != nil {
+		return c.Status(404).JSON(models.WorkflowResponse{
+			Success: false,
+			Message: "Kanban board not found",
+		})
+	}
+
+	transitions, err := h.workflowService.ListTransitions(board.ID)
+	if err != nil {
+		return c.Status(500).JSON(models.WorkflowResponse{
+			Success: false,
+			Message: "Error fetching workflow transitions",
+		})
+	}
+
+	columns := make([]models.WorkflowColumn, 0, len(board.Columns))
+	for i := range board.Columns {
+		columns = append(columns, board.Columns[i].ToWorkflowColumn())
+	}
+
+	return c.JSON(models.WorkflowResponse{
+		Success:         true,
+		Message:         message,
+		EnforceWorkflow: board.Settings.EnforceWorkflow,
+		Columns:         columns,
+		Transitions:     transitions,
+	})
+}
+
+// GetWorkflow - GET /api/v1/projects/:id/workflow
+// Az oszlopok státusz kategóriái és az engedélyezett átmenetek
+func (h *WorkflowHandler) GetWorkflow(c *fiber.Ctx) error {
+	currentUserID := c.Locals("userID").(uint)
+
+	projectID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(400).JSON(models.WorkflowResponse{
+			Success: false,
+			Message: "Invalid project ID",
+		})
+	}
+
+	if err := requireProjectRole(h.permissionService, currentUserID, projectID, "viewer"); err != nil {
+		return err
+	}
+
+	return h.workflowResult(c, projectID, "Workflow retrieved successfully")
+}
+
+// UpdateWorkflow - PUT /api/v1/projects/:id/workflow
+// Bekapcsolt workflow mellett csak a felsorolt átmenetek engedélyezettek; a transitions lista
+// megadása a teljes listát lecseréli
+func (h *WorkflowHandler) UpdateWorkflow(c *fiber.Ctx) error {
+	currentUserID := c.Locals("userID").(uint)
+
+	projectID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(400).JSON(models.WorkflowResponse{
+			Success: false,
+			Message: "Invalid project ID",
+		})
+	}
+
+	if err := requireProjectRole(h.permissionService, currentUserID, projectID, "manager"); err != nil {
+		return err
+	}
+
+	var req models.WorkflowUpdateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(models.WorkflowResponse{
+			Success: false,
+			Message: "Invalid request body",
+		})
+	}
+
+	// Validáció
+	if req.EnforceWorkflow == nil && req.Transitions == nil {
+		return c.Status(400).JSON(models.WorkflowResponse{
+			Success: false,
+			Message: "Nothing to update",
+		})
+	}
+	for _, transition := range req.Transitions {
+		if transition.FromColumnID == 0 || transition.ToColumnID == 0 {
+			return fiber.NewError(400, "Both fromColumnId and toColumnId are required")
+		}
+		if transition.RequiredRole != "" && !slices.Contains(models.ValidTransitionRoles, transition.RequiredRole) {
+			return fiber.NewError(400, "Required role must be one of: member, reviewer, manager, owner")
+		}
+	}
+
+	board, err := h.kanbanService.GetOrCreateBoard(projectID)
+	if err != nil {
+		return c.Status(404).JSON(models.WorkflowResponse{
+			Success: false,
+			Message: "Kanban board not found",
+		})
+	}
+
+	err = h.workflowService.UpdateWorkflow(board, req.EnforceWorkflow, req.Transitions)
+	if errors.Is(err, services.ErrTransitionColumn) || errors.Is(err, services.ErrTransitionSelf) {
+		return c.Status(400).JSON(models.WorkflowResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+	}
+	if err != nil {
+		return c.Status(500).JSON(models.WorkflowResponse{
+			Success: false,
+			Message: "Error updating workflow",
+		})
+	}
+
+	return h.workflowResult(c, projectID, "Workflow updated successfully")
+}
@@ -0,0 +1,31 @@
+package jobs
+
+import (
+	"context"
+	"dev-bridge-manager/internal/services"
+	"log"
+	"time"
+)
+
+// archivePurgeJob permanently deletes tasks archived longer than the retention period.
+// TASK_ARCHIVE_RETENTION_DAYS (default 90) sets the retention,
+// TASK_ARCHIVE_PURGE_INTERVAL_HOURS (default 24, 0 disables) the schedule.
+func archivePurgeJob() Job {
+	retention := time.Duration(envInt("TASK_ARCHIVE_RETENTION_DAYS", 90)) * 24 * time.Hour
+	interval := time.Duration(envInt("TASK_ARCHIVE_PURGE_INTERVAL_HOURS", 24)) * time.Hour
+
+	return Job{
+		Name:     "archive-purge",
+		Interval: interval,
+		Run: func() error {
+			purged, err := services.NewTaskService().PurgeArchivedTasks(context.Background(), time.Now().Add(-retention))
+			if err != nil {
+				return err
+			}
+			if purged > 0 {
+				log.Printf("🗑️  Purged %d archived tasks older than %s", purged, retention)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,34 @@
+package jobs
+
+import (
+	"dev-bridge-manager/internal/services"
+	"log"
+	"time"
+)
+
+// dueReminderJob notifies assignees about tasks that are due soon or overdue, and escalates
+// long overdue tasks to the project's managers and owners. Each reminder fires once per task.
+// DUE_REMINDER_WINDOW_HOURS (default 24) sets how early the "due soon" reminder fires,
+// OVERDUE_ESCALATION_DAYS (default 3, 0 disables) when overdue tasks are escalated and
+// DUE_REMINDER_INTERVAL_MINUTES (default 15, 0 disables) the schedule.
+func dueReminderJob() Job {
+	window := time.Duration(envInt("DUE_REMINDER_WINDOW_HOURS", 24)) * time.Hour
+	escalateAfter := time.Duration(envInt("OVERDUE_ESCALATION_DAYS", 3)) * 24 * time.Hour
+	interval := time.Duration(envInt("DUE_REMINDER_INTERVAL_MINUTES", 15)) * time.Minute
+
+	return Job{
+		Name:     "due-reminders",
+		Interval: interval,
+		Run: func() error {
+			result, err := services.NewNotificationService().SendDueReminders(time.Now(), window, escalateAfter)
+			if err != nil {
+				return err
+			}
+			if result.DueSoon+result.Overdue+result.Escalations > 0 {
+				log.Printf("🔔 Sent %d due soon, %d overdue and %d escalation notifications",
+					result.DueSoon, result.Overdue, result.Escalations)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,63 @@
+package jobs
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Job is a periodic background task running inside the server process
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func() error
+}
+
+// Start launches every registered job in its own goroutine.
+// Each job runs once at startup and then on its interval.
+func Start() {
+	for _, job := range registeredJobs() {
+		if job.Interval <= 0 {
+			log.Printf("⏸️  Job %s disabled", job.Name)
+			continue
+		}
+		go runJob(job)
+	}
+}
+
+// registeredJobs returns the jobs started with the server
+func registeredJobs() []Job {
+	return []Job{
+		archivePurgeJob(),
+		timerFlagJob(),
+		progressSnapshotJob(),
+		recurringTaskJob(),
+		dueReminderJob(),
+		loginAttemptPurgeJob(),
+	}
+}
+
+func runJob(job Job) {
+	log.Printf("⏱️  Job %s scheduled every %s", job.Name, job.Interval)
+
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := job.Run(); err != nil {
+			log.Printf("❌ Job %s failed: %v", job.Name, err)
+		}
+		<-ticker.C
+	}
+}
+
+// envInt reads an integer environment variable with a default
+func envInt(key string, defaultValue int) int {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := strconv.Atoi(value); err == nil {
+			return parsed
+		}
+	}
+	return defaultValue
+}
@@ -0,0 +1,28 @@
+package jobs
+
+import (
+	"dev-bridge-manager/internal/services"
+	"log"
+	"time"
+)
+
+// loginAttemptPurgeJob drops failed login counters that can no longer lock anything.
+// LOGIN_ATTEMPT_PURGE_INTERVAL_HOURS (default 24, 0 disables) sets the schedule.
+func loginAttemptPurgeJob() Job {
+	interval := time.Duration(envInt("LOGIN_ATTEMPT_PURGE_INTERVAL_HOURS", 24)) * time.Hour
+
+	return Job{
+		Name:     "login-attempt-purge",
+		Interval: interval,
+		Run: func() error {
+			purged, err := services.NewLoginAttemptService().PruneStale(time.Now())
+			if err != nil {
+				return err
+			}
+			if purged > 0 {
+				log.Printf("🗑️  Purged %d stale login attempt counters", purged)
+			}
+			return nil
+		},
+	}
+}
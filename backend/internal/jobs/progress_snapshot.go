@@ -0,0 +1,28 @@
+package jobs
+
+import (
+	"dev-bridge-manager/internal/services"
+	"log"
+	"time"
+)
+
+// progressSnapshotJob stores today's snapshot of every active sprint and project for the
+// burndown and burnup charts. The day's row is overwritten on each run, so it ends up holding
+// the state at the end of the day. PROGRESS_SNAPSHOT_INTERVAL_MINUTES (default 60, 0 disables)
+// sets the schedule.
+func progressSnapshotJob() Job {
+	interval := time.Duration(envInt("PROGRESS_SNAPSHOT_INTERVAL_MINUTES", 60)) * time.Minute
+
+	return Job{
+		Name:     "progress-snapshot",
+		Interval: interval,
+		Run: func() error {
+			sprints, projects, err := services.NewSnapshotService().RecordSnapshots()
+			if err != nil {
+				return err
+			}
+			log.Printf("📈 Recorded progress snapshots for %d sprints and %d projects", sprints, projects)
+			return nil
+		},
+	}
+}
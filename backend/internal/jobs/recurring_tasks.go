@@ -0,0 +1,29 @@
+package jobs
+
+import (
+	"dev-bridge-manager/internal/services"
+	"log"
+	"time"
+)
+
+// recurringTaskJob creates the tasks of the due recurring task templates. The service holds a
+// Postgres advisory lock while it runs, so with several server instances only one creates tasks.
+// RECURRING_TASK_INTERVAL_MINUTES (default 1, 0 disables) sets the schedule.
+func recurringTaskJob() Job {
+	interval := time.Duration(envInt("RECURRING_TASK_INTERVAL_MINUTES", 1)) * time.Minute
+
+	return Job{
+		Name:     "recurring-tasks",
+		Interval: interval,
+		Run: func() error {
+			created, err := services.NewRecurringTaskService().RunDueTemplates(time.Now())
+			if err != nil {
+				return err
+			}
+			if created > 0 {
+				log.Printf("🔁 Created %d recurring tasks", created)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,28 @@
+package jobs
+
+import (
+	"dev-bridge-manager/internal/services"
+	"log"
+	"time"
+)
+
+// timerFlagJob flags timers left running past TIMER_MAX_HOURS so they are not
+// logged without confirmation. TIMER_FLAG_INTERVAL_MINUTES (default 15, 0 disables) sets the schedule.
+func timerFlagJob() Job {
+	interval := time.Duration(envInt("TIMER_FLAG_INTERVAL_MINUTES", 15)) * time.Minute
+
+	return Job{
+		Name:     "timer-flag",
+		Interval: interval,
+		Run: func() error {
+			flagged, err := services.NewTimerService().FlagExceededTimers()
+			if err != nil {
+				return err
+			}
+			if flagged > 0 {
+				log.Printf("🚩 Flagged %d timers running past the maximum duration", flagged)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,81 @@
+// Package mailer sends transactional emails behind a driver-agnostic interface.
+// The driver is selected with MAILER_DRIVER (log or smtp).
+package mailer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Message is a plain text email
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// ErrHeaderInjection is returned for a recipient or subject containing line breaks,
+// which could smuggle extra headers or recipients into the email
+var ErrHeaderInjection = errors.New("line breaks are not allowed in email headers")
+
+// validate rejects messages whose header fields contain line breaks
+func (msg Message) validate() error {
+	if strings.ContainsAny(msg.To, "\r\n") || strings.ContainsAny(msg.Subject, "\r\n") {
+		return ErrHeaderInjection
+	}
+	return nil
+}
+
+// Mailer delivers emails
+type Mailer interface {
+	// Send delivers the message or returns why it could not be handed over
+	Send(ctx context.Context, msg Message) error
+}
+
+var (
+	defaultMailer Mailer
+	defaultOnce   sync.Once
+)
+
+// Default returns the mailer configured by the environment.
+// A misconfigured driver stops the server at startup.
+func Default() Mailer {
+	defaultOnce.Do(func() {
+		m, err := NewFromEnv()
+		if err != nil {
+			log.Fatalf("❌ Mailer configuration error: %v", err)
+		}
+		defaultMailer = m
+	})
+	return defaultMailer
+}
+
+// NewFromEnv builds the driver selected by MAILER_DRIVER
+func NewFromEnv() (Mailer, error) {
+	switch driver := strings.ToLower(os.Getenv("MAILER_DRIVER")); driver {
+	case "", "log":
+		return NewLogMailer(), nil
+	case "smtp":
+		return NewSMTPMailer(SMTPConfig{
+			Host:     os.Getenv("SMTP_HOST"),
+			Port:     getEnv("SMTP_PORT", "587"),
+			Username: os.Getenv("SMTP_USERNAME"),
+			Password: os.Getenv("SMTP_PASSWORD"),
+			From:     getEnv("MAIL_FROM", "Dev Bridge Manager <no-reply@localhost>"),
+		})
+	default:
+		return nil, fmt.Errorf("unknown MAILER_DRIVER %q", driver)
+	}
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
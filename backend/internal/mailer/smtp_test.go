@@ -0,0 +1,218 @@
+package mailer
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"net"
+	"net/mail"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// sinkMessage is one email received by the test SMTP server
+type sinkMessage struct {
+	from string
+	rcpt []string
+	data string
+}
+
+// smtpSink is a minimal in-process SMTP server without STARTTLS and AUTH, like MailHog
+type smtpSink struct {
+	listener net.Listener
+
+	mu       sync.Mutex
+	conns    int
+	messages []sinkMessage
+}
+
+func newSMTPSink(t *testing.T) *smtpSink {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	sink := &smtpSink{listener: listener}
+	go sink.serve()
+	t.Cleanup(func() { listener.Close() })
+	return sink
+}
+
+func (s *smtpSink) port() string {
+	_, port, _ := net.SplitHostPort(s.listener.Addr().String())
+	return port
+}
+
+func (s *smtpSink) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.conns++
+		s.mu.Unlock()
+		go s.handle(conn)
+	}
+}
+
+func (s *smtpSink) handle(conn net.Conn) {
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+	r := bufio.NewReader(conn)
+	reply := func(line string) { io.WriteString(conn, line+"\r\n") }
+
+	reply("220 sink ESMTP")
+	var msg sinkMessage
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		command := strings.ToUpper(line)
+		switch {
+		case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+			reply("250-sink")
+			reply("250 8BITMIME")
+		case strings.HasPrefix(command, "MAIL FROM:"):
+			// A paraméterek (pl. BODY=8BITMIME) nem részei a címnek
+			msg = sinkMessage{from: strings.Fields(line[len("MAIL FROM:"):])[0]}
+			reply("250 OK")
+		case strings.HasPrefix(command, "RCPT TO:"):
+			msg.rcpt = append(msg.rcpt, line[len("RCPT TO:"):])
+			reply("250 OK")
+		case command == "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			var data strings.Builder
+			for {
+				dataLine, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if dataLine == ".\r\n" {
+					break
+				}
+				data.WriteString(strings.TrimPrefix(dataLine, "."))
+			}
+			msg.data = data.String()
+			s.mu.Lock()
+			s.messages = append(s.messages, msg)
+			s.mu.Unlock()
+			reply("250 OK: queued")
+		case command == "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+}
+
+func (s *smtpSink) received() (int, []sinkMessage) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.conns, append([]sinkMessage(nil), s.messages...)
+}
+
+func newTestSMTPMailer(t *testing.T, sink *smtpSink) *SMTPMailer {
+	t.Helper()
+	m, err := NewSMTPMailer(SMTPConfig{
+		Host: "127.0.0.1",
+		Port: sink.port(),
+		From: "Dev Bridge Manager <no-reply@example.com>",
+	})
+	if err != nil {
+		t.Fatalf("NewSMTPMailer: %v", err)
+	}
+	return m
+}
+
+func TestSMTPMailerSendsHeaders(t *testing.T) {
+	sink := newSMTPSink(t)
+	m := newTestSMTPMailer(t, sink)
+
+	err := m.Send(context.Background(), Message{
+		To:      "Jane Doe <jane@example.com>",
+		Subject: "Jelszó visszaállítása",
+		Body:    "Hello\nsecond line\n",
+	})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	_, messages := sink.received()
+	if len(messages) != 1 {
+		t.Fatalf("sink received %d messages, want 1", len(messages))
+	}
+	got := messages[0]
+	if got.from != "<no-reply@example.com>" || len(got.rcpt) != 1 || got.rcpt[0] != "<jane@example.com>" {
+		t.Errorf("envelope from %s to %v", got.from, got.rcpt)
+	}
+
+	parsed, err := mail.ReadMessage(strings.NewReader(got.data))
+	if err != nil {
+		t.Fatalf("parse received message: %v\n%s", err, got.data)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	if err != nil || subject != "Jelszó visszaállítása" {
+		t.Errorf("Subject = %q (%v), want %q", subject, err, "Jelszó visszaállítása")
+	}
+	to, err := parsed.Header.AddressList("To")
+	if err != nil || len(to) != 1 || to[0].Address != "jane@example.com" || to[0].Name != "Jane Doe" {
+		t.Errorf("To = %v (%v), want Jane Doe <jane@example.com>", to, err)
+	}
+	from, err := parsed.Header.AddressList("From")
+	if err != nil || len(from) != 1 || from[0].Address != "no-reply@example.com" {
+		t.Errorf("From = %v (%v)", from, err)
+	}
+	if parsed.Header.Get("Message-ID") == "" || parsed.Header.Get("Date") == "" {
+		t.Error("Message-ID and Date headers are missing")
+	}
+	if !strings.Contains(got.data, "\r\n\r\nHello\r\nsecond line\r\n") {
+		t.Errorf("body line endings are not normalized to CRLF: %q", got.data)
+	}
+}
+
+func TestSMTPMailerRejectsHeaderInjection(t *testing.T) {
+	sink := newSMTPSink(t)
+	m := newTestSMTPMailer(t, sink)
+
+	tests := []struct {
+		name string
+		msg  Message
+	}{
+		{"subject CRLF", Message{To: "jane@example.com", Subject: "Hi\r\nBcc: victim@example.com", Body: "x"}},
+		{"subject LF", Message{To: "jane@example.com", Subject: "Hi\nBcc: victim@example.com", Body: "x"}},
+		{"subject CR", Message{To: "jane@example.com", Subject: "Hi\rBcc: victim@example.com", Body: "x"}},
+		{"recipient CRLF", Message{To: "jane@example.com\r\nBcc: victim@example.com", Subject: "Hi", Body: "x"}},
+		{"recipient LF", Message{To: "jane@example.com\nvictim@example.com", Subject: "Hi", Body: "x"}},
+	}
+
+	for _, tt := range tests {
+		if err := m.Send(context.Background(), tt.msg); !errors.Is(err, ErrHeaderInjection) {
+			t.Errorf("%s: err = %v, want ErrHeaderInjection", tt.name, err)
+		}
+		if err := NewLogMailer().Send(context.Background(), tt.msg); !errors.Is(err, ErrHeaderInjection) {
+			t.Errorf("%s: log mailer err = %v, want ErrHeaderInjection", tt.name, err)
+		}
+	}
+
+	// Az elutasított üzenetek el sem jutnak az SMTP szerverig
+	if conns, _ := sink.received(); conns != 0 {
+		t.Errorf("sink got %d connections, want none", conns)
+	}
+}
+
+func TestSMTPMailerRejectsInvalidRecipient(t *testing.T) {
+	sink := newSMTPSink(t)
+	m := newTestSMTPMailer(t, sink)
+
+	if err := m.Send(context.Background(), Message{To: "not an address", Subject: "Hi"}); err == nil {
+		t.Error("Send to an invalid address succeeded")
+	}
+}
@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"dev-bridge-manager/internal/services"
+	"errors"
 	"strings"
 
 	"github.com/gofiber/fiber/v2"
@@ -40,10 +41,25 @@ func JWTMiddleware() fiber.Handler {
 			})
 		}
 
+		// Visszavont session vagy régebbi token verzió (jelszócsere, szerepkör módosítás)
+		if err := authService.CheckRevocation(claims); err != nil {
+			if errors.Is(err, services.ErrTokenRevoked) {
+				return c.Status(401).JSON(fiber.Map{
+					"success": false,
+					"message": "Token has been revoked, please log in again",
+				})
+			}
+			return c.Status(500).JSON(fiber.Map{
+				"success": false,
+				"message": "Failed to verify token",
+			})
+		}
+
 		// Set user info in context
 		c.Locals("userID", claims.UserID)
 		c.Locals("userEmail", claims.Email)
 		c.Locals("userName", claims.Name)
+		c.Locals("sessionID", claims.SessionID)
 
 		return c.Next()
 	}
@@ -0,0 +1,45 @@
+package models
+
+// DurationStats egy időtartam (órában) átlaga és percentilisei a befejezett taskokon
+type DurationStats struct {
+	Count        int     `json:"count"`
+	AverageHours float64 `json:"averageHours"`
+	P50Hours     float64 `json:"p50Hours"`
+	P85Hours     float64 `json:"p85Hours"`
+	P95Hours     float64 `json:"p95Hours"`
+}
+
+// ThroughputWeek egy hét (hétfőtől) alatt befejezett taskok száma
+type ThroughputWeek struct {
+	WeekStart string `json:"weekStart"`
+	Completed int    `json:"completed"`
+}
+
+// ColumnTime az oszlopban töltött idő az időszakon belül
+type ColumnTime struct {
+	ColumnID     uint    `json:"columnId"`
+	Title        string  `json:"title"`
+	Category     string  `json:"category"`
+	TaskCount    int     `json:"taskCount"`
+	TotalHours   float64 `json:"totalHours"`
+	AverageHours float64 `json:"averageHours"`
+}
+
+// ProjectAnalytics a projekt folyamat metrikái. A cycle time az első in_progress/review oszlopba
+// lépéstől, a lead time a létrehozástól a done oszlopba kerülésig tart.
+// A Bottleneck a munkában lévő (in_progress/review) oszlopok közül a legtöbb átlagos időt igénylő.
+type ProjectAnalytics struct {
+	From        string           `json:"from"`
+	To          string           `json:"to"`
+	CycleTime   DurationStats    `json:"cycleTime"`
+	LeadTime    DurationStats    `json:"leadTime"`
+	Throughput  []ThroughputWeek `json:"throughput"`
+	ColumnTimes []ColumnTime     `json:"columnTimes"`
+	Bottleneck  *ColumnTime      `json:"bottleneck,omitempty"`
+}
+
+type ProjectAnalyticsResponse struct {
+	Success   bool              `json:"success"`
+	Message   string            `json:"message"`
+	Analytics *ProjectAnalytics `json:"analytics,omitempty"`
+}
@@ -0,0 +1,68 @@
+package models
+
+import (
+	"time"
+)
+
+// TaskAttachment egy taskhoz feltöltött fájl metaadatai. A JSON mezők a frontend TaskAttachment típusát követik.
+type TaskAttachment struct {
+	ID           uint      `json:"id" gorm:"primaryKey"`
+	TaskID       uint      `json:"taskId" gorm:"not null;index"`
+	ProjectID    uint      `json:"projectId" gorm:"not null;index"`
+	StorageKey   string    `json:"-" gorm:"size:500;not null;uniqueIndex"`
+	Filename     string    `json:"filename" gorm:"size:255;not null"`
+	OriginalName string    `json:"originalName" gorm:"size:255;not null"`
+	MimeType     string    `json:"mimeType" gorm:"size:255;not null"`
+	Size         int64     `json:"size" gorm:"not null"`
+	UploadedBy   *uint     `json:"uploadedBy"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// TableName override
+func (TaskAttachment) TableName() string {
+	return "task_attachments"
+}
+
+type AttachmentResponse struct {
+	ID           uint      `json:"id"`
+	TaskID       uint      `json:"taskId"`
+	Filename     string    `json:"filename"`
+	OriginalName string    `json:"originalName"`
+	MimeType     string    `json:"mimeType"`
+	Size         int64     `json:"size"`
+	URL          string    `json:"url"`
+	URLExpiresAt time.Time `json:"urlExpiresAt"`
+	UploadedBy   *uint     `json:"uploadedBy"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// AttachmentUsage a projekt tárhely használata
+type AttachmentUsage struct {
+	UsedBytes  int64 `json:"usedBytes"`
+	QuotaBytes int64 `json:"quotaBytes"`
+}
+
+type AttachmentListResponse struct {
+	Success     bool                 `json:"success"`
+	Message     string               `json:"message"`
+	Attachment  *AttachmentResponse  `json:"attachment,omitempty"`
+	Attachments []AttachmentResponse `json:"attachments,omitempty"`
+	Count       int                  `json:"count,omitempty"`
+	Usage       *AttachmentUsage     `json:"usage,omitempty"`
+}
+
+// ToResponse converts an attachment to its API shape with a signed download URL
+func (a *TaskAttachment) ToResponse(url string, expiresAt time.Time) AttachmentResponse {
+	return AttachmentResponse{
+		ID:           a.ID,
+		TaskID:       a.TaskID,
+		Filename:     a.Filename,
+		OriginalName: a.OriginalName,
+		MimeType:     a.MimeType,
+		Size:         a.Size,
+		URL:          url,
+		URLExpiresAt: expiresAt,
+		UploadedBy:   a.UploadedBy,
+		CreatedAt:    a.CreatedAt,
+	}
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+)
+
+// TaskChecklistItem egy task checklistájának eleme, sorrenddel és kész jelzővel
+type TaskChecklistItem struct {
+	ID        uint       `json:"id" gorm:"primaryKey"`
+	TaskID    uint       `json:"taskId" gorm:"not null;index"`
+	Title     string     `json:"title" gorm:"size:255;not null"`
+	IsDone    bool       `json:"isDone" gorm:"not null"`
+	Position  int        `json:"position" gorm:"not null;default:0"`
+	DoneAt    *time.Time `json:"doneAt"`
+	DoneBy    *uint      `json:"doneBy"`
+	CreatedBy uint       `json:"createdBy" gorm:"not null"`
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt time.Time  `json:"updatedAt"`
+}
+
+// TableName override
+func (TaskChecklistItem) TableName() string {
+	return "task_checklist_items"
+}
+
+type ChecklistItemCreateRequest struct {
+	Title    string `json:"title" validate:"required,min=1,max=255"`
+	Position *int   `json:"position"`
+}
+
+// ChecklistItemUpdateRequest - csak a megadott (nem nil) mezők frissülnek
+type ChecklistItemUpdateRequest struct {
+	Title    *string `json:"title" validate:"omitempty,min=1,max=255"`
+	IsDone   *bool   `json:"isDone"`
+	Position *int    `json:"position"`
+}
+
+type ChecklistListResponse struct {
+	Success  bool                `json:"success"`
+	Message  string              `json:"message"`
+	Item     *TaskChecklistItem  `json:"item,omitempty"`
+	Items    []TaskChecklistItem `json:"items,omitempty"`
+	Count    int                 `json:"count,omitempty"`
+	Progress *TaskProgress       `json:"progress,omitempty"`
+}
@@ -0,0 +1,157 @@
+package models
+
+import (
+	"time"
+)
+
+// TaskComment egy task kommentje. A JSON mezők a frontend TaskComment típusát követik (camelCase).
+type TaskComment struct {
+	ID          uint       `json:"id" gorm:"primaryKey"`
+	TaskID      uint       `json:"taskId" gorm:"not null;index"`
+	ProjectID   uint       `json:"projectId" gorm:"not null;index"`
+	UserID      uint       `json:"userId" gorm:"not null"`
+	Content     string     `json:"content" gorm:"type:text;not null"`
+	HTMLContent string     `json:"htmlContent" gorm:"column:html_content;type:text"`
+	IsEdited    bool       `json:"isEdited"`
+	IsPinned    bool       `json:"isPinned"`
+	PinnedAt    *time.Time `json:"pinnedAt"`
+	PinnedBy    *uint      `json:"pinnedBy"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+
+	User      *User                 `json:"-" gorm:"foreignKey:UserID"`
+	Reactions []TaskCommentReaction `json:"-" gorm:"foreignKey:CommentID"`
+}
+
+// TableName override
+func (TaskComment) TableName() string {
+	return "task_comments"
+}
+
+// TaskCommentRevision a komment szerkesztés előtti változata
+type TaskCommentRevision struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	CommentID   uint      `json:"commentId" gorm:"not null;index"`
+	Content     string    `json:"content" gorm:"type:text;not null"`
+	HTMLContent string    `json:"htmlContent" gorm:"column:html_content;type:text"`
+	EditedBy    *uint     `json:"editedBy"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// TableName override
+func (TaskCommentRevision) TableName() string {
+	return "task_comment_revisions"
+}
+
+// TaskCommentReaction - felhasználónként emojinként egy reakció
+type TaskCommentReaction struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	CommentID uint      `json:"commentId" gorm:"not null"`
+	UserID    uint      `json:"userId" gorm:"not null"`
+	Emoji     string    `json:"emoji" gorm:"size:32;not null"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// TableName override
+func (TaskCommentReaction) TableName() string {
+	return "task_comment_reactions"
+}
+
+// LikeEmoji a frontend like/unlike reakciójának megfelelő emoji
+const LikeEmoji = "👍"
+
+type CommentCreateRequest struct {
+	Content     string `json:"content" validate:"required"`
+	HTMLContent string `json:"htmlContent"`
+}
+
+type CommentUpdateRequest struct {
+	Content     string `json:"content" validate:"required"`
+	HTMLContent string `json:"htmlContent"`
+}
+
+// CommentReactRequest - emoji megadásakor váltogat, a frontend like/unlike értéke a LikeEmoji-t adja hozzá/veszi el
+type CommentReactRequest struct {
+	Emoji    string `json:"emoji" validate:"omitempty,max=32"`
+	Reaction string `json:"reaction" validate:"omitempty,oneof=like unlike"`
+}
+
+type CommentUserResponse struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+// CommentReactionSummary egy emoji reakcióinak összesítése
+type CommentReactionSummary struct {
+	Emoji   string `json:"emoji"`
+	Count   int    `json:"count"`
+	UserIDs []uint `json:"userIds"`
+	Reacted bool   `json:"reacted"`
+}
+
+type CommentResponse struct {
+	ID          uint                     `json:"id"`
+	TaskID      uint                     `json:"taskId"`
+	ProjectID   uint                     `json:"projectId"`
+	Content     string                   `json:"content"`
+	HTMLContent string                   `json:"htmlContent,omitempty"`
+	UserID      uint                     `json:"userId"`
+	User        *CommentUserResponse     `json:"user,omitempty"`
+	IsEdited    bool                     `json:"isEdited"`
+	IsPinned    bool                     `json:"isPinned"`
+	PinnedAt    *time.Time               `json:"pinnedAt,omitempty"`
+	Reactions   []CommentReactionSummary `json:"reactions"`
+	CreatedAt   time.Time                `json:"createdAt"`
+	UpdatedAt   time.Time                `json:"updatedAt"`
+}
+
+type CommentListResponse struct {
+	Success   bool                  `json:"success"`
+	Message   string                `json:"message"`
+	Comment   *CommentResponse      `json:"comment,omitempty"`
+	Comments  []CommentResponse     `json:"comments,omitempty"`
+	Revisions []TaskCommentRevision `json:"revisions,omitempty"`
+	Count     int                   `json:"count,omitempty"`
+}
+
+// ToResponse converts a comment (with optional preloaded User and Reactions) to its
+// API shape. Reactions are grouped per emoji in first-reaction order.
+func (cm *TaskComment) ToResponse(currentUserID uint) CommentResponse {
+	response := CommentResponse{
+		ID:          cm.ID,
+		TaskID:      cm.TaskID,
+		ProjectID:   cm.ProjectID,
+		Content:     cm.Content,
+		HTMLContent: cm.HTMLContent,
+		UserID:      cm.UserID,
+		IsEdited:    cm.IsEdited,
+		IsPinned:    cm.IsPinned,
+		PinnedAt:    cm.PinnedAt,
+		Reactions:   []CommentReactionSummary{},
+		CreatedAt:   cm.CreatedAt,
+		UpdatedAt:   cm.UpdatedAt,
+	}
+	if cm.User != nil {
+		response.User = &CommentUserResponse{
+			ID:   cm.User.ID,
+			Name: cm.User.Name,
+		}
+	}
+
+	index := make(map[string]int)
+	for _, reaction := range cm.Reactions {
+		i, ok := index[reaction.Emoji]
+		if !ok {
+			i = len(response.Reactions)
+			index[reaction.Emoji] = i
+			response.Reactions = append(response.Reactions, CommentReactionSummary{Emoji: reaction.Emoji, UserIDs: []uint{}})
+		}
+		summary := &response.Reactions[i]
+		summary.Count++
+		summary.UserIDs = append(summary.UserIDs, reaction.UserID)
+		if reaction.UserID == currentUserID {
+			summary.Reacted = true
+		}
+	}
+	return response
+}
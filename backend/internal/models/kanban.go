@@ -0,0 +1,170 @@
+package models
+
+import (
+	"time"
+)
+
+// KanbanSettings a board beállításai, a kanban_boards táblában tárolva
+type KanbanSettings struct {
+	EnableWipLimits     bool   `json:"enableWipLimits"`
+	EnableTimeTracking  bool   `json:"enableTimeTracking"`
+	EnableComments      bool   `json:"enableComments"`
+	EnablePriorities    bool   `json:"enablePriorities"`
+	EnableTags          bool   `json:"enableTags"`
+	DefaultEstimateUnit string `json:"defaultEstimateUnit" gorm:"size:20"`
+	DefaultBillable     bool   `json:"defaultBillable"`
+	// EnforceWorkflow: csak a workflow_transitions táblában felsorolt oszlopváltások engedélyezettek
+	EnforceWorkflow bool `json:"enforceWorkflow"`
+}
+
+// KanbanBoard projektenként egy board
+type KanbanBoard struct {
+	ID        uint           `json:"id" gorm:"primaryKey"`
+	ProjectID uint           `json:"projectId" gorm:"uniqueIndex;not null"`
+	Settings  KanbanSettings `json:"settings" gorm:"embedded"`
+	CreatedAt time.Time      `json:"createdAt"`
+	UpdatedAt time.Time      `json:"updatedAt"`
+
+	Columns []KanbanColumn `json:"columns,omitempty" gorm:"foreignKey:BoardID"`
+}
+
+// TableName override
+func (KanbanBoard) TableName() string {
+	return "kanban_boards"
+}
+
+type KanbanColumn struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	BoardID   uint      `json:"boardId" gorm:"not null;index"`
+	Title     string    `json:"title" gorm:"size:100;not null"`
+	Color     string    `json:"color" gorm:"size:20"`
+	Position  int       `json:"position" gorm:"not null;default:0"`
+	MaxTasks  *int      `json:"maxTasks"`
+	Category  string    `json:"category" gorm:"size:20;not null;default:todo"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// TableName override
+func (KanbanColumn) TableName() string {
+	return "kanban_columns"
+}
+
+// IsDone checks if tasks in the column count as finished
+func (col *KanbanColumn) IsDone() bool {
+	return col.Category == "done"
+}
+
+// ValidColumnCategories - az oszlopok státusz kategóriái a frontend TaskStatus értékei
+var ValidColumnCategories = ValidTaskStatuses
+
+// ValidEstimateUnits a frontend KanbanSettings.defaultEstimateUnit értékei
+var ValidEstimateUnits = []string{"hours", "days", "points"}
+
+// DefaultKanbanSettings az új board-ok beállításai
+var DefaultKanbanSettings = KanbanSettings{
+	EnableWipLimits:     false,
+	EnableTimeTracking:  true,
+	EnableComments:      true,
+	EnablePriorities:    true,
+	EnableTags:          true,
+	DefaultEstimateUnit: "hours",
+	DefaultBillable:     true,
+	EnforceWorkflow:     false,
+}
+
+// DefaultKanbanColumns az új projektek board-jának oszlopai
+var DefaultKanbanColumns = []KanbanColumn{
+	{Title: "To Do", Color: "#6B7280", Position: 0, Category: "todo"},
+	{Title: "In Progress", Color: "#3B82F6", Position: 1, Category: "in_progress"},
+	{Title: "Review", Color: "#F59E0B", Position: 2, Category: "review"},
+	{Title: "Done", Color: "#10B981", Position: 3, Category: "done"},
+}
+
+// KanbanSettingsUpdateRequest - csak a megadott (nem nil) beállítások frissülnek
+type KanbanSettingsUpdateRequest struct {
+	EnableWipLimits     *bool   `json:"enableWipLimits"`
+	EnableTimeTracking  *bool   `json:"enableTimeTracking"`
+	EnableComments      *bool   `json:"enableComments"`
+	EnablePriorities    *bool   `json:"enablePriorities"`
+	EnableTags          *bool   `json:"enableTags"`
+	DefaultEstimateUnit *string `json:"defaultEstimateUnit" validate:"omitempty,oneof=hours days points"`
+	DefaultBillable     *bool   `json:"defaultBillable"`
+	EnforceWorkflow     *bool   `json:"enforceWorkflow"`
+}
+
+type KanbanBoardUpdateRequest struct {
+	Settings *KanbanSettingsUpdateRequest `json:"settings"`
+}
+
+type KanbanColumnCreateRequest struct {
+	Title    string `json:"title" validate:"required,min=1,max=100"`
+	Color    string `json:"color"`
+	Position *int   `json:"position"`
+	MaxTasks *int   `json:"maxTasks"`
+	Category string `json:"category" validate:"omitempty,oneof=todo in_progress review done"`
+}
+
+type KanbanColumnUpdateRequest struct {
+	Title    *string `json:"title" validate:"omitempty,min=1,max=100"`
+	Color    *string `json:"color"`
+	Position *int    `json:"position"`
+	MaxTasks *int    `json:"maxTasks"`
+	Category *string `json:"category" validate:"omitempty,oneof=todo in_progress review done"`
+}
+
+type KanbanColumnOrder struct {
+	ColumnID uint `json:"columnId"`
+	Position int  `json:"position"`
+}
+
+type KanbanColumnReorderRequest struct {
+	Orders []KanbanColumnOrder `json:"orders" validate:"required"`
+}
+
+type KanbanColumnResponse struct {
+	ID        uint           `json:"id"`
+	Title     string         `json:"title"`
+	Color     string         `json:"color"`
+	Position  int            `json:"position"`
+	MaxTasks  *int           `json:"maxTasks,omitempty"`
+	Category  string         `json:"category"`
+	Tasks     []TaskResponse `json:"tasks"`
+	CreatedAt time.Time      `json:"createdAt"`
+	UpdatedAt time.Time      `json:"updatedAt"`
+}
+
+type KanbanBoardResponse struct {
+	ID        uint                   `json:"id"`
+	ProjectID uint                   `json:"projectId"`
+	Columns   []KanbanColumnResponse `json:"columns"`
+	Settings  KanbanSettings         `json:"settings"`
+	CreatedAt time.Time              `json:"createdAt"`
+	UpdatedAt time.Time              `json:"updatedAt"`
+}
+
+type KanbanResponse struct {
+	Success bool                   `json:"success"`
+	Message string                 `json:"message"`
+	Board   *KanbanBoardResponse   `json:"board,omitempty"`
+	Column  *KanbanColumnResponse  `json:"column,omitempty"`
+	Columns []KanbanColumnResponse `json:"columns,omitempty"`
+}
+
+// ToResponse converts a column to its API shape with the given tasks
+func (col *KanbanColumn) ToResponse(tasks []TaskResponse) KanbanColumnResponse {
+	if tasks == nil {
+		tasks = []TaskResponse{}
+	}
+	return KanbanColumnResponse{
+		ID:        col.ID,
+		Title:     col.Title,
+		Color:     col.Color,
+		Position:  col.Position,
+		MaxTasks:  col.MaxTasks,
+		Category:  col.Category,
+		Tasks:     tasks,
+		CreatedAt: col.CreatedAt,
+		UpdatedAt: col.UpdatedAt,
+	}
+}
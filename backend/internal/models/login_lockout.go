@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// Zárolás hatókörök
+const (
+	LockoutScopeAccount = "account"
+	LockoutScopeIP      = "ip"
+)
+
+// LoginLockout egy zárolási esemény a túl sok sikertelen belépési kísérlet miatt; UserID nil = nem létező fiók
+type LoginLockout struct {
+	ID           uint       `json:"id" gorm:"primaryKey"`
+	UserID       *uint      `json:"user_id"`
+	Email        string     `json:"email" gorm:"size:255;not null"`
+	IPAddress    string     `json:"ip_address" gorm:"column:ip_address;size:64"`
+	Scope        string     `json:"scope" gorm:"size:20;not null"`
+	LockoutCount int        `json:"lockout_count" gorm:"not null"`
+	LockedUntil  time.Time  `json:"locked_until" gorm:"not null"`
+	UnlockedAt   *time.Time `json:"unlocked_at,omitempty"`
+	UnlockedBy   *uint      `json:"unlocked_by,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// TableName override
+func (LoginLockout) TableName() string {
+	return "login_lockouts"
+}
+
+type LoginLockoutListResponse struct {
+	Success     bool           `json:"success"`
+	Message     string         `json:"message"`
+	Locked      bool           `json:"locked"`
+	LockedUntil *time.Time     `json:"locked_until,omitempty"`
+	Lockouts    []LoginLockout `json:"lockouts,omitempty"`
+	Count       int            `json:"count,omitempty"`
+}
@@ -0,0 +1,50 @@
+package models
+
+import "time"
+
+// Értesítés típusok
+const (
+	NotificationTaskDueSoon    = "task_due_soon"
+	NotificationTaskOverdue    = "task_overdue"
+	NotificationTaskEscalation = "task_overdue_escalation"
+)
+
+// Notification egy felhasználónak szóló értesítés; ReadAt nil = olvasatlan
+type Notification struct {
+	ID        uint       `json:"id" gorm:"primaryKey"`
+	UserID    uint       `json:"userId" gorm:"not null;index"`
+	ProjectID *uint      `json:"projectId"`
+	TaskID    *uint      `json:"taskId"`
+	Type      string     `json:"type" gorm:"size:50;not null"`
+	Title     string     `json:"title" gorm:"size:255;not null"`
+	Message   string     `json:"message" gorm:"type:text"`
+	ReadAt    *time.Time `json:"readAt"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+// TableName override
+func (Notification) TableName() string {
+	return "notifications"
+}
+
+// TaskReminder egy elküldött határidő emlékeztető; (task_id, due_date, threshold) egyedi
+type TaskReminder struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	TaskID    uint      `json:"taskId" gorm:"not null"`
+	DueDate   time.Time `json:"dueDate" gorm:"not null"`
+	Threshold string    `json:"threshold" gorm:"size:30;not null"`
+	SentAt    time.Time `json:"sentAt"`
+}
+
+// TableName override
+func (TaskReminder) TableName() string {
+	return "task_reminders"
+}
+
+type NotificationListResponse struct {
+	Success       bool           `json:"success"`
+	Message       string         `json:"message"`
+	Notifications []Notification `json:"notifications,omitempty"`
+	Count         int            `json:"count"`
+	UnreadCount   int64          `json:"unreadCount"`
+}
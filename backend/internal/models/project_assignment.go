@@ -8,7 +8,7 @@ type ProjectAssignment struct {
 	ID         uint      `json:"id" gorm:"primaryKey"`
 	ProjectID  uint      `json:"project_id" gorm:"not null"`
 	UserID     uint      `json:"user_id" gorm:"not null"`
-	Role       string    `json:"role" gorm:"default:member" validate:"oneof=owner manager member viewer"`
+	Role       string    `json:"role" gorm:"default:member" validate:"oneof=owner manager reviewer member viewer"`
 	AssignedAt time.Time `json:"assigned_at" gorm:"default:now()"`
 	AssignedBy uint      `json:"assigned_by"`
 	IsActive   bool      `json:"is_active" gorm:"default:true"`
@@ -18,13 +18,17 @@ type ProjectAssignment struct {
 	AssignedByUser User    `json:"assigned_by_user,omitempty" gorm:"foreignKey:AssignedBy"`
 }
 
+// ValidProjectRoles a projekt szerepkörök; a reviewer a member jogain felül a workflow
+// reviewer-hez kötött átmeneteit is végrehajthatja
+var ValidProjectRoles = []string{"owner", "manager", "reviewer", "member", "viewer"}
+
 type ProjectAssignmentCreateRequest struct {
 	UserID uint   `json:"user_id" validate:"required"`
-	Role   string `json:"role" validate:"omitempty,oneof=owner manager member viewer"`
+	Role   string `json:"role" validate:"omitempty,oneof=owner manager reviewer member viewer"`
 }
 
 type ProjectAssignmentUpdateRequest struct {
-	Role string `json:"role" validate:"required,oneof=owner manager member viewer"`
+	Role string `json:"role" validate:"required,oneof=owner manager reviewer member viewer"`
 }
 
 type ProjectAssignmentResponse struct {
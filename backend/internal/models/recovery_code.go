@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// UserRecoveryCode egy egyszer használható MFA helyreállító kód SHA-256 hash-e; UsedAt nil = felhasználható
+type UserRecoveryCode struct {
+	ID        uint       `json:"id" gorm:"primaryKey"`
+	UserID    uint       `json:"user_id" gorm:"not null;index"`
+	CodeHash  string     `json:"-" gorm:"size:64;not null"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// TableName override
+func (UserRecoveryCode) TableName() string {
+	return "user_recovery_codes"
+}
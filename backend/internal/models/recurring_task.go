@@ -0,0 +1,87 @@
+package models
+
+import "time"
+
+// RecurringTaskTemplate egy ismétlődő task sablonja. A Schedule cron kifejezés a Timezone szerint
+// értendő; a NextRunAt (UTC) elérésekor az ütemező létrehozza a taskot a cél oszlopban.
+type RecurringTaskTemplate struct {
+	ID             uint       `json:"id" gorm:"primaryKey"`
+	ProjectID      uint       `json:"projectId" gorm:"not null;index"`
+	ColumnID       *uint      `json:"columnId"`
+	Title          string     `json:"title" gorm:"size:255;not null"`
+	Description    string     `json:"description" gorm:"type:text"`
+	Priority       string     `json:"priority" gorm:"size:20;default:medium"`
+	AssigneeID     *uint      `json:"assigneeId"`
+	EstimatedHours float64    `json:"estimatedHours" gorm:"default:0"`
+	DueInDays      *int       `json:"dueInDays"`
+	Schedule       string     `json:"schedule" gorm:"size:100;not null"`
+	Timezone       string     `json:"timezone" gorm:"size:64;not null;default:UTC"`
+	IsActive       bool       `json:"isActive" gorm:"not null;default:true"`
+	NextRunAt      time.Time  `json:"nextRunAt" gorm:"not null"`
+	LastRunAt      *time.Time `json:"lastRunAt"`
+	LastTaskID     *uint      `json:"lastTaskId"`
+	CreatedBy      uint       `json:"createdBy" gorm:"not null"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	UpdatedAt      time.Time  `json:"updatedAt"`
+}
+
+// TableName override
+func (RecurringTaskTemplate) TableName() string {
+	return "recurring_task_templates"
+}
+
+// RecurringTaskRun egy sablon egy futása; (template_id, scheduled_for) egyedi
+type RecurringTaskRun struct {
+	ID           uint      `json:"id" gorm:"primaryKey"`
+	TemplateID   uint      `json:"templateId" gorm:"not null"`
+	ScheduledFor time.Time `json:"scheduledFor" gorm:"not null"`
+	TaskID       *uint     `json:"taskId"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// TableName override
+func (RecurringTaskRun) TableName() string {
+	return "recurring_task_runs"
+}
+
+type RecurringTaskCreateRequest struct {
+	Title          string  `json:"title" validate:"required,min=1,max=255"`
+	Description    string  `json:"description"`
+	Priority       string  `json:"priority" validate:"omitempty,oneof=low medium high urgent"`
+	ColumnID       *uint   `json:"columnId"`
+	AssigneeID     *uint   `json:"assigneeId"`
+	EstimatedHours float64 `json:"estimatedHours"`
+	DueInDays      *int    `json:"dueInDays"`
+	Schedule       string  `json:"schedule" validate:"required"`
+	Timezone       string  `json:"timezone"`
+	IsActive       *bool   `json:"isActive"`
+}
+
+// RecurringTaskUpdateRequest - csak a megadott (nem nil) mezők frissülnek.
+// ColumnID/AssigneeID 0 = törlés, DueInDays negatív = nincs határidő.
+type RecurringTaskUpdateRequest struct {
+	Title          *string  `json:"title" validate:"omitempty,min=1,max=255"`
+	Description    *string  `json:"description"`
+	Priority       *string  `json:"priority" validate:"omitempty,oneof=low medium high urgent"`
+	ColumnID       *uint    `json:"columnId"`
+	AssigneeID     *uint    `json:"assigneeId"`
+	EstimatedHours *float64 `json:"estimatedHours"`
+	DueInDays      *int     `json:"dueInDays"`
+	Schedule       *string  `json:"schedule"`
+	Timezone       *string  `json:"timezone"`
+	IsActive       *bool    `json:"isActive"`
+}
+
+// RecurringTaskResponse a sablon a következő néhány futás időpontjával
+type RecurringTaskResponse struct {
+	RecurringTaskTemplate
+	UpcomingRuns []time.Time `json:"upcomingRuns"`
+}
+
+type RecurringTaskListResponse struct {
+	Success   bool                    `json:"success"`
+	Message   string                  `json:"message"`
+	Template  *RecurringTaskResponse  `json:"template,omitempty"`
+	Templates []RecurringTaskResponse `json:"templates,omitempty"`
+	Count     int                     `json:"count,omitempty"`
+}
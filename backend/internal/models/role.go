@@ -10,6 +10,7 @@ type Role struct {
 	DisplayName string    `gorm:"size:100;not null" json:"display_name"`
 	Description string    `gorm:"type:text" json:"description"`
 	IsActive    bool      `gorm:"default:true" json:"is_active"`
+	MFARequired bool      `gorm:"column:mfa_required;not null;default:false" json:"mfa_required"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 
@@ -0,0 +1,55 @@
+package models
+
+import "time"
+
+// Session egy bejelentkezés (refresh token család). Minden refresh új tokent ad ki ugyanabban a
+// sessionben; RevokedAt nem nil = a session már nem frissíthető.
+type Session struct {
+	ID            uint       `json:"id" gorm:"primaryKey"`
+	UserID        uint       `json:"user_id" gorm:"not null;index"`
+	Device        string     `json:"device" gorm:"size:255"`
+	IPAddress     string     `json:"ip_address" gorm:"column:ip_address;size:64"`
+	UserAgent     string     `json:"user_agent" gorm:"type:text"`
+	ExpiresAt     time.Time  `json:"expires_at" gorm:"not null"`
+	LastUsedAt    time.Time  `json:"last_used_at"`
+	RevokedAt     *time.Time `json:"revoked_at,omitempty"`
+	RevokedReason string     `json:"revoked_reason,omitempty" gorm:"size:50"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// TableName override
+func (Session) TableName() string {
+	return "sessions"
+}
+
+// IsActive checks if the session can still be refreshed
+func (s *Session) IsActive(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+// SessionRefreshToken a session egy refresh tokenjének SHA-256 hash-e; UsedAt nil = aktuális token
+type SessionRefreshToken struct {
+	ID        uint   `gorm:"primaryKey"`
+	SessionID uint   `gorm:"not null;index"`
+	TokenHash string `gorm:"size:64;not null;uniqueIndex"`
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+// TableName override
+func (SessionRefreshToken) TableName() string {
+	return "session_refresh_tokens"
+}
+
+// SessionResponse - a Current jelzi a kérést küldő sessiont
+type SessionResponse struct {
+	Session
+	Current bool `json:"current"`
+}
+
+type SessionListResponse struct {
+	Success  bool              `json:"success"`
+	Message  string            `json:"message"`
+	Sessions []SessionResponse `json:"sessions,omitempty"`
+	Count    int               `json:"count,omitempty"`
+}
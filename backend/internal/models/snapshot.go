@@ -0,0 +1,91 @@
+package models
+
+import "time"
+
+// SprintSnapshot egy sprint napi állapota a burndown diagramhoz (a nem archivált taskok alapján)
+type SprintSnapshot struct {
+	ID             uint      `json:"id" gorm:"primaryKey"`
+	SprintID       uint      `json:"sprintId" gorm:"not null;index"`
+	SnapshotDate   time.Time `json:"snapshotDate" gorm:"type:date;not null"`
+	TaskCount      int       `json:"taskCount"`
+	CompletedCount int       `json:"completedCount"`
+	EstimatedHours float64   `json:"estimatedHours"`
+	CompletedHours float64   `json:"completedHours"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+// TableName override
+func (SprintSnapshot) TableName() string {
+	return "sprint_snapshots"
+}
+
+// Stats returns the snapshot's counters
+func (s *SprintSnapshot) Stats() *SprintStats {
+	return &SprintStats{
+		TaskCount:      s.TaskCount,
+		CompletedCount: s.CompletedCount,
+		EstimatedHours: s.EstimatedHours,
+		CompletedHours: s.CompletedHours,
+	}
+}
+
+// ProjectSnapshot egy projekt napi állapota a burnup diagramhoz (a nem archivált taskok alapján)
+type ProjectSnapshot struct {
+	ID             uint      `json:"id" gorm:"primaryKey"`
+	ProjectID      uint      `json:"projectId" gorm:"not null;index"`
+	SnapshotDate   time.Time `json:"snapshotDate" gorm:"type:date;not null"`
+	TaskCount      int       `json:"taskCount"`
+	CompletedCount int       `json:"completedCount"`
+	EstimatedHours float64   `json:"estimatedHours"`
+	CompletedHours float64   `json:"completedHours"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+// TableName override
+func (ProjectSnapshot) TableName() string {
+	return "project_snapshots"
+}
+
+// Stats returns the snapshot's counters
+func (s *ProjectSnapshot) Stats() *SprintStats {
+	return &SprintStats{
+		TaskCount:      s.TaskCount,
+		CompletedCount: s.CompletedCount,
+		EstimatedHours: s.EstimatedHours,
+		CompletedHours: s.CompletedHours,
+	}
+}
+
+// BurndownPoint a sprint egy napja: a hátralévő becslés és taskszám, a scope és az ideális vonal
+type BurndownPoint struct {
+	Date           string  `json:"date"`
+	RemainingHours float64 `json:"remainingHours"`
+	RemainingCount int     `json:"remainingCount"`
+	ScopeHours     float64 `json:"scopeHours"`
+	ScopeCount     int     `json:"scopeCount"`
+	IdealHours     float64 `json:"idealHours"`
+}
+
+// BurnupPoint a projekt egy napja: a teljes scope és az elkészült rész
+type BurnupPoint struct {
+	Date           string  `json:"date"`
+	ScopeHours     float64 `json:"scopeHours"`
+	ScopeCount     int     `json:"scopeCount"`
+	CompletedHours float64 `json:"completedHours"`
+	CompletedCount int     `json:"completedCount"`
+}
+
+type BurndownResponse struct {
+	Success bool            `json:"success"`
+	Message string          `json:"message"`
+	Sprint  *SprintResponse `json:"sprint,omitempty"`
+	Points  []BurndownPoint `json:"points"`
+}
+
+type BurnupResponse struct {
+	Success bool          `json:"success"`
+	Message string        `json:"message"`
+	From    string        `json:"from,omitempty"`
+	To      string        `json:"to,omitempty"`
+	Points  []BurnupPoint `json:"points"`
+}
@@ -0,0 +1,181 @@
+package models
+
+import (
+	"time"
+)
+
+// Sprint állapotok
+const (
+	SprintPlanned = "planned"
+	SprintActive  = "active"
+	SprintClosed  = "closed"
+)
+
+// Sprint scope változás típusok
+const (
+	ScopeAdded   = "added"
+	ScopeRemoved = "removed"
+)
+
+// Sprint egy projekt időkeretes iterációja. A taskok sprint_id-val tartoznak hozzá, a többi a backlog.
+type Sprint struct {
+	ID              uint       `json:"id" gorm:"primaryKey"`
+	ProjectID       uint       `json:"projectId" gorm:"not null;index"`
+	Name            string     `json:"name" gorm:"size:100;not null"`
+	Goal            string     `json:"goal" gorm:"type:text"`
+	StartDate       time.Time  `json:"startDate" gorm:"type:date;not null"`
+	EndDate         time.Time  `json:"endDate" gorm:"type:date;not null"`
+	State           string     `json:"state" gorm:"size:20;not null;default:planned"`
+	StartedAt       *time.Time `json:"startedAt"`
+	ClosedAt        *time.Time `json:"closedAt"`
+	ClosedBy        *uint      `json:"closedBy"`
+	CompletedTasks  int        `json:"completedTasks" gorm:"not null;default:0"`
+	IncompleteTasks int        `json:"incompleteTasks" gorm:"not null;default:0"`
+	CreatedBy       uint       `json:"createdBy" gorm:"not null"`
+	CreatedAt       time.Time  `json:"createdAt"`
+	UpdatedAt       time.Time  `json:"updatedAt"`
+}
+
+// TableName override
+func (Sprint) TableName() string {
+	return "sprints"
+}
+
+// IsClosed checks if the sprint was closed
+func (s *Sprint) IsClosed() bool {
+	return s.State == SprintClosed
+}
+
+// SprintScopeChange egy task hozzáadása/kivétele az aktív sprintből
+type SprintScopeChange struct {
+	ID             uint      `json:"id" gorm:"primaryKey"`
+	SprintID       uint      `json:"sprintId" gorm:"not null;index"`
+	TaskID         uint      `json:"taskId" gorm:"not null"`
+	ChangeType     string    `json:"changeType" gorm:"size:20;not null"`
+	EstimatedHours float64   `json:"estimatedHours"`
+	ChangedBy      *uint     `json:"changedBy"`
+	CreatedAt      time.Time `json:"createdAt"`
+
+	Task *Task `json:"-" gorm:"foreignKey:TaskID"`
+}
+
+// TableName override
+func (SprintScopeChange) TableName() string {
+	return "sprint_scope_changes"
+}
+
+type SprintCreateRequest struct {
+	Name      string `json:"name" validate:"required,min=1,max=100"`
+	Goal      string `json:"goal"`
+	StartDate string `json:"startDate" validate:"required"`
+	EndDate   string `json:"endDate" validate:"required"`
+}
+
+// SprintUpdateRequest - csak a megadott (nem nil) mezők frissülnek
+type SprintUpdateRequest struct {
+	Name      *string `json:"name" validate:"omitempty,min=1,max=100"`
+	Goal      *string `json:"goal"`
+	StartDate *string `json:"startDate"`
+	EndDate   *string `json:"endDate"`
+}
+
+// SprintCloseRequest - a befejezetlen taskok a következő sprintbe (moveTo=next) vagy a backlogba kerülnek.
+// TargetSprintID nélkül a következő a legkorábban kezdődő tervezett sprint.
+type SprintCloseRequest struct {
+	MoveTo         string `json:"moveTo" validate:"omitempty,oneof=backlog next"`
+	TargetSprintID *uint  `json:"targetSprintId"`
+}
+
+// SprintStats a sprint taskjainak összesítése
+type SprintStats struct {
+	TaskCount      int     `json:"taskCount"`
+	CompletedCount int     `json:"completedCount"`
+	EstimatedHours float64 `json:"estimatedHours"`
+	CompletedHours float64 `json:"completedHours"`
+}
+
+type SprintResponse struct {
+	ID              uint         `json:"id"`
+	ProjectID       uint         `json:"projectId"`
+	Name            string       `json:"name"`
+	Goal            string       `json:"goal"`
+	StartDate       string       `json:"startDate"`
+	EndDate         string       `json:"endDate"`
+	State           string       `json:"state"`
+	StartedAt       *time.Time   `json:"startedAt,omitempty"`
+	ClosedAt        *time.Time   `json:"closedAt,omitempty"`
+	ClosedBy        *uint        `json:"closedBy,omitempty"`
+	CompletedTasks  int          `json:"completedTasks"`
+	IncompleteTasks int          `json:"incompleteTasks"`
+	Stats           *SprintStats `json:"stats,omitempty"`
+	CreatedBy       uint         `json:"createdBy"`
+	CreatedAt       time.Time    `json:"createdAt"`
+	UpdatedAt       time.Time    `json:"updatedAt"`
+}
+
+// SprintScopeChangeResponse egy scope változás a task címével
+type SprintScopeChangeResponse struct {
+	ID             uint      `json:"id"`
+	TaskID         uint      `json:"taskId"`
+	TaskTitle      string    `json:"taskTitle"`
+	ChangeType     string    `json:"changeType"`
+	EstimatedHours float64   `json:"estimatedHours"`
+	ChangedBy      *uint     `json:"changedBy,omitempty"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+// SprintCloseReport a lezárás eredménye
+type SprintCloseReport struct {
+	CompletedTaskIDs []uint                      `json:"completedTaskIds"`
+	MovedTaskIDs     []uint                      `json:"movedTaskIds"`
+	MovedTo          string                      `json:"movedTo"`
+	TargetSprintID   *uint                       `json:"targetSprintId,omitempty"`
+	ScopeChanges     []SprintScopeChangeResponse `json:"scopeChanges"`
+}
+
+type SprintListResponse struct {
+	Success      bool                        `json:"success"`
+	Message      string                      `json:"message"`
+	Sprint       *SprintResponse             `json:"sprint,omitempty"`
+	Sprints      []SprintResponse            `json:"sprints,omitempty"`
+	Count        int                         `json:"count,omitempty"`
+	ScopeChanges []SprintScopeChangeResponse `json:"scopeChanges,omitempty"`
+	CloseReport  *SprintCloseReport          `json:"closeReport,omitempty"`
+}
+
+// ToResponse converts a sprint to its API shape
+func (s *Sprint) ToResponse() SprintResponse {
+	return SprintResponse{
+		ID:              s.ID,
+		ProjectID:       s.ProjectID,
+		Name:            s.Name,
+		Goal:            s.Goal,
+		StartDate:       s.StartDate.Format(TimeEntryDateLayout),
+		EndDate:         s.EndDate.Format(TimeEntryDateLayout),
+		State:           s.State,
+		StartedAt:       s.StartedAt,
+		ClosedAt:        s.ClosedAt,
+		ClosedBy:        s.ClosedBy,
+		CompletedTasks:  s.CompletedTasks,
+		IncompleteTasks: s.IncompleteTasks,
+		CreatedBy:       s.CreatedBy,
+		CreatedAt:       s.CreatedAt,
+		UpdatedAt:       s.UpdatedAt,
+	}
+}
+
+// ToResponse converts a scope change (with optional preloaded Task) to its API shape
+func (c *SprintScopeChange) ToResponse() SprintScopeChangeResponse {
+	response := SprintScopeChangeResponse{
+		ID:             c.ID,
+		TaskID:         c.TaskID,
+		ChangeType:     c.ChangeType,
+		EstimatedHours: c.EstimatedHours,
+		ChangedBy:      c.ChangedBy,
+		CreatedAt:      c.CreatedAt,
+	}
+	if c.Task != nil {
+		response.TaskTitle = c.Task.Title
+	}
+	return response
+}
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"time"
+)
+
+// Tag projekt szintű címke. A taskokhoz a task_tags kapcsolótáblán keresztül tartozik.
+type Tag struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	ProjectID uint      `json:"projectId" gorm:"not null;index"`
+	Name      string    `json:"name" gorm:"size:50;not null"`
+	Color     string    `json:"color" gorm:"size:20"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// TableName override
+func (Tag) TableName() string {
+	return "tags"
+}
+
+// DefaultTagColor az új tagek színe, ha nincs megadva
+const DefaultTagColor = "#6B7280"
+
+// MaxTagNameLength a tag név maximális hossza
+const MaxTagNameLength = 50
+
+type TagCreateRequest struct {
+	Name  string `json:"name" validate:"required,min=1,max=50"`
+	Color string `json:"color"`
+}
+
+// TagUpdateRequest - csak a megadott (nem nil) mezők frissülnek
+type TagUpdateRequest struct {
+	Name  *string `json:"name" validate:"omitempty,min=1,max=50"`
+	Color *string `json:"color"`
+}
+
+// TagMergeRequest - a forrás tag taskjai a cél taghez kerülnek, a forrás tag törlődik
+type TagMergeRequest struct {
+	TargetTagID uint `json:"targetTagId" validate:"required"`
+}
+
+// TaskTagResponse a frontend TaskTag típusa
+type TaskTagResponse struct {
+	ID    uint   `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+// TagUsage egy tag és a hozzá tartozó taskok száma
+type TagUsage struct {
+	Tag
+	TaskCount int `json:"taskCount"`
+}
+
+type TagListResponse struct {
+	Success bool       `json:"success"`
+	Message string     `json:"message"`
+	Tag     *TagUsage  `json:"tag,omitempty"`
+	Tags    []TagUsage `json:"tags,omitempty"`
+	Count   int        `json:"count,omitempty"`
+}
+
+// ToTaskResponse converts a tag to the shape embedded in tasks
+func (t *Tag) ToTaskResponse() TaskTagResponse {
+	return TaskTagResponse{
+		ID:    t.ID,
+		Name:  t.Name,
+		Color: t.Color,
+	}
+}
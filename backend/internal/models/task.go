@@ -0,0 +1,235 @@
+package models
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// Task a kanban board kártyája. A JSON mezők a frontend Task típusát követik (camelCase).
+type Task struct {
+	ID               uint       `json:"id" gorm:"primaryKey"`
+	ProjectID        uint       `json:"projectId" gorm:"not null;index"`
+	ColumnID         uint       `json:"columnId" gorm:"not null;index"`
+	ParentID         *uint      `json:"parentId" gorm:"index"`
+	SprintID         *uint      `json:"sprintId" gorm:"index"`
+	Title            string     `json:"title" gorm:"size:255;not null"`
+	Description      string     `json:"description" gorm:"type:text"`
+	HTMLDescription  string     `json:"htmlDescription" gorm:"column:html_description;type:text"`
+	Priority         string     `json:"priority" gorm:"size:20;default:medium"`
+	Status           string     `json:"status" gorm:"size:50;default:todo"`
+	AssigneeID       *uint      `json:"assigneeId" gorm:"index"`
+	EstimatedHours   float64    `json:"estimatedHours" gorm:"default:0"`
+	LoggedHours      float64    `json:"loggedHours" gorm:"default:0"`
+	Position         int        `json:"position" gorm:"not null;default:0"`
+	DueDate          *time.Time `json:"dueDate"`
+	ArchivedAt       *time.Time `json:"archivedAt"`
+	ArchivedBy       *uint      `json:"archivedBy"`
+	ArchivedPosition *int       `json:"-"`
+	CreatedBy        uint       `json:"createdBy" gorm:"not null"`
+	UpdatedBy        uint       `json:"updatedBy" gorm:"not null"`
+	CreatedAt        time.Time  `json:"createdAt"`
+	UpdatedAt        time.Time  `json:"updatedAt"`
+
+	Project  Project       `json:"-" gorm:"foreignKey:ProjectID"`
+	Column   *KanbanColumn `json:"-" gorm:"foreignKey:ColumnID"`
+	Assignee *User         `json:"-" gorm:"foreignKey:AssigneeID"`
+	Tags     []Tag         `json:"-" gorm:"many2many:task_tags"`
+}
+
+// TableName override
+func (Task) TableName() string {
+	return "tasks"
+}
+
+// IsArchived checks if the task was archived
+func (t *Task) IsArchived() bool {
+	return t.ArchivedAt != nil
+}
+
+// IsResolved checks if the task no longer blocks others: it is archived or sits in a done column.
+// Requires the Column association to be loaded.
+func (t *Task) IsResolved() bool {
+	return t.IsArchived() || (t.Column != nil && t.Column.IsDone())
+}
+
+// ToLinkedResponse converts a task (with preloaded Column) to its short linked-task shape
+func (t *Task) ToLinkedResponse() LinkedTaskResponse {
+	return LinkedTaskResponse{
+		ID:        t.ID,
+		ProjectID: t.ProjectID,
+		Title:     t.Title,
+		Status:    t.Status,
+		ColumnID:  t.ColumnID,
+		Resolved:  t.IsResolved(),
+	}
+}
+
+// ValidTaskPriorities a frontend TaskPriority enum értékei
+var ValidTaskPriorities = []string{"low", "medium", "high", "urgent"}
+
+// ValidTaskStatuses a frontend TaskStatus enum értékei
+var ValidTaskStatuses = []string{"todo", "in_progress", "review", "done"}
+
+type TaskCreateRequest struct {
+	Title           string     `json:"title" validate:"required,min=1,max=255"`
+	Description     string     `json:"description"`
+	HTMLDescription string     `json:"htmlDescription"`
+	Priority        string     `json:"priority" validate:"omitempty,oneof=low medium high urgent"`
+	ColumnID        uint       `json:"columnId" validate:"required"`
+	AssigneeID      *uint      `json:"assigneeId"`
+	EstimatedHours  float64    `json:"estimatedHours"`
+	Tags            []string   `json:"tags"`
+	DueDate         *time.Time `json:"dueDate"`
+	ParentID        *uint      `json:"parentId"`
+	SprintID        *uint      `json:"sprintId"`
+}
+
+// TaskUpdateRequest - csak a megadott (nem nil) mezők frissülnek
+type TaskUpdateRequest struct {
+	Title           *string    `json:"title" validate:"omitempty,min=1,max=255"`
+	Description     *string    `json:"description"`
+	HTMLDescription *string    `json:"htmlDescription"`
+	Priority        *string    `json:"priority" validate:"omitempty,oneof=low medium high urgent"`
+	AssigneeID      *uint      `json:"assigneeId"`
+	EstimatedHours  *float64   `json:"estimatedHours"`
+	Tags            []string   `json:"tags"`
+	DueDate         *time.Time `json:"dueDate"`
+	// ParentID 0 = leválasztás a szülő taskról
+	ParentID *uint `json:"parentId"`
+	// SprintID 0 = vissza a backlogba
+	SprintID *uint `json:"sprintId"`
+}
+
+// TaskMoveRequest a frontend MoveTaskData típusa.
+// Force: a szülő task nyitott subtaskok mellett is a Done oszlopba kerülhet.
+type TaskMoveRequest struct {
+	ColumnID uint `json:"columnId" validate:"required"`
+	Position int  `json:"position" validate:"min=0"`
+	Force    bool `json:"force"`
+}
+
+// TaskBulkUpdateData - a TaskUpdateRequest mezői, kiegészítve az oszlop váltással
+type TaskBulkUpdateData struct {
+	TaskUpdateRequest
+	ColumnID *uint `json:"columnId"`
+	Force    bool  `json:"force"`
+}
+
+type TaskBulkUpdateItem struct {
+	TaskID uint               `json:"taskId" validate:"required"`
+	Data   TaskBulkUpdateData `json:"data"`
+}
+
+type TaskBulkUpdateRequest struct {
+	Updates []TaskBulkUpdateItem `json:"updates" validate:"required,min=1"`
+}
+
+// TaskBulkItemError egy elutasított tétel a bulk kérésben
+type TaskBulkItemError struct {
+	Index   int    `json:"index"`
+	TaskID  uint   `json:"taskId"`
+	Message string `json:"message"`
+}
+
+type TaskBulkUpdateResponse struct {
+	Success bool                `json:"success"`
+	Message string              `json:"message"`
+	Tasks   []TaskResponse      `json:"tasks,omitempty"`
+	Count   int                 `json:"count,omitempty"`
+	Errors  []TaskBulkItemError `json:"errors,omitempty"`
+}
+
+type TaskAssigneeResponse struct {
+	ID    uint   `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// TaskProgress a task subtaskjaiból és checklistájából összesített haladás.
+// A Total* mezők a task saját és a (nem archivált) subtaskjai óraszámait adják össze.
+type TaskProgress struct {
+	SubtaskCount        int     `json:"subtaskCount"`
+	CompletedSubtasks   int     `json:"completedSubtasks"`
+	ChecklistCount      int     `json:"checklistCount"`
+	CompletedChecklist  int     `json:"completedChecklist"`
+	Percent             float64 `json:"percent"`
+	TotalEstimatedHours float64 `json:"totalEstimatedHours"`
+	TotalLoggedHours    float64 `json:"totalLoggedHours"`
+}
+
+type TaskResponse struct {
+	ID              uint                  `json:"id"`
+	ProjectID       uint                  `json:"projectId"`
+	ColumnID        uint                  `json:"columnId"`
+	ParentID        *uint                 `json:"parentId,omitempty"`
+	SprintID        *uint                 `json:"sprintId,omitempty"`
+	Title           string                `json:"title"`
+	Description     string                `json:"description"`
+	HTMLDescription string                `json:"htmlDescription,omitempty"`
+	Priority        string                `json:"priority"`
+	Status          string                `json:"status"`
+	AssigneeID      *uint                 `json:"assigneeId,omitempty"`
+	Assignee        *TaskAssigneeResponse `json:"assignee,omitempty"`
+	EstimatedHours  float64               `json:"estimatedHours"`
+	LoggedHours     float64               `json:"loggedHours"`
+	Tags            []TaskTagResponse     `json:"tags"`
+	Progress        *TaskProgress         `json:"progress,omitempty"`
+	Position        int                   `json:"position"`
+	DueDate         *time.Time            `json:"dueDate,omitempty"`
+	ArchivedAt      *time.Time            `json:"archivedAt,omitempty"`
+	CreatedBy       uint                  `json:"createdBy"`
+	UpdatedBy       uint                  `json:"updatedBy"`
+	CreatedAt       time.Time             `json:"createdAt"`
+	UpdatedAt       time.Time             `json:"updatedAt"`
+}
+
+type TaskListResponse struct {
+	Success bool            `json:"success"`
+	Message string          `json:"message"`
+	Task    *TaskResponse   `json:"task,omitempty"`
+	Tasks   []TaskResponse  `json:"tasks,omitempty"`
+	Count   int             `json:"count,omitempty"`
+	Warning *BlockerWarning `json:"warning,omitempty"`
+}
+
+// ToResponse converts a task (with optional preloaded Assignee and Tags) to its API shape
+func (t *Task) ToResponse() TaskResponse {
+	response := TaskResponse{
+		ID:              t.ID,
+		ProjectID:       t.ProjectID,
+		ColumnID:        t.ColumnID,
+		ParentID:        t.ParentID,
+		SprintID:        t.SprintID,
+		Title:           t.Title,
+		Description:     t.Description,
+		HTMLDescription: t.HTMLDescription,
+		Priority:        t.Priority,
+		Status:          t.Status,
+		AssigneeID:      t.AssigneeID,
+		EstimatedHours:  t.EstimatedHours,
+		LoggedHours:     t.LoggedHours,
+		Position:        t.Position,
+		DueDate:         t.DueDate,
+		ArchivedAt:      t.ArchivedAt,
+		CreatedBy:       t.CreatedBy,
+		UpdatedBy:       t.UpdatedBy,
+		CreatedAt:       t.CreatedAt,
+		UpdatedAt:       t.UpdatedAt,
+	}
+	response.Tags = make([]TaskTagResponse, 0, len(t.Tags))
+	for i := range t.Tags {
+		response.Tags = append(response.Tags, t.Tags[i].ToTaskResponse())
+	}
+	slices.SortFunc(response.Tags, func(a, b TaskTagResponse) int {
+		return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	})
+	if t.Assignee != nil {
+		response.Assignee = &TaskAssigneeResponse{
+			ID:    t.Assignee.ID,
+			Name:  t.Assignee.Name,
+			Email: t.Assignee.Email,
+		}
+	}
+	return response
+}
@@ -0,0 +1,96 @@
+package models
+
+import (
+	"time"
+)
+
+// Task kapcsolat típusok. A "blocks" irányított: a source task blokkolja a target taskot.
+const (
+	TaskLinkBlocks    = "blocks"
+	TaskLinkRelatesTo = "relates_to"
+)
+
+// TaskLink két task kapcsolata, akár különböző projektek között
+type TaskLink struct {
+	ID           uint      `json:"id" gorm:"primaryKey"`
+	SourceTaskID uint      `json:"sourceTaskId" gorm:"not null;index"`
+	TargetTaskID uint      `json:"targetTaskId" gorm:"not null;index"`
+	LinkType     string    `json:"linkType" gorm:"size:20;not null"`
+	CreatedBy    *uint     `json:"createdBy"`
+	CreatedAt    time.Time `json:"createdAt"`
+
+	SourceTask *Task `json:"-" gorm:"foreignKey:SourceTaskID"`
+	TargetTask *Task `json:"-" gorm:"foreignKey:TargetTaskID"`
+}
+
+// TableName override
+func (TaskLink) TableName() string {
+	return "task_links"
+}
+
+// ValidTaskLinkTypes a létrehozáskor megadható típusok, a viewed task szemszögéből
+var ValidTaskLinkTypes = []string{"blocks", "blocked_by", "relates_to"}
+
+type TaskLinkCreateRequest struct {
+	TargetTaskID uint   `json:"targetTaskId" validate:"required"`
+	Type         string `json:"type" validate:"required,oneof=blocks blocked_by relates_to"`
+}
+
+// LinkedTaskResponse a kapcsolt task rövid adatai
+type LinkedTaskResponse struct {
+	ID        uint   `json:"id"`
+	ProjectID uint   `json:"projectId"`
+	Title     string `json:"title"`
+	Status    string `json:"status"`
+	ColumnID  uint   `json:"columnId"`
+	Resolved  bool   `json:"resolved"`
+}
+
+// TaskLinkResponse egy kapcsolat az adott task szemszögéből (blocks, blocked_by, relates_to)
+type TaskLinkResponse struct {
+	ID        uint               `json:"id"`
+	Type      string             `json:"type"`
+	Task      LinkedTaskResponse `json:"task"`
+	CreatedBy *uint              `json:"createdBy,omitempty"`
+	CreatedAt time.Time          `json:"createdAt"`
+}
+
+type TaskLinkListResponse struct {
+	Success bool               `json:"success"`
+	Message string             `json:"message"`
+	Link    *TaskLinkResponse  `json:"link,omitempty"`
+	Links   []TaskLinkResponse `json:"links,omitempty"`
+	Count   int                `json:"count,omitempty"`
+}
+
+// DependencyGraphNode a függőségi gráf egy taskja
+type DependencyGraphNode struct {
+	ID        uint   `json:"id"`
+	ProjectID uint   `json:"projectId"`
+	Title     string `json:"title"`
+	Status    string `json:"status"`
+	ColumnID  uint   `json:"columnId"`
+	Resolved  bool   `json:"resolved"`
+	External  bool   `json:"external"`
+}
+
+// DependencyGraphEdge egy kapcsolat a gráfban (blocks: source -> target)
+type DependencyGraphEdge struct {
+	ID     uint   `json:"id"`
+	Source uint   `json:"source"`
+	Target uint   `json:"target"`
+	Type   string `json:"type"`
+}
+
+type DependencyGraphResponse struct {
+	Success bool                  `json:"success"`
+	Message string                `json:"message"`
+	Nodes   []DependencyGraphNode `json:"nodes"`
+	Edges   []DependencyGraphEdge `json:"edges"`
+}
+
+// BlockerWarning a mozgatás válaszában: a task még megoldatlan blokkolói
+type BlockerWarning struct {
+	Message  string               `json:"message"`
+	Blockers []LinkedTaskResponse `json:"blockers"`
+}
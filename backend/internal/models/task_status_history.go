@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// TaskStatusHistory egy task oszlopváltása. FromColumnID nil: a task létrehozása.
+// A kategóriák a váltás pillanatának állapotát őrzik.
+type TaskStatusHistory struct {
+	ID           uint      `json:"id" gorm:"primaryKey"`
+	TaskID       uint      `json:"taskId" gorm:"not null;index"`
+	ProjectID    uint      `json:"projectId" gorm:"not null;index"`
+	FromColumnID *uint     `json:"fromColumnId"`
+	ToColumnID   *uint     `json:"toColumnId"`
+	FromCategory *string   `json:"fromCategory"`
+	ToCategory   string    `json:"toCategory" gorm:"size:20;not null"`
+	ChangedBy    *uint     `json:"changedBy"`
+	ChangedAt    time.Time `json:"changedAt" gorm:"not null"`
+}
+
+// TableName override
+func (TaskStatusHistory) TableName() string {
+	return "task_status_history"
+}
+
+type TaskStatusHistoryResponse struct {
+	Success bool                `json:"success"`
+	Message string              `json:"message"`
+	History []TaskStatusHistory `json:"history"`
+	Count   int                 `json:"count"`
+}
@@ -0,0 +1,122 @@
+package models
+
+import (
+	"time"
+)
+
+// TimeEntryDateLayout a bejegyzés dátumának formátuma (YYYY-MM-DD)
+const TimeEntryDateLayout = "2006-01-02"
+
+type TimeEntry struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	TaskID      uint      `json:"taskId" gorm:"not null;index"`
+	ProjectID   uint      `json:"projectId" gorm:"not null;index"`
+	UserID      uint      `json:"userId" gorm:"not null;index"`
+	Hours       float64   `json:"hours" gorm:"not null"`
+	Description string    `json:"description" gorm:"type:text"`
+	Date        time.Time `json:"date" gorm:"type:date;not null"`
+	Billable    bool      `json:"billable" gorm:"not null"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+
+	Task Task  `json:"-" gorm:"foreignKey:TaskID"`
+	User *User `json:"-" gorm:"foreignKey:UserID"`
+}
+
+// TableName override
+func (TimeEntry) TableName() string {
+	return "time_entries"
+}
+
+type TimeEntryCreateRequest struct {
+	Hours       float64 `json:"hours" validate:"required,gt=0,lte=24"`
+	Description string  `json:"description"`
+	Date        string  `json:"date"`
+	// Billable - ha nincs megadva, a projekt board defaultBillable beállítása érvényes
+	Billable *bool `json:"billable"`
+}
+
+// TimeEntryUpdateRequest - csak a megadott (nem nil) mezők frissülnek
+type TimeEntryUpdateRequest struct {
+	Hours       *float64 `json:"hours" validate:"omitempty,gt=0,lte=24"`
+	Description *string  `json:"description"`
+	Date        *string  `json:"date"`
+	Billable    *bool    `json:"billable"`
+}
+
+type TimeEntryUserResponse struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+type TimeEntryResponse struct {
+	ID          uint                   `json:"id"`
+	TaskID      uint                   `json:"taskId"`
+	ProjectID   uint                   `json:"projectId"`
+	Hours       float64                `json:"hours"`
+	Description string                 `json:"description"`
+	Date        string                 `json:"date"`
+	Billable    bool                   `json:"billable"`
+	UserID      uint                   `json:"userId"`
+	User        *TimeEntryUserResponse `json:"user,omitempty"`
+	CreatedAt   time.Time              `json:"createdAt"`
+	UpdatedAt   time.Time              `json:"updatedAt"`
+}
+
+type TimeEntryListResponse struct {
+	Success     bool                `json:"success"`
+	Message     string              `json:"message"`
+	TimeEntry   *TimeEntryResponse  `json:"timeEntry,omitempty"`
+	TimeEntries []TimeEntryResponse `json:"timeEntries,omitempty"`
+	Count       int                 `json:"count,omitempty"`
+	TotalHours  float64             `json:"totalHours,omitempty"`
+}
+
+// TimeSummaryTask egy task összesített órái
+type TimeSummaryTask struct {
+	TaskID        uint    `json:"taskId"`
+	TaskTitle     string  `json:"taskTitle"`
+	Hours         float64 `json:"hours"`
+	BillableHours float64 `json:"billableHours"`
+}
+
+// TimeSummaryUser egy felhasználó összesített órái
+type TimeSummaryUser struct {
+	UserID        uint    `json:"userId"`
+	UserName      string  `json:"userName"`
+	Hours         float64 `json:"hours"`
+	BillableHours float64 `json:"billableHours"`
+}
+
+// TimeSummaryResponse a frontend getTimeSummary válasza
+type TimeSummaryResponse struct {
+	Success       bool              `json:"success"`
+	Message       string            `json:"message"`
+	TotalHours    float64           `json:"totalHours"`
+	BillableHours float64           `json:"billableHours"`
+	TaskBreakdown []TimeSummaryTask `json:"taskBreakdown"`
+	UserBreakdown []TimeSummaryUser `json:"userBreakdown"`
+}
+
+// ToResponse converts a time entry (with optional preloaded User) to its API shape
+func (e *TimeEntry) ToResponse() TimeEntryResponse {
+	response := TimeEntryResponse{
+		ID:          e.ID,
+		TaskID:      e.TaskID,
+		ProjectID:   e.ProjectID,
+		Hours:       e.Hours,
+		Description: e.Description,
+		Date:        e.Date.Format(TimeEntryDateLayout),
+		Billable:    e.Billable,
+		UserID:      e.UserID,
+		CreatedAt:   e.CreatedAt,
+		UpdatedAt:   e.UpdatedAt,
+	}
+	if e.User != nil {
+		response.User = &TimeEntryUserResponse{
+			ID:   e.User.ID,
+			Name: e.User.Name,
+		}
+	}
+	return response
+}
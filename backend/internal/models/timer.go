@@ -0,0 +1,75 @@
+package models
+
+import (
+	"math"
+	"time"
+)
+
+// Timer egy felhasználó futó időmérője. Leállításkor TimeEntry lesz belőle.
+type Timer struct {
+	ID          uint       `json:"id" gorm:"primaryKey"`
+	UserID      uint       `json:"userId" gorm:"not null;uniqueIndex"`
+	TaskID      uint       `json:"taskId" gorm:"not null;index"`
+	ProjectID   uint       `json:"projectId" gorm:"not null"`
+	Description string     `json:"description" gorm:"type:text"`
+	StartedAt   time.Time  `json:"startedAt" gorm:"not null"`
+	FlaggedAt   *time.Time `json:"flaggedAt"`
+	CreatedAt   time.Time  `json:"createdAt"`
+
+	Task Task `json:"-" gorm:"foreignKey:TaskID"`
+}
+
+// TableName override
+func (Timer) TableName() string {
+	return "timers"
+}
+
+// ElapsedHours returns the running time in hours, rounded to two decimals
+func (t *Timer) ElapsedHours(now time.Time) float64 {
+	return math.Round(now.Sub(t.StartedAt).Hours()*100) / 100
+}
+
+// TimerStopRequest - a hours mező felülírja a mért időt, a discard eldobja a timert
+type TimerStopRequest struct {
+	Description *string  `json:"description"`
+	Hours       *float64 `json:"hours" validate:"omitempty,gt=0,lte=24"`
+	Discard     bool     `json:"discard"`
+}
+
+type TimerResponse struct {
+	ID           uint       `json:"id"`
+	TaskID       uint       `json:"taskId"`
+	TaskTitle    string     `json:"taskTitle,omitempty"`
+	ProjectID    uint       `json:"projectId"`
+	UserID       uint       `json:"userId"`
+	Description  string     `json:"description"`
+	StartedAt    time.Time  `json:"startedAt"`
+	ElapsedHours float64    `json:"elapsedHours"`
+	Exceeded     bool       `json:"exceeded"`
+	FlaggedAt    *time.Time `json:"flaggedAt,omitempty"`
+}
+
+type TimerActionResponse struct {
+	Success bool           `json:"success"`
+	Message string         `json:"message"`
+	Timer   *TimerResponse `json:"timer"`
+	// TimeEntry a leállított (vagy új timer indításakor automatikusan leállított) timer bejegyzése
+	TimeEntry *TimeEntryResponse `json:"timeEntry,omitempty"`
+}
+
+// ToResponse converts a timer to its API shape. A timer is exceeded when it was
+// flagged or has been running longer than maxDuration.
+func (t *Timer) ToResponse(now time.Time, maxDuration time.Duration) TimerResponse {
+	return TimerResponse{
+		ID:           t.ID,
+		TaskID:       t.TaskID,
+		TaskTitle:    t.Task.Title,
+		ProjectID:    t.ProjectID,
+		UserID:       t.UserID,
+		Description:  t.Description,
+		StartedAt:    t.StartedAt,
+		ElapsedHours: t.ElapsedHours(now),
+		Exceeded:     t.FlaggedAt != nil || now.Sub(t.StartedAt) > maxDuration,
+		FlaggedAt:    t.FlaggedAt,
+	}
+}
@@ -6,14 +6,20 @@ import (
 )
 
 type User struct {
-	ID        uint      `gorm:"primaryKey" json:"id"`
-	Name      string    `gorm:"size:255;not null" json:"name"`
-	Email     string    `gorm:"size:255;uniqueIndex;not null" json:"email"`
-	Password  string    `gorm:"size:255;not null" json:"-"`
-	Position  string    `gorm:"size:100" json:"position"`
-	RoleID    uint      `gorm:"not null;index" json:"role_id"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID              uint       `gorm:"primaryKey" json:"id"`
+	Name            string     `gorm:"size:255;not null" json:"name"`
+	Email           string     `gorm:"size:255;uniqueIndex;not null" json:"email"`
+	Password        string     `gorm:"size:255;not null" json:"-"`
+	Position        string     `gorm:"size:100" json:"position"`
+	RoleID          uint       `gorm:"not null;index" json:"role_id"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`           // nil = meg nem erősített email cím
+	TokenVersion    int        `gorm:"not null;default:0" json:"-"` // Növelése a kiadott access tokeneket érvényteleníti
+	MFAEnabled      bool       `gorm:"column:mfa_enabled;not null;default:false" json:"mfa_enabled"`
+	MFASecret       string     `gorm:"column:mfa_secret;type:text" json:"-"` // Titkosított TOTP titok
+	MFAEnabledAt    *time.Time `gorm:"column:mfa_enabled_at" json:"-"`
+	MFALastStep     *int64     `gorm:"column:mfa_last_step" json:"-"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
 
 	// Relationships
 	Role Role `gorm:"foreignKey:RoleID" json:"role,omitempty"`
@@ -40,6 +46,25 @@ func (u *User) CheckPassword(password string) bool {
 	return err == nil
 }
 
+// EmailVerified checks if the user confirmed the email address
+func (u *User) EmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+// RestrictPermissions keeps only the allowed permissions of the loaded role (in memory only)
+func (u *User) RestrictPermissions(allowed []string) {
+	permissions := make([]Permission, 0, len(u.Role.Permissions))
+	for _, permission := range u.Role.Permissions {
+		for _, name := range allowed {
+			if permission.Name == name {
+				permissions = append(permissions, permission)
+				break
+			}
+		}
+	}
+	u.Role.Permissions = permissions
+}
+
 // HasPermission checks if user has specific permission
 func (u *User) HasPermission(permissionName string) bool {
 	return u.Role.HasPermission(permissionName)
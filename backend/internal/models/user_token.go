@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// Felhasználói token célok
+const (
+	UserTokenPasswordReset     = "password_reset"
+	UserTokenEmailVerification = "email_verification"
+)
+
+// UserToken egy emailben kiküldött, egyszer használható token SHA-256 hash-e; UsedAt nil = még felhasználható
+type UserToken struct {
+	ID        uint   `gorm:"primaryKey"`
+	UserID    uint   `gorm:"not null;index"`
+	Purpose   string `gorm:"size:30;not null"`
+	TokenHash string `gorm:"size:64;not null;uniqueIndex"`
+	Email     string `gorm:"size:255;not null"`
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+// TableName override
+func (UserToken) TableName() string {
+	return "user_tokens"
+}
@@ -0,0 +1,58 @@
+package models
+
+import "time"
+
+// WorkflowTransition egy engedélyezett oszlopváltás a board-on.
+// RequiredRole: a mozgatáshoz szükséges minimális projekt szerepkör (nil: bármely member).
+type WorkflowTransition struct {
+	ID           uint      `json:"id" gorm:"primaryKey"`
+	BoardID      uint      `json:"boardId" gorm:"not null;index"`
+	FromColumnID uint      `json:"fromColumnId" gorm:"not null"`
+	ToColumnID   uint      `json:"toColumnId" gorm:"not null"`
+	RequiredRole *string   `json:"requiredRole,omitempty" gorm:"size:20"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// TableName override
+func (WorkflowTransition) TableName() string {
+	return "workflow_transitions"
+}
+
+// ValidTransitionRoles az átmenetekhez köthető projekt szerepkörök
+var ValidTransitionRoles = []string{"member", "reviewer", "manager", "owner"}
+
+type WorkflowTransitionInput struct {
+	FromColumnID uint   `json:"fromColumnId" validate:"required"`
+	ToColumnID   uint   `json:"toColumnId" validate:"required"`
+	RequiredRole string `json:"requiredRole" validate:"omitempty,oneof=member reviewer manager owner"`
+}
+
+// WorkflowUpdateRequest - Transitions megadása esetén a teljes átmenet lista lecserélődik
+type WorkflowUpdateRequest struct {
+	EnforceWorkflow *bool                     `json:"enforceWorkflow"`
+	Transitions     []WorkflowTransitionInput `json:"transitions"`
+}
+
+// WorkflowColumn egy oszlop a workflow-ban a státusz kategóriájával
+type WorkflowColumn struct {
+	ID       uint   `json:"id"`
+	Title    string `json:"title"`
+	Category string `json:"category"`
+}
+
+type WorkflowResponse struct {
+	Success         bool                 `json:"success"`
+	Message         string               `json:"message"`
+	EnforceWorkflow bool                 `json:"enforceWorkflow"`
+	Columns         []WorkflowColumn     `json:"columns"`
+	Transitions     []WorkflowTransition `json:"transitions"`
+}
+
+// ToWorkflowColumn converts a column to its short workflow shape
+func (col *KanbanColumn) ToWorkflowColumn() WorkflowColumn {
+	return WorkflowColumn{
+		ID:       col.ID,
+		Title:    col.Title,
+		Category: col.Category,
+	}
+}
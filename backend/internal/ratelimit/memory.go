@@ -0,0 +1,66 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryLimiter keeps the state in the process. It is not shared between server instances,
+// so with several replicas an attacker gets the attempts of every instance.
+type MemoryLimiter struct {
+	mu     sync.Mutex
+	states map[string]State
+}
+
+func NewMemoryLimiter() *MemoryLimiter {
+	return &MemoryLimiter{
+		states: make(map[string]State),
+	}
+}
+
+func (l *MemoryLimiter) Status(ctx context.Context, key string) (State, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.states[key], nil
+}
+
+func (l *MemoryLimiter) Fail(ctx context.Context, key string, policy Policy, now time.Time) (State, bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	state, locked := policy.RecordFailure(l.states[key], now)
+	l.states[key] = state
+	return state, locked, nil
+}
+
+func (l *MemoryLimiter) Refund(ctx context.Context, key string, policy Policy, reserved State, locked bool) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if state, ok := l.states[key]; ok {
+		l.states[key] = policy.Refund(state, reserved, locked)
+	}
+	return nil
+}
+
+func (l *MemoryLimiter) Reset(ctx context.Context, key string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.states, key)
+	return nil
+}
+
+func (l *MemoryLimiter) Prune(ctx context.Context, before time.Time) (int64, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var pruned int64
+	for key, state := range l.states {
+		if state.LastFailureAt.Before(before) && !state.LockedUntil.After(before) {
+			delete(l.states, key)
+			pruned++
+		}
+	}
+	return pruned, nil
+}
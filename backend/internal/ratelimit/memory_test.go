@@ -0,0 +1,87 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMemoryLimiterConcurrentFailures(t *testing.T) {
+	l := NewMemoryLimiter()
+	ctx := context.Background()
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	policy := Policy{MaxAttempts: 5, Window: time.Hour, BaseLockout: time.Minute, MaxLockout: time.Hour}
+
+	// Párhuzamos hibás próbálkozásokból pontosan egy zárol
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	lockedCount := 0
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, locked, err := l.Fail(ctx, "user:1", policy, now)
+			if err != nil {
+				t.Error(err)
+			}
+			if locked {
+				mu.Lock()
+				lockedCount++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if lockedCount != 1 {
+		t.Errorf("%d attempts locked the key, want exactly 1", lockedCount)
+	}
+	state, _ := l.Status(ctx, "user:1")
+	if !state.IsLocked(now) || state.Lockouts != 1 {
+		t.Errorf("state after concurrent failures = %+v", state)
+	}
+}
+
+func TestMemoryLimiterResetAndPrune(t *testing.T) {
+	l := NewMemoryLimiter()
+	ctx := context.Background()
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	policy := Policy{MaxAttempts: 1, Window: time.Hour, BaseLockout: time.Hour, MaxLockout: time.Hour}
+
+	l.Fail(ctx, "locked", policy, now)
+	l.Fail(ctx, "old", Policy{MaxAttempts: 10, Window: time.Hour}, now)
+	l.Fail(ctx, "recent", Policy{MaxAttempts: 10, Window: time.Hour}, now.Add(time.Minute))
+
+	pruned, err := l.Prune(ctx, now.Add(30*time.Second))
+	if err != nil || pruned != 1 {
+		t.Errorf("Prune = %d, %v; want only the old unlocked key", pruned, err)
+	}
+	if s, _ := l.Status(ctx, "old"); s != (State{}) {
+		t.Errorf("old key was kept: %+v", s)
+	}
+	if s, _ := l.Status(ctx, "locked"); !s.IsLocked(now) {
+		t.Error("locked key was pruned")
+	}
+
+	if err := l.Reset(ctx, "locked"); err != nil {
+		t.Fatal(err)
+	}
+	if s, _ := l.Status(ctx, "locked"); s.IsLocked(now) {
+		t.Error("Reset did not lift the lockout")
+	}
+}
+
+func TestNewFromEnv(t *testing.T) {
+	t.Setenv("LOGIN_LIMITER_DRIVER", "Memory")
+	if l, err := NewFromEnv(); err != nil {
+		t.Errorf("memory driver: %v", err)
+	} else if _, ok := l.(*MemoryLimiter); !ok {
+		t.Errorf("memory driver built %T", l)
+	}
+
+	t.Setenv("LOGIN_LIMITER_DRIVER", "redis")
+	if _, err := NewFromEnv(); err == nil {
+		t.Error("unknown driver was accepted")
+	}
+}
@@ -0,0 +1,129 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// loginAttempt is a row of login_attempts
+type loginAttempt struct {
+	AttemptKey    string `gorm:"primaryKey;size:320"`
+	Failures      int
+	Lockouts      int
+	LastFailureAt *time.Time
+	LockedUntil   *time.Time
+	UpdatedAt     time.Time
+}
+
+// TableName override
+func (loginAttempt) TableName() string {
+	return "login_attempts"
+}
+
+func (a *loginAttempt) state() State {
+	state := State{
+		Failures: a.Failures,
+		Lockouts: a.Lockouts,
+	}
+	if a.LastFailureAt != nil {
+		state.LastFailureAt = *a.LastFailureAt
+	}
+	if a.LockedUntil != nil {
+		state.LockedUntil = *a.LockedUntil
+	}
+	return state
+}
+
+// PostgresLimiter keeps the state in the login_attempts table, shared by every server instance
+type PostgresLimiter struct {
+	db *gorm.DB
+}
+
+func NewPostgresLimiter(db *gorm.DB) *PostgresLimiter {
+	return &PostgresLimiter{
+		db: db,
+	}
+}
+
+func (l *PostgresLimiter) Status(ctx context.Context, key string) (State, error) {
+	var attempt loginAttempt
+	err := l.db.WithContext(ctx).Where("attempt_key = ?", key).First(&attempt).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return State{}, nil
+	}
+	if err != nil {
+		return State{}, err
+	}
+	return attempt.state(), nil
+}
+
+func (l *PostgresLimiter) Fail(ctx context.Context, key string, policy Policy, now time.Time) (State, bool, error) {
+	var state State
+	var locked bool
+
+	err := l.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// A sor létrehozása, majd zárolása: párhuzamos próbálkozások sorban növelik a számlálót
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&loginAttempt{AttemptKey: key, UpdatedAt: now}).Error; err != nil {
+			return err
+		}
+		var attempt loginAttempt
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("attempt_key = ?", key).First(&attempt).Error; err != nil {
+			return err
+		}
+
+		state, locked = policy.RecordFailure(attempt.state(), now)
+		updates := map[string]interface{}{
+			"failures":        state.Failures,
+			"lockouts":        state.Lockouts,
+			"last_failure_at": state.LastFailureAt,
+			"updated_at":      now,
+		}
+		if !state.LockedUntil.IsZero() {
+			updates["locked_until"] = state.LockedUntil
+		}
+		return tx.Model(&loginAttempt{}).Where("attempt_key = ?", key).Updates(updates).Error
+	})
+	if err != nil {
+		return State{}, false, err
+	}
+	return state, locked, nil
+}
+
+func (l *PostgresLimiter) Refund(ctx context.Context, key string, policy Policy, reserved State, locked bool) error {
+	return l.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var attempts []loginAttempt
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("attempt_key = ?", key).Find(&attempts).Error; err != nil {
+			return err
+		}
+		// A számlálót közben nullázhatták (sikeres belépés, admin feloldás)
+		if len(attempts) == 0 {
+			return nil
+		}
+
+		state := policy.Refund(attempts[0].state(), reserved, locked)
+		updates := map[string]interface{}{
+			"failures":   state.Failures,
+			"lockouts":   state.Lockouts,
+			"updated_at": time.Now(),
+		}
+		if state.LockedUntil.IsZero() {
+			updates["locked_until"] = nil
+		}
+		return tx.Model(&loginAttempt{}).Where("attempt_key = ?", key).Updates(updates).Error
+	})
+}
+
+func (l *PostgresLimiter) Reset(ctx context.Context, key string) error {
+	return l.db.WithContext(ctx).Where("attempt_key = ?", key).Delete(&loginAttempt{}).Error
+}
+
+func (l *PostgresLimiter) Prune(ctx context.Context, before time.Time) (int64, error) {
+	result := l.db.WithContext(ctx).
+		Where("(last_failure_at IS NULL OR last_failure_at < ?) AND (locked_until IS NULL OR locked_until <= ?)", before, before).
+		Delete(&loginAttempt{})
+	return result.RowsAffected, result.Error
+}
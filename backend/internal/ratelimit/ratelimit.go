@@ -0,0 +1,143 @@
+// Package ratelimit tracks failed attempts per key (e.g. account or IP address) and locks keys
+// with exponential backoff. The state lives behind a driver-agnostic interface, selected with
+// LOGIN_LIMITER_DRIVER (postgres or memory); postgres shares it between server instances.
+package ratelimit
+
+import (
+	"context"
+	"dev-bridge-manager/internal/database"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// State is the failure counter of one key
+type State struct {
+	Failures      int       // failed attempts since the last lockout, within the window
+	Lockouts      int       // consecutive lockouts, drives the backoff
+	LastFailureAt time.Time // zero if there was no failure yet
+	LockedUntil   time.Time // zero if the key was never locked
+}
+
+// IsLocked checks if the key is locked at the given time
+func (s State) IsLocked(now time.Time) bool {
+	return now.Before(s.LockedUntil)
+}
+
+// Policy decides when a key gets locked and for how long
+type Policy struct {
+	MaxAttempts int           // failures within Window that lock the key; 0 disables locking
+	Window      time.Duration // failures older than this are forgotten
+	BaseLockout time.Duration // duration of the first lockout, doubled by every further one
+	MaxLockout  time.Duration // upper limit of a lockout, at least BaseLockout; the backoff resets after this long without failures
+}
+
+// Enabled checks if the policy locks keys at all
+func (p Policy) Enabled() bool {
+	return p.MaxAttempts > 0 && p.BaseLockout > 0
+}
+
+// LockoutDuration returns the length of the key's next lockout after the given number of earlier ones
+func (p Policy) LockoutDuration(lockouts int) time.Duration {
+	duration := p.BaseLockout
+	for i := 0; i < lockouts && duration < p.MaxLockout; i++ {
+		duration *= 2
+	}
+	if duration > p.MaxLockout {
+		duration = p.MaxLockout
+	}
+	return duration
+}
+
+// RecordFailure returns the state after a failed attempt at now and reports whether this attempt
+// locked the key. A key that is already locked stays as it is.
+func (p Policy) RecordFailure(s State, now time.Time) (State, bool) {
+	if s.IsLocked(now) {
+		return s, false
+	}
+
+	if !s.LastFailureAt.IsZero() {
+		idle := now.Sub(s.LastFailureAt)
+		if idle > p.Window {
+			s.Failures = 0
+		}
+		if idle > p.MaxLockout {
+			s.Lockouts = 0
+		}
+	}
+
+	s.Failures++
+	s.LastFailureAt = now
+	if !p.Enabled() || s.Failures < p.MaxAttempts {
+		return s, false
+	}
+
+	s.LockedUntil = now.Add(p.LockoutDuration(s.Lockouts))
+	s.Lockouts++
+	s.Failures = 0
+	return s, true
+}
+
+// Refund takes back an attempt that RecordFailure counted before its outcome was known and that
+// did not fail after all. reserved and locked are what RecordFailure returned for the attempt; if
+// it locked the key and the lockout is still the same, the lockout is lifted as well.
+func (p Policy) Refund(s, reserved State, locked bool) State {
+	if locked && !reserved.LockedUntil.IsZero() && s.LockedUntil.Equal(reserved.LockedUntil) {
+		s.LockedUntil = time.Time{}
+		s.Lockouts--
+		s.Failures = p.MaxAttempts - 1
+		return s
+	}
+	if s.Failures > 0 {
+		s.Failures--
+	}
+	return s
+}
+
+// Limiter stores the failure state of keys
+type Limiter interface {
+	// Status returns the state of key; the zero State if it has none
+	Status(ctx context.Context, key string) (State, error)
+	// Fail records a failed attempt of key under the policy atomically and returns the new
+	// state, and whether this attempt locked the key
+	Fail(ctx context.Context, key string, policy Policy, now time.Time) (State, bool, error)
+	// Refund takes back an attempt counted by Fail atomically (see Policy.Refund)
+	Refund(ctx context.Context, key string, policy Policy, reserved State, locked bool) error
+	// Reset forgets the state of key, lifting its lockout
+	Reset(ctx context.Context, key string) error
+	// Prune drops the state of unlocked keys without failures since before
+	Prune(ctx context.Context, before time.Time) (int64, error)
+}
+
+var (
+	defaultLimiter Limiter
+	defaultOnce    sync.Once
+)
+
+// Default returns the limiter configured by the environment. It is shared by the whole process,
+// which the memory driver relies on. A misconfigured driver stops the server at startup.
+func Default() Limiter {
+	defaultOnce.Do(func() {
+		l, err := NewFromEnv()
+		if err != nil {
+			log.Fatalf("❌ Login limiter configuration error: %v", err)
+		}
+		defaultLimiter = l
+	})
+	return defaultLimiter
+}
+
+// NewFromEnv builds the driver selected by LOGIN_LIMITER_DRIVER
+func NewFromEnv() (Limiter, error) {
+	switch driver := strings.ToLower(os.Getenv("LOGIN_LIMITER_DRIVER")); driver {
+	case "", "postgres":
+		return NewPostgresLimiter(database.GetDB()), nil
+	case "memory":
+		return NewMemoryLimiter(), nil
+	default:
+		return nil, fmt.Errorf("unknown LOGIN_LIMITER_DRIVER %q", driver)
+	}
+}
@@ -0,0 +1,144 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+var testPolicy = Policy{
+	MaxAttempts: 3,
+	Window:      15 * time.Minute,
+	BaseLockout: time.Minute,
+	MaxLockout:  time.Hour,
+}
+
+func TestLockoutDuration(t *testing.T) {
+	tests := []struct {
+		lockouts int
+		want     time.Duration
+	}{
+		{0, time.Minute},
+		{1, 2 * time.Minute},
+		{2, 4 * time.Minute},
+		{5, 32 * time.Minute},
+		{6, time.Hour},
+		{1000, time.Hour},
+	}
+
+	for _, tt := range tests {
+		if got := testPolicy.LockoutDuration(tt.lockouts); got != tt.want {
+			t.Errorf("LockoutDuration(%d) = %s, want %s", tt.lockouts, got, tt.want)
+		}
+	}
+}
+
+func TestRecordFailureLocksWithBackoff(t *testing.T) {
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	var s State
+	var locked bool
+
+	for i := 1; i < testPolicy.MaxAttempts; i++ {
+		s, locked = testPolicy.RecordFailure(s, now)
+		if locked || s.IsLocked(now) || s.Failures != i {
+			t.Fatalf("failure %d: state %+v, locked %v", i, s, locked)
+		}
+	}
+	s, locked = testPolicy.RecordFailure(s, now)
+	if !locked || !s.LockedUntil.Equal(now.Add(time.Minute)) || s.Lockouts != 1 || s.Failures != 0 {
+		t.Fatalf("first lockout: state %+v, locked %v", s, locked)
+	}
+
+	// Zárolás alatt a további hibák nem hosszabbítják a zárolást
+	during := now.Add(30 * time.Second)
+	if next, locked := testPolicy.RecordFailure(s, during); locked || next != s {
+		t.Errorf("failure while locked changed the state: %+v", next)
+	}
+
+	// A következő zárolás kétszer olyan hosszú
+	now = s.LockedUntil
+	for i := 0; i < testPolicy.MaxAttempts; i++ {
+		s, locked = testPolicy.RecordFailure(s, now)
+	}
+	if !locked || !s.LockedUntil.Equal(now.Add(2*time.Minute)) || s.Lockouts != 2 {
+		t.Errorf("second lockout: state %+v, locked %v", s, locked)
+	}
+}
+
+func TestRecordFailureForgetsOldFailures(t *testing.T) {
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	s, _ := testPolicy.RecordFailure(State{}, now)
+	s, _ = testPolicy.RecordFailure(s, now)
+
+	// Az ablakon túli hibák elfelejtődnek
+	s, locked := testPolicy.RecordFailure(s, now.Add(testPolicy.Window+time.Second))
+	if locked || s.Failures != 1 {
+		t.Errorf("failures outside the window were counted: %+v", s)
+	}
+
+	// MaxLockout idejű csend után a backoff is újraindul
+	s = State{Lockouts: 4, LastFailureAt: now, LockedUntil: now.Add(16 * time.Minute)}
+	later := now.Add(testPolicy.MaxLockout + time.Second)
+	for i := 0; i < testPolicy.MaxAttempts; i++ {
+		s, locked = testPolicy.RecordFailure(s, later)
+	}
+	if !locked || !s.LockedUntil.Equal(later.Add(testPolicy.BaseLockout)) {
+		t.Errorf("backoff was not reset after a quiet period: %+v", s)
+	}
+}
+
+func TestRecordFailureWithDisabledPolicy(t *testing.T) {
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	for _, p := range []Policy{{}, {MaxAttempts: 3, Window: time.Minute}} {
+		if p.Enabled() {
+			t.Errorf("%+v is enabled", p)
+		}
+		var s State
+		for i := 0; i < 10; i++ {
+			var locked bool
+			if s, locked = p.RecordFailure(s, now); locked || s.IsLocked(now) {
+				t.Fatalf("disabled policy %+v locked the key", p)
+			}
+		}
+	}
+}
+
+func TestIsLocked(t *testing.T) {
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	s := State{LockedUntil: now}
+	if s.IsLocked(now) || !s.IsLocked(now.Add(-time.Nanosecond)) || (State{}).IsLocked(now) {
+		t.Error("lock boundaries are wrong")
+	}
+}
+
+func TestRefund(t *testing.T) {
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+
+	// Egy nem zároló kísérlet visszavétele csak a számlálót csökkenti
+	before, _ := testPolicy.RecordFailure(State{}, now)
+	reserved, locked := testPolicy.RecordFailure(before, now)
+	if got := testPolicy.Refund(reserved, reserved, locked); got.Failures != before.Failures || got.IsLocked(now) {
+		t.Errorf("refund of a counted attempt: %+v, want %d failures", got, before.Failures)
+	}
+
+	// A zároló kísérlet visszavétele a zárolást is feloldja
+	before = reserved
+	reserved, locked = testPolicy.RecordFailure(before, now)
+	if !locked {
+		t.Fatalf("attempt %d did not lock: %+v", testPolicy.MaxAttempts, reserved)
+	}
+	got := testPolicy.Refund(reserved, reserved, locked)
+	if got.IsLocked(now) || got.Lockouts != 0 || got.Failures != testPolicy.MaxAttempts-1 {
+		t.Errorf("refund of the locking attempt: %+v", got)
+	}
+
+	// Egy későbbi, másik kísérlet okozta zárolás megmarad
+	later := State{Lockouts: 2, LastFailureAt: now, LockedUntil: now.Add(2 * time.Minute)}
+	if got := testPolicy.Refund(later, reserved, locked); got != later {
+		t.Errorf("refund lifted another attempt's lockout: %+v", got)
+	}
+
+	// Közben nullázott számláló nem megy negatívba
+	if got := testPolicy.Refund(State{}, before, false); got.Failures != 0 {
+		t.Errorf("refund of a reset counter: %+v", got)
+	}
+}
@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"dev-bridge-manager/internal/handlers"
+	"dev-bridge-manager/internal/middleware"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupAnalyticsRoutes(api fiber.Router) {
+	analyticsHandler := handlers.NewAnalyticsHandler()
+
+	projects := api.Group("/projects/:id")
+
+	// GET /api/v1/projects/:id/analytics - Cycle time, lead time, throughput, oszloponkénti idő
+	projects.Get("/analytics", middleware.JWTMiddleware(), analyticsHandler.GetProjectAnalytics)
+
+	// GET /api/v1/projects/:id/tasks/:taskId/history - Task oszlopváltásai
+	projects.Get("/tasks/:taskId/history", middleware.JWTMiddleware(), analyticsHandler.GetTaskHistory)
+}
@@ -0,0 +1,60 @@
+package routes
+
+import (
+	"bytes"
+	"dev-bridge-manager/internal/handlers"
+	"dev-bridge-manager/internal/middleware"
+	"regexp"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+)
+
+// uploadPath matches the attachment upload route; like Fiber's router it
+// ignores case and a trailing slash
+var uploadPath = regexp.MustCompile(`(?i)^/api/v1/projects/[^/]+/tasks/[^/]+/attachments/?$`)
+
+// UploadBodyLimit returns a request header hook that raises the body limit to
+// limit for attachment uploads only. Every other request keeps the server's
+// default limit, because the body is read before Fiber routes the request.
+func UploadBodyLimit(limit int) func(header *fasthttp.RequestHeader) fasthttp.RequestConfig {
+	return func(header *fasthttp.RequestHeader) fasthttp.RequestConfig {
+		path := header.RequestURI()
+		if i := bytes.IndexByte(path, '?'); i >= 0 {
+			path = path[:i]
+		}
+		if header.IsPost() && uploadPath.Match(path) {
+			return fasthttp.RequestConfig{MaxRequestBodySize: limit}
+		}
+		return fasthttp.RequestConfig{}
+	}
+}
+
+func SetupAttachmentRoutes(api fiber.Router) {
+	attachmentHandler := handlers.NewAttachmentHandler()
+
+	// Task csatolmányok
+	attachments := api.Group("/projects/:id/tasks/:taskId/attachments")
+	attachments.Use(middleware.JWTMiddleware())
+
+	// GET /api/v1/projects/:id/tasks/:taskId/attachments - Csatolmányok listája aláírt URL-ekkel
+	attachments.Get("/", attachmentHandler.GetTaskAttachments)
+
+	// POST /api/v1/projects/:id/tasks/:taskId/attachments - Fájl feltöltése (multipart, "file" mező)
+	attachments.Post("/", attachmentHandler.UploadAttachment)
+
+	// GET /api/v1/projects/:id/tasks/:taskId/attachments/:attachmentId/url - Új aláírt letöltési URL
+	attachments.Get("/:attachmentId/url", attachmentHandler.GetAttachmentURL)
+
+	// GET /api/v1/projects/:id/tasks/:taskId/attachments/:attachmentId/download - Letöltés
+	attachments.Get("/:attachmentId/download", attachmentHandler.DownloadAttachment)
+
+	// DELETE /api/v1/projects/:id/tasks/:taskId/attachments/:attachmentId - Törlés (feltöltő vagy manager)
+	attachments.Delete("/:attachmentId", attachmentHandler.DeleteAttachment)
+
+	// GET /api/v1/projects/:id/attachments/usage - Projekt tárhely használat és kvóta
+	api.Get("/projects/:id/attachments/usage", middleware.JWTMiddleware(), attachmentHandler.GetAttachmentUsage)
+
+	// GET /api/v1/files/* - Aláírt, lejáró letöltési link (local driver, JWT nélkül)
+	api.Get("/files/*", attachmentHandler.DownloadSignedFile)
+}
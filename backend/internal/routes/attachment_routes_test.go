@@ -0,0 +1,72 @@
+package routes
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+)
+
+// testStatus sends the request and returns the response status. The server may
+// drop an oversized body before answering, which counts as 413 as well.
+func testStatus(t *testing.T, app *fiber.App, req *http.Request) int {
+	t.Helper()
+	resp, err := app.Test(req, -1)
+	if errors.Is(err, fasthttp.ErrBodyTooLarge) {
+		return fiber.StatusRequestEntityTooLarge
+	}
+	if err != nil {
+		t.Fatalf("%s %s: %v", req.Method, req.URL, err)
+	}
+	return resp.StatusCode
+}
+
+func TestUploadBodyLimitOnlyRaisesUploadRoute(t *testing.T) {
+	app := fiber.New()
+	app.Server().HeaderReceived = UploadBodyLimit(8 << 20)
+	ok := func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusNoContent) }
+	app.Post("/api/v1/projects/:id/tasks/:taskId/attachments", ok)
+	app.Post("/api/v1/projects/:id/tasks/:taskId/comments", ok)
+	app.Put("/api/v1/projects/:id/tasks/:taskId/attachments", ok)
+
+	// Nagyobb az alapértelmezett 4 MB-os limitnél
+	body := bytes.Repeat([]byte("x"), fiber.DefaultBodyLimit+1)
+
+	tests := []struct {
+		method string
+		target string
+		size   int
+		want   int
+	}{
+		{"POST", "/api/v1/projects/1/tasks/2/attachments", len(body), fiber.StatusNoContent},
+		{"POST", "/api/v1/projects/1/tasks/2/attachments/?x=1", len(body), fiber.StatusNoContent},
+		{"POST", "/API/V1/projects/1/tasks/2/Attachments", len(body), fiber.StatusNoContent},
+		{"POST", "/api/v1/projects/1/tasks/2/comments", len(body), fiber.StatusRequestEntityTooLarge},
+		{"PUT", "/api/v1/projects/1/tasks/2/attachments", len(body), fiber.StatusRequestEntityTooLarge},
+		{"POST", "/api/v1/projects/1/tasks/2/comments", 1024, fiber.StatusNoContent},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.target, bytes.NewReader(body[:tt.size]))
+		if status := testStatus(t, app, req); status != tt.want {
+			t.Errorf("%s %s with %d bytes: status = %d, want %d", tt.method, tt.target, tt.size, status, tt.want)
+		}
+	}
+}
+
+func TestUploadBodyLimitRejectsOversizedUpload(t *testing.T) {
+	app := fiber.New()
+	app.Server().HeaderReceived = UploadBodyLimit(1 << 20)
+	app.Post("/api/v1/projects/:id/tasks/:taskId/attachments", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	req := httptest.NewRequest("POST", "/api/v1/projects/1/tasks/2/attachments", bytes.NewReader(make([]byte, 2<<20)))
+	if status := testStatus(t, app, req); status != fiber.StatusRequestEntityTooLarge {
+		t.Errorf("status = %d, want %d", status, fiber.StatusRequestEntityTooLarge)
+	}
+}
@@ -8,15 +8,38 @@ import (
 
 func SetupAuthRoutes(api fiber.Router) {
 	authHandler := handlers.NewAuthHandler()
+	mfaHandler := handlers.NewMFAHandler()
+	accountHandler := handlers.NewAccountHandler()
 
 	auth := api.Group("/auth")
 
 	// Public endpoints
 	auth.Post("/register", authHandler.Register)
 	auth.Post("/login", authHandler.Login)
+	auth.Post("/refresh", authHandler.RefreshToken) // Refresh token a body-ban, rotációval
+
+	// Jelszó visszaállítás és email megerősítés (emailben küldött, egyszer használható tokenekkel)
+	auth.Post("/forgot-password", accountHandler.ForgotPassword) // Visszaállító link küldése
+	auth.Post("/reset-password", accountHandler.ResetPassword)   // Új jelszó a tokennel
+	auth.Post("/verify-email", accountHandler.VerifyEmail)       // Email cím megerősítése a tokennel
+
+	// MFA belépés második lépése (a belépéskor kapott mfa_token-nel)
+	auth.Post("/mfa/verify", mfaHandler.VerifyMFALogin)               // TOTP vagy helyreállító kód
+	auth.Post("/mfa/enroll", mfaHandler.EnrollMFA)                    // Kötelező MFA beállítása: titok és URI
+	auth.Post("/mfa/enroll/confirm", mfaHandler.ConfirmMFAEnrollment) // Kötelező MFA megerősítése és belépés
 
 	// Protected endpoints
 	auth.Get("/me", middleware.JWTMiddleware(), authHandler.Me)
-	auth.Post("/refresh", authHandler.RefreshToken)
+	auth.Post("/logout", middleware.JWTMiddleware(), authHandler.Logout)                             // Aktuális session visszavonása
+	auth.Get("/sessions", middleware.JWTMiddleware(), authHandler.GetSessions)                       // Saját aktív sessionök
+	auth.Delete("/sessions/:id", middleware.JWTMiddleware(), authHandler.RevokeSession)              // Session távoli visszavonása
+	auth.Post("/verify-email/resend", middleware.JWTMiddleware(), accountHandler.ResendVerification) // Új megerősítő email
 
+	// Saját MFA kezelése
+	mfa := auth.Group("/mfa", middleware.JWTMiddleware())
+	mfa.Get("/", mfaHandler.GetMFAStatus)                           // MFA állapot
+	mfa.Post("/setup", mfaHandler.SetupMFA)                         // Új titok és provisioning URI
+	mfa.Post("/enable", mfaHandler.EnableMFA)                       // Megerősítés kóddal, helyreállító kódok
+	mfa.Post("/disable", mfaHandler.DisableMFA)                     // Kikapcsolás jelszóval és kóddal
+	mfa.Post("/recovery-codes", mfaHandler.RegenerateRecoveryCodes) // Új helyreállító kódok
 }
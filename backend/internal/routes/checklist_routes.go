@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"dev-bridge-manager/internal/handlers"
+	"dev-bridge-manager/internal/middleware"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupChecklistRoutes(api fiber.Router) {
+	checklistHandler := handlers.NewChecklistHandler()
+
+	checklist := api.Group("/projects/:id/tasks/:taskId/checklist")
+	checklist.Use(middleware.JWTMiddleware())
+
+	// GET /api/v1/projects/:id/tasks/:taskId/checklist - Task checklistája haladással
+	checklist.Get("/", checklistHandler.GetChecklist)
+
+	// POST /api/v1/projects/:id/tasks/:taskId/checklist - Checklist elem hozzáadása
+	checklist.Post("/", checklistHandler.CreateChecklistItem)
+
+	// PUT /api/v1/projects/:id/tasks/:taskId/checklist/:itemId - Cím, kész jelző, sorrend módosítása
+	checklist.Put("/:itemId", checklistHandler.UpdateChecklistItem)
+
+	// DELETE /api/v1/projects/:id/tasks/:taskId/checklist/:itemId - Checklist elem törlése
+	checklist.Delete("/:itemId", checklistHandler.DeleteChecklistItem)
+}
@@ -0,0 +1,40 @@
+package routes
+
+import (
+	"dev-bridge-manager/internal/handlers"
+	"dev-bridge-manager/internal/middleware"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupCommentRoutes(api fiber.Router) {
+	commentHandler := handlers.NewCommentHandler()
+
+	// Task szintű kommentek
+	taskComments := api.Group("/projects/:id/tasks/:taskId/comments")
+	taskComments.Use(middleware.JWTMiddleware())
+
+	// GET /api/v1/projects/:id/tasks/:taskId/comments - Task kommentjei (kitűzöttek elöl)
+	taskComments.Get("/", commentHandler.GetTaskComments)
+
+	// POST /api/v1/projects/:id/tasks/:taskId/comments - Komment írása
+	taskComments.Post("/", commentHandler.CreateComment)
+
+	// Komment műveletek
+	comments := api.Group("/projects/:id/comments")
+	comments.Use(middleware.JWTMiddleware())
+
+	// PUT /api/v1/projects/:id/comments/:commentId - Komment szerkesztése (csak szerző)
+	comments.Put("/:commentId", commentHandler.UpdateComment)
+
+	// DELETE /api/v1/projects/:id/comments/:commentId - Komment törlése (szerző vagy manager)
+	comments.Delete("/:commentId", commentHandler.DeleteComment)
+
+	// GET /api/v1/projects/:id/comments/:commentId/revisions - Korábbi változatok
+	comments.Get("/:commentId/revisions", commentHandler.GetCommentRevisions)
+
+	// POST /api/v1/projects/:id/comments/:commentId/react - Emoji reakció
+	comments.Post("/:commentId/react", commentHandler.ReactToComment)
+
+	// PUT /api/v1/projects/:id/comments/:commentId/pin - Kitűzés/levétel (manager)
+	comments.Put("/:commentId/pin", commentHandler.TogglePinComment)
+}
@@ -0,0 +1,34 @@
+package routes
+
+import (
+	"dev-bridge-manager/internal/handlers"
+	"dev-bridge-manager/internal/middleware"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupKanbanRoutes(api fiber.Router) {
+	kanbanHandler := handlers.NewKanbanHandler()
+
+	// Kanban routes group - a projekt szerepkör ellenőrzés a handler-ben történik
+	kanban := api.Group("/projects/:id/kanban")
+	kanban.Use(middleware.JWTMiddleware())
+
+	// GET /api/v1/projects/:id/kanban - Board oszlopokkal és taskokkal
+	kanban.Get("/", kanbanHandler.GetBoard)
+
+	// PUT /api/v1/projects/:id/kanban - Board beállítások frissítése (manager)
+	kanban.Put("/", kanbanHandler.UpdateBoard)
+
+	// POST /api/v1/projects/:id/kanban/columns - Oszlop létrehozása (manager)
+	kanban.Post("/columns", kanbanHandler.CreateColumn)
+
+	// PUT /api/v1/projects/:id/kanban/columns/reorder - Oszlopok átrendezése (manager)
+	// A /:columnId előtt kell regisztrálni
+	kanban.Put("/columns/reorder", kanbanHandler.ReorderColumns)
+
+	// PUT /api/v1/projects/:id/kanban/columns/:columnId - Oszlop frissítése (manager)
+	kanban.Put("/columns/:columnId", kanbanHandler.UpdateColumn)
+
+	// DELETE /api/v1/projects/:id/kanban/columns/:columnId - Üres oszlop törlése (manager)
+	kanban.Delete("/columns/:columnId", kanbanHandler.DeleteColumn)
+}
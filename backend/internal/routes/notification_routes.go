@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"dev-bridge-manager/internal/handlers"
+	"dev-bridge-manager/internal/middleware"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupNotificationRoutes(api fiber.Router) {
+	notificationHandler := handlers.NewNotificationHandler()
+
+	notifications := api.Group("/notifications")
+	notifications.Use(middleware.JWTMiddleware())
+
+	// GET /api/v1/notifications - Saját értesítések és az olvasatlanok száma
+	notifications.Get("/", notificationHandler.GetNotifications)
+
+	// PUT /api/v1/notifications/read-all - Minden értesítés olvasottra állítása
+	notifications.Put("/read-all", notificationHandler.MarkAllNotificationsRead)
+
+	// PUT /api/v1/notifications/:notificationId/read - Értesítés olvasottra állítása
+	notifications.Put("/:notificationId/read", notificationHandler.MarkNotificationRead)
+}
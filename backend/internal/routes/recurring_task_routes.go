@@ -0,0 +1,29 @@
+package routes
+
+import (
+	"dev-bridge-manager/internal/handlers"
+	"dev-bridge-manager/internal/middleware"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupRecurringTaskRoutes(api fiber.Router) {
+	recurringTaskHandler := handlers.NewRecurringTaskHandler()
+
+	recurring := api.Group("/projects/:id/recurring-tasks")
+	recurring.Use(middleware.JWTMiddleware())
+
+	// GET /api/v1/projects/:id/recurring-tasks - Ismétlődő task sablonok listázása
+	recurring.Get("/", recurringTaskHandler.GetRecurringTasks)
+
+	// GET /api/v1/projects/:id/recurring-tasks/:templateId - Egy sablon a következő futásokkal
+	recurring.Get("/:templateId", recurringTaskHandler.GetRecurringTask)
+
+	// POST /api/v1/projects/:id/recurring-tasks - Új sablon cron ütemezéssel (manager)
+	recurring.Post("/", recurringTaskHandler.CreateRecurringTask)
+
+	// PUT /api/v1/projects/:id/recurring-tasks/:templateId - Sablon módosítása (manager)
+	recurring.Put("/:templateId", recurringTaskHandler.UpdateRecurringTask)
+
+	// DELETE /api/v1/projects/:id/recurring-tasks/:templateId - Sablon törlése (manager)
+	recurring.Delete("/:templateId", recurringTaskHandler.DeleteRecurringTask)
+}
@@ -18,6 +18,20 @@ func SetupRoutes(app *fiber.App) {
 	SetupRoleRoutes(v1)              // Role endpoints
 	SetupPermissionRoutes(v1)        // Permission endpoints
 	SetupProjectRoutes(v1)           // Project endpoints - ÚJ!
+	SetupTaskRoutes(v1)              // Task endpoints
+	SetupKanbanRoutes(v1)            // Kanban board endpoints
+	SetupTimeEntryRoutes(v1)         // Time entry endpoints
+	SetupTimerRoutes(v1)             // Timer endpoints
+	SetupCommentRoutes(v1)           // Task comment endpoints
+	SetupAttachmentRoutes(v1)        // Task attachment endpoints
+	SetupTagRoutes(v1)               // Project tag endpoints
+	SetupChecklistRoutes(v1)         // Task checklist endpoints
+	SetupTaskLinkRoutes(v1)          // Task dependency endpoints
+	SetupSprintRoutes(v1)            // Sprint endpoints
+	SetupAnalyticsRoutes(v1)         // Project analytics endpoints
+	SetupWorkflowRoutes(v1)          // Project workflow endpoints
+	SetupRecurringTaskRoutes(v1)     // Recurring task template endpoints
+	SetupNotificationRoutes(v1)      // Notification endpoints
 	SetupProjectAssignmentRoutes(v1) // Project endpoints - ÚJ!
 }
 
@@ -32,9 +46,28 @@ func SetupAPIRoutes(api fiber.Router) {
 				"GET /api/v1 - API info",
 				"GET /api/v1/users - Get all users",
 				"GET /api/v1/users/:id - Get user by ID",
-				"POST /api/v1/auth/register - User registration",
-				"POST /api/v1/auth/login - User login",
+				"POST /api/v1/auth/register - User registration, emails a confirmation link; unverified accounts get limited permissions",
+				"POST /api/v1/auth/login - User login, returns a short-lived access token and a refresh token, or an mfa_token when two-factor verification or setup is required; repeated failures lock the account and IP (429)",
+				"POST /api/v1/auth/forgot-password - Email a single-use password reset link",
+				"POST /api/v1/auth/reset-password - Set a new password with the reset token, revokes every session",
+				"POST /api/v1/auth/verify-email - Confirm the email address with the emailed token",
+				"POST /api/v1/auth/mfa/verify - Second login step with mfa_token and TOTP or recovery code",
+				"POST /api/v1/auth/mfa/enroll - Start mandatory two-factor setup with the mfa_token of the login",
+				"POST /api/v1/auth/mfa/enroll/confirm - Confirm mandatory two-factor setup with a code, returns tokens and recovery codes",
+				"POST /api/v1/auth/refresh - Exchange refresh token (body) for new access and refresh tokens; reused tokens revoke the session",
 				"GET /api/v1/auth/me - Current user (protected)",
+				"POST /api/v1/auth/logout - Revoke the current session (protected)",
+				"GET /api/v1/auth/sessions - List own active sessions with device, IP and user agent (protected)",
+				"DELETE /api/v1/auth/sessions/:id - Revoke one of the own sessions (protected)",
+				"POST /api/v1/auth/verify-email/resend - Resend the email confirmation link (protected)",
+				"GET /api/v1/auth/mfa - Own two-factor status (protected)",
+				"POST /api/v1/auth/mfa/setup - Generate TOTP secret and provisioning URI (protected)",
+				"POST /api/v1/auth/mfa/enable - Confirm TOTP setup with a code, returns recovery codes (protected)",
+				"POST /api/v1/auth/mfa/disable - Disable two-factor with password and code, unless the role requires it (protected)",
+				"POST /api/v1/auth/mfa/recovery-codes - Regenerate recovery codes with a TOTP code (protected)",
+				"DELETE /api/v1/users/:id/mfa - Reset a user's two-factor authentication and sessions (users.update)",
+				"GET /api/v1/users/:id/lockouts - Login lockout status and lockout events of a user (users.read)",
+				"POST /api/v1/users/:id/unlock - Unlock an account locked by failed logins (users.update)",
 				"GET /api/v1/users - Get all users",
 				"GET /api/v1/users/:id - Get user by ID",
 				"GET /api/v1/projects - Get all projects (protected)",
@@ -42,6 +75,81 @@ func SetupAPIRoutes(api fiber.Router) {
 				"POST /api/v1/projects - Create project (admin only)",
 				"PUT /api/v1/projects/:id - Update project (admin only)",
 				"DELETE /api/v1/projects/:id - Delete project (admin only)",
+				"GET /api/v1/projects/:id/tasks - Get project tasks (protected)",
+				"POST /api/v1/projects/:id/tasks - Create task (project member)",
+				"PUT /api/v1/projects/:id/tasks/bulk - Bulk update tasks (project member)",
+				"GET /api/v1/projects/:id/tasks/:taskId - Get task (protected)",
+				"PUT /api/v1/projects/:id/tasks/:taskId - Update task (project member)",
+				"GET /api/v1/projects/:id/tasks/:taskId/subtasks - Get subtasks (protected)",
+				"PUT /api/v1/projects/:id/tasks/:taskId/move - Move task; workflow violations return 422 with allowed targets, parents with open subtasks need force to reach Done, blocked tasks get a warning (project member)",
+				"POST /api/v1/projects/:id/tasks/:taskId/duplicate - Duplicate task (project member)",
+				"PUT /api/v1/projects/:id/tasks/:taskId/archive - Archive task (project member)",
+				"PUT /api/v1/projects/:id/tasks/:taskId/restore - Restore archived task (project member)",
+				"DELETE /api/v1/projects/:id/tasks/:taskId - Delete task (project member)",
+				"GET /api/v1/projects/:id/kanban - Get kanban board (protected)",
+				"PUT /api/v1/projects/:id/kanban - Update board settings (project manager)",
+				"POST /api/v1/projects/:id/kanban/columns - Create column (project manager)",
+				"PUT /api/v1/projects/:id/kanban/columns/reorder - Reorder columns (project manager)",
+				"PUT /api/v1/projects/:id/kanban/columns/:columnId - Update column (project manager)",
+				"DELETE /api/v1/projects/:id/kanban/columns/:columnId - Delete empty column (project manager)",
+				"GET /api/v1/projects/:id/tasks/:taskId/time-entries - Get task time entries (protected)",
+				"POST /api/v1/projects/:id/tasks/:taskId/time-entries - Log time on task (project member)",
+				"GET /api/v1/projects/:id/time-entries - Get project time entries (protected)",
+				"GET /api/v1/projects/:id/time-entries/summary - Get time and billable hours summary (protected)",
+				"PUT /api/v1/projects/:id/time-entries/:entryId - Update time entry (author or project manager)",
+				"DELETE /api/v1/projects/:id/time-entries/:entryId - Delete time entry (author or project manager)",
+				"POST /api/v1/projects/:id/tasks/:taskId/timer/start - Start timer, stopping any running one (project member)",
+				"POST /api/v1/projects/:id/tasks/:taskId/timer/stop - Stop timer and log time (project member)",
+				"GET /api/v1/projects/:id/timer/active - Get own active timer (protected)",
+				"GET /api/v1/projects/:id/tasks/:taskId/comments - Get task comments, pinned first (protected)",
+				"POST /api/v1/projects/:id/tasks/:taskId/comments - Create comment (project member)",
+				"PUT /api/v1/projects/:id/comments/:commentId - Edit comment (author only)",
+				"DELETE /api/v1/projects/:id/comments/:commentId - Delete comment (author or project manager)",
+				"GET /api/v1/projects/:id/comments/:commentId/revisions - Get comment edit history (protected)",
+				"POST /api/v1/projects/:id/comments/:commentId/react - Toggle emoji reaction (project member)",
+				"PUT /api/v1/projects/:id/comments/:commentId/pin - Pin or unpin comment (project manager)",
+				"GET /api/v1/projects/:id/tasks/:taskId/attachments - Get task attachments with signed URLs (protected)",
+				"POST /api/v1/projects/:id/tasks/:taskId/attachments - Upload attachment (project member)",
+				"GET /api/v1/projects/:id/tasks/:taskId/attachments/:attachmentId/url - Get signed download URL (protected)",
+				"GET /api/v1/projects/:id/tasks/:taskId/attachments/:attachmentId/download - Download attachment (protected)",
+				"DELETE /api/v1/projects/:id/tasks/:taskId/attachments/:attachmentId - Delete attachment (uploader or project manager)",
+				"GET /api/v1/projects/:id/attachments/usage - Get attachment storage usage (protected)",
+				"GET /api/v1/files/* - Download file via signed, expiring URL",
+				"GET /api/v1/projects/:id/tags - Get project tags with usage counts (protected)",
+				"POST /api/v1/projects/:id/tags - Create tag (project member)",
+				"PUT /api/v1/projects/:id/tags/:tagId - Rename or recolor tag (project manager)",
+				"DELETE /api/v1/projects/:id/tags/:tagId - Delete tag from every task (project manager)",
+				"POST /api/v1/projects/:id/tags/:tagId/merge - Merge tag into another tag (project manager)",
+				"GET /api/v1/projects/:id/tasks/:taskId/checklist - Get task checklist with progress (protected)",
+				"POST /api/v1/projects/:id/tasks/:taskId/checklist - Add checklist item (project member)",
+				"PUT /api/v1/projects/:id/tasks/:taskId/checklist/:itemId - Update checklist item (project member)",
+				"DELETE /api/v1/projects/:id/tasks/:taskId/checklist/:itemId - Delete checklist item (project member)",
+				"GET /api/v1/projects/:id/tasks/:taskId/links - Get task links (protected)",
+				"POST /api/v1/projects/:id/tasks/:taskId/links - Link tasks, across visible projects; blocking cycles are rejected (project member)",
+				"DELETE /api/v1/projects/:id/tasks/:taskId/links/:linkId - Delete task link (project member)",
+				"GET /api/v1/projects/:id/dependency-graph - Get dependency graph nodes and edges (protected)",
+				"GET /api/v1/projects/:id/sprints - Get project sprints with task statistics (protected)",
+				"POST /api/v1/projects/:id/sprints - Create planned sprint (project manager)",
+				"GET /api/v1/projects/:id/sprints/:sprintId - Get sprint (protected)",
+				"PUT /api/v1/projects/:id/sprints/:sprintId - Update sprint (project manager)",
+				"DELETE /api/v1/projects/:id/sprints/:sprintId - Delete planned sprint, its tasks return to the backlog (project manager)",
+				"POST /api/v1/projects/:id/sprints/:sprintId/start - Start sprint, one active sprint per project (project manager)",
+				"POST /api/v1/projects/:id/sprints/:sprintId/close - Close sprint, moving unfinished tasks to the next sprint or backlog (project manager)",
+				"GET /api/v1/projects/:id/sprints/:sprintId/scope-changes - Get tasks added to or removed from the running sprint (protected)",
+				"GET /api/v1/projects/:id/sprints/:sprintId/burndown - Get daily remaining estimate and task count points (protected)",
+				"GET /api/v1/projects/:id/burnup - Get daily scope and completed work points, last 30 days by default (protected)",
+				"GET /api/v1/projects/:id/analytics - Get cycle time, lead time, weekly throughput and time per column, last 90 days by default (protected)",
+				"GET /api/v1/projects/:id/tasks/:taskId/history - Get task column transitions (protected)",
+				"GET /api/v1/projects/:id/workflow - Get column status categories and allowed transitions (protected)",
+				"PUT /api/v1/projects/:id/workflow - Enable workflow and replace allowed transitions, optionally role-restricted (project manager)",
+				"GET /api/v1/projects/:id/recurring-tasks - List recurring task templates with their upcoming runs (protected)",
+				"GET /api/v1/projects/:id/recurring-tasks/:templateId - Get recurring task template (protected)",
+				"POST /api/v1/projects/:id/recurring-tasks - Create recurring task template with a cron schedule and timezone (project manager)",
+				"PUT /api/v1/projects/:id/recurring-tasks/:templateId - Update recurring task template (project manager)",
+				"DELETE /api/v1/projects/:id/recurring-tasks/:templateId - Delete recurring task template, created tasks are kept (project manager)",
+				"GET /api/v1/notifications - List own notifications (due date reminders, overdue escalations) with unread count, ?unread=true&limit= (protected)",
+				"PUT /api/v1/notifications/read-all - Mark all own notifications as read (protected)",
+				"PUT /api/v1/notifications/:notificationId/read - Mark notification as read (protected)",
 			},
 		})
 	})
@@ -0,0 +1,45 @@
+package routes
+
+import (
+	"dev-bridge-manager/internal/handlers"
+	"dev-bridge-manager/internal/middleware"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupSprintRoutes(api fiber.Router) {
+	sprintHandler := handlers.NewSprintHandler()
+	burndownHandler := handlers.NewBurndownHandler()
+
+	sprints := api.Group("/projects/:id/sprints")
+	sprints.Use(middleware.JWTMiddleware())
+
+	// GET /api/v1/projects/:id/sprints - Projekt sprintjei statisztikával
+	sprints.Get("/", sprintHandler.GetProjectSprints)
+
+	// POST /api/v1/projects/:id/sprints - Sprint tervezése (manager)
+	sprints.Post("/", sprintHandler.CreateSprint)
+
+	// GET /api/v1/projects/:id/sprints/:sprintId - Sprint részletei
+	sprints.Get("/:sprintId", sprintHandler.GetSprint)
+
+	// PUT /api/v1/projects/:id/sprints/:sprintId - Sprint módosítása (manager)
+	sprints.Put("/:sprintId", sprintHandler.UpdateSprint)
+
+	// DELETE /api/v1/projects/:id/sprints/:sprintId - Tervezett sprint törlése (manager)
+	sprints.Delete("/:sprintId", sprintHandler.DeleteSprint)
+
+	// POST /api/v1/projects/:id/sprints/:sprintId/start - Sprint indítása (manager)
+	sprints.Post("/:sprintId/start", sprintHandler.StartSprint)
+
+	// POST /api/v1/projects/:id/sprints/:sprintId/close - Sprint lezárása riporttal (manager)
+	sprints.Post("/:sprintId/close", sprintHandler.CloseSprint)
+
+	// GET /api/v1/projects/:id/sprints/:sprintId/scope-changes - Scope változások
+	sprints.Get("/:sprintId/scope-changes", sprintHandler.GetSprintScopeChanges)
+
+	// GET /api/v1/projects/:id/sprints/:sprintId/burndown - Napi burndown pontok
+	sprints.Get("/:sprintId/burndown", burndownHandler.GetSprintBurndown)
+
+	// GET /api/v1/projects/:id/burnup - Projekt burnup scope vonallal
+	api.Get("/projects/:id/burnup", middleware.JWTMiddleware(), burndownHandler.GetProjectBurnup)
+}
@@ -0,0 +1,29 @@
+package routes
+
+import (
+	"dev-bridge-manager/internal/handlers"
+	"dev-bridge-manager/internal/middleware"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupTagRoutes(api fiber.Router) {
+	tagHandler := handlers.NewTagHandler()
+
+	tags := api.Group("/projects/:id/tags")
+	tags.Use(middleware.JWTMiddleware())
+
+	// GET /api/v1/projects/:id/tags - Projekt tagjei használati számmal
+	tags.Get("/", tagHandler.GetProjectTags)
+
+	// POST /api/v1/projects/:id/tags - Tag létrehozása
+	tags.Post("/", tagHandler.CreateTag)
+
+	// PUT /api/v1/projects/:id/tags/:tagId - Átnevezés/átszínezés (manager)
+	tags.Put("/:tagId", tagHandler.UpdateTag)
+
+	// DELETE /api/v1/projects/:id/tags/:tagId - Tag törlése (manager)
+	tags.Delete("/:tagId", tagHandler.DeleteTag)
+
+	// POST /api/v1/projects/:id/tags/:tagId/merge - Összevonás másik taggel (manager)
+	tags.Post("/:tagId/merge", tagHandler.MergeTag)
+}
@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"dev-bridge-manager/internal/handlers"
+	"dev-bridge-manager/internal/middleware"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupTaskLinkRoutes(api fiber.Router) {
+	taskLinkHandler := handlers.NewTaskLinkHandler()
+
+	links := api.Group("/projects/:id/tasks/:taskId/links")
+	links.Use(middleware.JWTMiddleware())
+
+	// GET /api/v1/projects/:id/tasks/:taskId/links - Task kapcsolatai (blocks, blocked_by, relates_to)
+	links.Get("/", taskLinkHandler.GetTaskLinks)
+
+	// POST /api/v1/projects/:id/tasks/:taskId/links - Kapcsolat létrehozása (projektek között is)
+	links.Post("/", taskLinkHandler.CreateTaskLink)
+
+	// DELETE /api/v1/projects/:id/tasks/:taskId/links/:linkId - Kapcsolat törlése
+	links.Delete("/:linkId", taskLinkHandler.DeleteTaskLink)
+
+	// GET /api/v1/projects/:id/dependency-graph - Függőségi gráf (nodes, edges)
+	api.Get("/projects/:id/dependency-graph", middleware.JWTMiddleware(), taskLinkHandler.GetDependencyGraph)
+}
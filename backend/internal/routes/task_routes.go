@@ -0,0 +1,49 @@
+package routes
+
+import (
+	"dev-bridge-manager/internal/handlers"
+	"dev-bridge-manager/internal/middleware"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupTaskRoutes(api fiber.Router) {
+	taskHandler := handlers.NewTaskHandler()
+
+	// Task routes group - a projekt szerepkör ellenőrzés a handler-ben történik
+	tasks := api.Group("/projects/:id/tasks")
+	tasks.Use(middleware.JWTMiddleware())
+
+	// GET /api/v1/projects/:id/tasks - Taskok listázása (szűrőkkel)
+	tasks.Get("/", taskHandler.GetTasks)
+
+	// POST /api/v1/projects/:id/tasks - Task létrehozása
+	tasks.Post("/", taskHandler.CreateTask)
+
+	// PUT /api/v1/projects/:id/tasks/bulk - Több task módosítása egyszerre (mindent vagy semmit)
+	// A /:taskId előtt kell regisztrálni
+	tasks.Put("/bulk", taskHandler.BulkUpdateTasks)
+
+	// GET /api/v1/projects/:id/tasks/:taskId - Egy task megtekintése
+	tasks.Get("/:taskId", taskHandler.GetTask)
+
+	// PUT /api/v1/projects/:id/tasks/:taskId - Task frissítése
+	tasks.Put("/:taskId", taskHandler.UpdateTask)
+
+	// GET /api/v1/projects/:id/tasks/:taskId/subtasks - A task subtaskjai
+	tasks.Get("/:taskId/subtasks", taskHandler.GetSubtasks)
+
+	// PUT /api/v1/projects/:id/tasks/:taskId/move - Task mozgatása oszlopok között (force: nyitott subtaskok mellett is Done-ba)
+	tasks.Put("/:taskId/move", taskHandler.MoveTask)
+
+	// POST /api/v1/projects/:id/tasks/:taskId/duplicate - Task másolása
+	tasks.Post("/:taskId/duplicate", taskHandler.DuplicateTask)
+
+	// PUT /api/v1/projects/:id/tasks/:taskId/archive - Task archiválása
+	tasks.Put("/:taskId/archive", taskHandler.ArchiveTask)
+
+	// PUT /api/v1/projects/:id/tasks/:taskId/restore - Archivált task visszaállítása
+	tasks.Put("/:taskId/restore", taskHandler.RestoreTask)
+
+	// DELETE /api/v1/projects/:id/tasks/:taskId - Task törlése
+	tasks.Delete("/:taskId", taskHandler.DeleteTask)
+}
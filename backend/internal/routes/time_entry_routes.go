@@ -0,0 +1,37 @@
+package routes
+
+import (
+	"dev-bridge-manager/internal/handlers"
+	"dev-bridge-manager/internal/middleware"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupTimeEntryRoutes(api fiber.Router) {
+	timeEntryHandler := handlers.NewTimeEntryHandler()
+
+	// Task szintű időbejegyzések
+	taskEntries := api.Group("/projects/:id/tasks/:taskId/time-entries")
+	taskEntries.Use(middleware.JWTMiddleware())
+
+	// GET /api/v1/projects/:id/tasks/:taskId/time-entries - Task időbejegyzései
+	taskEntries.Get("/", timeEntryHandler.GetTaskTimeEntries)
+
+	// POST /api/v1/projects/:id/tasks/:taskId/time-entries - Idő rögzítése a taskra
+	taskEntries.Post("/", timeEntryHandler.CreateTimeEntry)
+
+	// Projekt szintű időbejegyzések
+	entries := api.Group("/projects/:id/time-entries")
+	entries.Use(middleware.JWTMiddleware())
+
+	// GET /api/v1/projects/:id/time-entries - Projekt időbejegyzései (userId, startDate, endDate, taskId szűrők)
+	entries.Get("/", timeEntryHandler.GetProjectTimeEntries)
+
+	// GET /api/v1/projects/:id/time-entries/summary - Összesített és számlázható órák
+	entries.Get("/summary", timeEntryHandler.GetTimeSummary)
+
+	// PUT /api/v1/projects/:id/time-entries/:entryId - Bejegyzés módosítása (szerző vagy manager)
+	entries.Put("/:entryId", timeEntryHandler.UpdateTimeEntry)
+
+	// DELETE /api/v1/projects/:id/time-entries/:entryId - Bejegyzés törlése (szerző vagy manager)
+	entries.Delete("/:entryId", timeEntryHandler.DeleteTimeEntry)
+}
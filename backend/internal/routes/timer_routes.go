@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"dev-bridge-manager/internal/handlers"
+	"dev-bridge-manager/internal/middleware"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupTimerRoutes(api fiber.Router) {
+	timerHandler := handlers.NewTimerHandler()
+
+	// Task timer routes group
+	taskTimer := api.Group("/projects/:id/tasks/:taskId/timer")
+	taskTimer.Use(middleware.JWTMiddleware())
+
+	// POST /api/v1/projects/:id/tasks/:taskId/timer/start - Timer indítása (a futó timer automatikusan leáll)
+	taskTimer.Post("/start", timerHandler.StartTimer)
+
+	// POST /api/v1/projects/:id/tasks/:taskId/timer/stop - Timer leállítása és idő rögzítése
+	taskTimer.Post("/stop", timerHandler.StopTimer)
+
+	// GET /api/v1/projects/:id/timer/active - Saját aktív timer a projektben
+	api.Get("/projects/:id/timer/active", middleware.JWTMiddleware(), timerHandler.GetActiveTimer)
+}
@@ -9,6 +9,8 @@ import (
 func SetupUsersRoutes(api fiber.Router) {
 	usersHandler := handlers.NewUsersHandler()
 	userHandler := handlers.NewUserHandler()
+	mfaHandler := handlers.NewMFAHandler()
+	lockoutHandler := handlers.NewLoginLockoutHandler()
 
 	// PUBLIC ENDPOINTS (middleware nélkül)
 	api.Get("/users", usersHandler.GetUsers)    // GET /api/v1/users (public lista)
@@ -49,6 +51,23 @@ func SetupUsersRoutes(api fiber.Router) {
 		userHandler.DeleteUser,
 	)
 
+	// MFA visszaállítása (elveszett eszköz és helyreállító kódok)
+	protected.Delete("/:id/mfa",
+		middleware.RequirePermission("users.update"),
+		mfaHandler.ResetUserMFA,
+	)
+
+	// Belépési zárolások (túl sok sikertelen kísérlet) és feloldásuk
+	protected.Get("/:id/lockouts",
+		middleware.RequirePermission("users.read"),
+		lockoutHandler.GetUserLockouts,
+	)
+
+	protected.Post("/:id/unlock",
+		middleware.RequirePermission("users.update"),
+		lockoutHandler.UnlockUser,
+	)
+
 	// Admin user részletek (ha kell külön védett verzió)
 	protected.Get("/:id/details",
 		middleware.RequirePermission("users.read"),
@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"dev-bridge-manager/internal/handlers"
+	"dev-bridge-manager/internal/middleware"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupWorkflowRoutes(api fiber.Router) {
+	workflowHandler := handlers.NewWorkflowHandler()
+
+	workflow := api.Group("/projects/:id/workflow")
+	workflow.Use(middleware.JWTMiddleware())
+
+	// GET /api/v1/projects/:id/workflow - Oszlop kategóriák és engedélyezett átmenetek
+	workflow.Get("/", workflowHandler.GetWorkflow)
+
+	// PUT /api/v1/projects/:id/workflow - Workflow bekapcsolása, átmenetek lecserélése (manager)
+	workflow.Put("/", workflowHandler.UpdateWorkflow)
+}
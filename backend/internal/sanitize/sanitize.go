@@ -0,0 +1,242 @@
+// Package sanitize cleans rich text HTML coming from the frontend editor with an
+// allow-list and derives the plain text version used for search and notifications.
+package sanitize
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// allowedElements maps every permitted tag to the attributes it may keep
+var allowedElements = map[string][]string{
+	"p": {}, "br": {}, "hr": {}, "div": {}, "span": {"class"},
+	"strong": {}, "b": {}, "em": {}, "i": {}, "u": {}, "s": {}, "strike": {}, "del": {}, "ins": {}, "mark": {},
+	"sub": {}, "sup": {}, "small": {},
+	"h1": {}, "h2": {}, "h3": {}, "h4": {}, "h5": {}, "h6": {},
+	"ul": {}, "ol": {"start"}, "li": {},
+	"blockquote": {}, "pre": {"class"}, "code": {"class"},
+	"a":     {"href", "title", "target"},
+	"img":   {"src", "alt", "title", "width", "height"},
+	"table": {}, "thead": {}, "tbody": {}, "tfoot": {}, "tr": {},
+	"th": {"colspan", "rowspan"}, "td": {"colspan", "rowspan"},
+}
+
+// droppedElements are removed together with their content.
+// Any other element that is not allowed is unwrapped and only its children are kept.
+var droppedElements = map[string]bool{
+	"script": true, "style": true, "iframe": true, "frame": true, "frameset": true,
+	"object": true, "embed": true, "applet": true, "noscript": true, "template": true,
+	"svg": true, "math": true, "head": true, "title": true, "base": true, "link": true,
+	"meta": true, "textarea": true, "select": true, "option": true, "button": true,
+}
+
+// blockElements start a new line in the plain text version
+var blockElements = map[string]bool{
+	"p": true, "div": true, "br": true, "hr": true, "li": true, "tr": true, "blockquote": true, "pre": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true, "ul": true, "ol": true, "table": true,
+}
+
+var voidElements = map[string]bool{"br": true, "hr": true, "img": true}
+
+var allowedSchemes = map[string]bool{"http": true, "https": true, "mailto": true}
+
+var (
+	classPattern  = regexp.MustCompile(`^[A-Za-z0-9_\- ]{1,100}$`)
+	numberPattern = regexp.MustCompile(`^[0-9]{1,4}$`)
+	spacePattern  = regexp.MustCompile(`[ \t\f\r\v]+`)
+	blankLines    = regexp.MustCompile(`\n{2,}`)
+)
+
+// HTML returns the input with every element, attribute and URL outside the
+// allow-list removed. Scripts, event handlers and javascript: links never survive.
+func HTML(input string) string {
+	nodes, err := parse(input)
+	if err != nil {
+		return html.EscapeString(input)
+	}
+
+	var b strings.Builder
+	for _, n := range nodes {
+		writeNode(&b, n)
+	}
+	return b.String()
+}
+
+// PlainText returns the visible text of an HTML fragment with block elements on
+// separate lines. Plain input without markup is returned trimmed.
+func PlainText(input string) string {
+	nodes, err := parse(input)
+	if err != nil {
+		return strings.TrimSpace(input)
+	}
+
+	var b strings.Builder
+	for _, n := range nodes {
+		writeText(&b, n)
+	}
+
+	lines := strings.Split(b.String(), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(spacePattern.ReplaceAllString(line, " "))
+	}
+	text := strings.Join(lines, "\n")
+	return strings.TrimSpace(blankLines.ReplaceAllString(text, "\n"))
+}
+
+// RichText sanitises the HTML and derives its plain text. When no HTML is given
+// the plain text is still stripped of any markup.
+func RichText(htmlContent, plain string) (cleanHTML, text string) {
+	if strings.TrimSpace(htmlContent) == "" {
+		return "", PlainText(plain)
+	}
+	cleanHTML = HTML(htmlContent)
+	return cleanHTML, PlainText(cleanHTML)
+}
+
+func parse(input string) ([]*html.Node, error) {
+	return html.ParseFragment(strings.NewReader(input), &html.Node{
+		Type:     html.ElementNode,
+		Data:     "body",
+		DataAtom: atom.Body,
+	})
+}
+
+func writeNode(b *strings.Builder, n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		b.WriteString(html.EscapeString(n.Data))
+		return
+	case html.ElementNode:
+	default:
+		// Kommentek, doctype - kihagyva
+		return
+	}
+
+	tag := strings.ToLower(n.Data)
+	if droppedElements[tag] {
+		return
+	}
+
+	attrs, allowed := allowedElements[tag]
+	if !allowed {
+		writeChildren(b, n)
+		return
+	}
+
+	b.WriteString("<" + tag)
+	for _, attr := range n.Attr {
+		if value, ok := cleanAttribute(tag, attr, attrs); ok {
+			b.WriteString(" " + attr.Key + `="` + html.EscapeString(value) + `"`)
+		}
+	}
+	if tag == "a" {
+		b.WriteString(` rel="noopener noreferrer nofollow"`)
+	}
+	b.WriteString(">")
+
+	if voidElements[tag] {
+		return
+	}
+	writeChildren(b, n)
+	b.WriteString("</" + tag + ">")
+}
+
+func writeChildren(b *strings.Builder, n *html.Node) {
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		writeNode(b, child)
+	}
+}
+
+// cleanAttribute validates a single attribute against the element's allow-list
+func cleanAttribute(tag string, attr html.Attribute, allowed []string) (string, bool) {
+	if attr.Namespace != "" {
+		return "", false
+	}
+	key := strings.ToLower(attr.Key)
+	permitted := false
+	for _, name := range allowed {
+		if name == key {
+			permitted = true
+			break
+		}
+	}
+	if !permitted {
+		return "", false
+	}
+
+	value := strings.TrimSpace(attr.Val)
+	switch key {
+	case "href", "src":
+		return cleanURL(value, tag == "img")
+	case "target":
+		return "_blank", value == "_blank"
+	case "class":
+		return value, classPattern.MatchString(value)
+	case "start", "colspan", "rowspan", "width", "height":
+		return value, numberPattern.MatchString(value)
+	}
+	return value, true
+}
+
+// cleanURL accepts relative URLs and http(s)/mailto links. Images may only use http(s).
+func cleanURL(value string, image bool) (string, bool) {
+	// A böngészők figyelmen kívül hagyják a vezérlő- és szóköz karaktereket (pl. "java\tscript:")
+	stripped := strings.Map(func(r rune) rune {
+		if r <= ' ' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, value)
+	if stripped == "" {
+		return "", false
+	}
+
+	parsed, err := url.Parse(stripped)
+	if err != nil {
+		return "", false
+	}
+	if parsed.Scheme == "" {
+		// Relatív URL: kettőspont csak az első /, ? vagy # után állhat
+		if i := strings.IndexAny(stripped, ":/?#"); i >= 0 && stripped[i] == ':' {
+			return "", false
+		}
+		return stripped, true
+	}
+
+	scheme := strings.ToLower(parsed.Scheme)
+	if !allowedSchemes[scheme] || (image && scheme == "mailto") {
+		return "", false
+	}
+	return stripped, true
+}
+
+func writeText(b *strings.Builder, n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		b.WriteString(n.Data)
+		return
+	case html.ElementNode:
+	default:
+		return
+	}
+
+	tag := strings.ToLower(n.Data)
+	if droppedElements[tag] {
+		return
+	}
+
+	block := blockElements[tag]
+	if block {
+		b.WriteString("\n")
+	}
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		writeText(b, child)
+	}
+	if block {
+		b.WriteString("\n")
+	}
+}
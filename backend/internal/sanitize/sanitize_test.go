@@ -0,0 +1,153 @@
+package sanitize
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHTMLStripsScripts(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"script element", `<p>hi</p><script>alert(1)</script>`, `<p>hi</p>`},
+		{"script in allowed element", `<p>a<script>alert(1)</script>b</p>`, `<p>ab</p>`},
+		{"uppercase script", `<SCRIPT>alert(1)</SCRIPT>ok`, `ok`},
+		{"style", `<style>body{display:none}</style><em>x</em>`, `<em>x</em>`},
+		{"iframe", `<iframe src="https://evil.example"></iframe>text`, `text`},
+		{"svg with script", `<svg><script>alert(1)</script></svg>x`, `x`},
+		{"object and embed", `<object data="x.swf"></object><embed src="x.swf">y`, `y`},
+		{"escaped text stays text", `&lt;script&gt;alert(1)&lt;/script&gt;`, `&lt;script&gt;alert(1)&lt;/script&gt;`},
+		{"comment", `<!-- <script>alert(1)</script> -->x`, `x`},
+	}
+
+	for _, tt := range tests {
+		if got := HTML(tt.in); got != tt.want {
+			t.Errorf("%s: HTML(%q) = %q, want %q", tt.name, tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestHTMLStripsEventHandlers(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"onclick", `<p onclick="alert(1)">x</p>`, `<p>x</p>`},
+		{"onerror on image", `<img src="https://example.com/a.png" onerror="alert(1)">`, `<img src="https://example.com/a.png">`},
+		{"mixed case handler", `<strong OnMouseOver="alert(1)">x</strong>`, `<strong>x</strong>`},
+		{"style attribute", `<span style="background:url(javascript:alert(1))">x</span>`, `<span>x</span>`},
+		{"unknown element is unwrapped", `<font color="red" onclick="alert(1)">x</font>`, `x`},
+		{"form controls", `<form action="https://evil.example"><input value="x"><button>go</button></form>y`, `y`},
+	}
+
+	for _, tt := range tests {
+		if got := HTML(tt.in); got != tt.want {
+			t.Errorf("%s: HTML(%q) = %q, want %q", tt.name, tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestHTMLStripsDangerousURLs(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"javascript link", `<a href="javascript:alert(1)">x</a>`, `<a rel="noopener noreferrer nofollow">x</a>`},
+		{"uppercase scheme", `<a href="JaVaScRiPt:alert(1)">x</a>`, `<a rel="noopener noreferrer nofollow">x</a>`},
+		{"tab in scheme", "<a href=\"java\tscript:alert(1)\">x</a>", `<a rel="noopener noreferrer nofollow">x</a>`},
+		{"entity encoded scheme", `<a href="&#106;avascript:alert(1)">x</a>`, `<a rel="noopener noreferrer nofollow">x</a>`},
+		{"leading spaces", `<a href="  javascript:alert(1)">x</a>`, `<a rel="noopener noreferrer nofollow">x</a>`},
+		{"vbscript", `<a href="vbscript:msgbox(1)">x</a>`, `<a rel="noopener noreferrer nofollow">x</a>`},
+		{"data image", `<img src="data:image/svg+xml;base64,PHN2Zz4=">`, `<img>`},
+		{"mailto image", `<img src="mailto:a@example.com">`, `<img>`},
+		{"javascript image", `<img src="javascript:alert(1)">`, `<img>`},
+	}
+
+	for _, tt := range tests {
+		if got := HTML(tt.in); got != tt.want {
+			t.Errorf("%s: HTML(%q) = %q, want %q", tt.name, tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestHTMLKeepsAllowedMarkup(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"formatting", `<p><strong>bold</strong> <em>it</em> <u>u</u> <s>s</s></p>`, `<p><strong>bold</strong> <em>it</em> <u>u</u> <s>s</s></p>`},
+		{"headings and lists", `<h2>T</h2><ol start="3"><li>a</li></ol><ul><li>b</li></ul>`, `<h2>T</h2><ol start="3"><li>a</li></ol><ul><li>b</li></ul>`},
+		{"https link", `<a href="https://example.com/a?b=1&amp;c=2" title="t" target="_blank">x</a>`, `<a href="https://example.com/a?b=1&amp;c=2" title="t" target="_blank" rel="noopener noreferrer nofollow">x</a>`},
+		{"mailto link", `<a href="mailto:a@example.com">mail</a>`, `<a href="mailto:a@example.com" rel="noopener noreferrer nofollow">mail</a>`},
+		{"relative link", `<a href="/projects/1">p</a>`, `<a href="/projects/1" rel="noopener noreferrer nofollow">p</a>`},
+		{"other target dropped", `<a href="/x" target="_top">x</a>`, `<a href="/x" rel="noopener noreferrer nofollow">x</a>`},
+		{"image", `<img src="https://example.com/a.png" alt="a" width="10" height="20">`, `<img src="https://example.com/a.png" alt="a" width="10" height="20">`},
+		{"invalid size dropped", `<img src="/a.png" width="100%">`, `<img src="/a.png">`},
+		{"code block class", `<pre class="language-go"><code class="language-go">x := 1</code></pre>`, `<pre class="language-go"><code class="language-go">x := 1</code></pre>`},
+		{"table", `<table><tbody><tr><td colspan="2">c</td></tr></tbody></table>`, `<table><tbody><tr><td colspan="2">c</td></tr></tbody></table>`},
+		{"line break", `a<br>b`, `a<br>b`},
+	}
+
+	for _, tt := range tests {
+		if got := HTML(tt.in); got != tt.want {
+			t.Errorf("%s: HTML(%q) = %q, want %q", tt.name, tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestPlainText(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"plain input", "  just text  ", "just text"},
+		{"blocks on separate lines", `<p>first</p><p>second</p>`, "first\nsecond"},
+		{"list items", `<ul><li>a</li><li>b</li></ul>`, "a\nb"},
+		{"inline elements", `<p>a <strong>b</strong>  c</p>`, "a b c"},
+		{"script content dropped", `<p>x</p><script>alert(1)</script>`, "x"},
+		{"entities decoded", `<p>a &amp; b &lt;c&gt;</p>`, "a & b <c>"},
+	}
+
+	for _, tt := range tests {
+		if got := PlainText(tt.in); got != tt.want {
+			t.Errorf("%s: PlainText(%q) = %q, want %q", tt.name, tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestRichText(t *testing.T) {
+	cleanHTML, text := RichText(`<p onclick="x()">Hello <b>world</b></p><script>bad()</script>`, "ignored")
+	if cleanHTML != `<p>Hello <b>world</b></p>` || text != "Hello world" {
+		t.Errorf("RichText = %q, %q", cleanHTML, text)
+	}
+
+	// HTML nélkül a plain text is tisztításon megy át
+	cleanHTML, text = RichText("  ", "<b>plain</b> text")
+	if cleanHTML != "" || text != "plain text" {
+		t.Errorf("RichText without HTML = %q, %q", cleanHTML, text)
+	}
+}
+
+func TestHTMLOutputIsStable(t *testing.T) {
+	// A már tisztított HTML újbóli tisztítása nem változtat rajta
+	inputs := []string{
+		`<p><a href="https://example.com" onclick="x()">l</a><img src="javascript:x"></p>`,
+		`<table><tr><td>x</td></tr></table>`,
+		`<div><span class="a b">t</span><script>x</script></div>`,
+	}
+	for _, in := range inputs {
+		once := HTML(in)
+		if twice := HTML(once); twice != once {
+			t.Errorf("HTML is not idempotent for %q: %q then %q", in, once, twice)
+		}
+		if strings.Contains(strings.ToLower(once), "script") || strings.Contains(strings.ToLower(once), "onclick") {
+			t.Errorf("HTML(%q) = %q still contains script", in, once)
+		}
+	}
+}
@@ -0,0 +1,230 @@
+// Package schedule parses the cron expressions of recurring task templates
+// and computes their next run times.
+package schedule
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	// A sablonok IANA időzónái tzdata nélküli konténerben is betölthetők legyenek
+	_ "time/tzdata"
+)
+
+// ErrInvalidSchedule is returned for expressions that are not valid cron schedules
+var ErrInvalidSchedule = errors.New("invalid schedule")
+
+// searchYears limits how far Next looks ahead (e.g. "0 0 30 2 *" never matches)
+const searchYears = 5
+
+// macros are the supported shorthand schedules
+var macros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+type field struct {
+	name  string
+	min   int
+	max   int
+	names map[string]int
+}
+
+var (
+	minuteField = field{name: "minute", min: 0, max: 59}
+	hourField   = field{name: "hour", min: 0, max: 23}
+	domField    = field{name: "day of month", min: 1, max: 31}
+	monthField  = field{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// A hét napja 0-7, a 0 és a 7 is vasárnap
+	dowField = field{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// Schedule is a parsed five-field cron expression: minute hour day-of-month month day-of-week.
+// Each field is a bit set of the matching values.
+type Schedule struct {
+	minute, hour, dom, month, dow uint64
+	// Ha mindkét nap mező korlátozott, elég az egyiknek teljesülnie (standard cron viselkedés)
+	domRestricted, dowRestricted bool
+}
+
+// Parse parses a five-field cron expression or one of the @hourly, @daily, @weekly,
+// @monthly, @yearly macros. Fields accept *, numbers, names (jan, mon), ranges (1-5),
+// lists (1,15) and steps (*/15, 9-17/2).
+func Parse(expr string) (*Schedule, error) {
+	expr = strings.TrimSpace(strings.ToLower(expr))
+	if macro, ok := macros[expr]; ok {
+		expr = macro
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("%w: expected 5 fields (minute hour day-of-month month day-of-week), got %d", ErrInvalidSchedule, len(fields))
+	}
+
+	var s Schedule
+	var err error
+	if s.minute, _, err = parseField(fields[0], minuteField); err != nil {
+		return nil, err
+	}
+	if s.hour, _, err = parseField(fields[1], hourField); err != nil {
+		return nil, err
+	}
+	if s.dom, s.domRestricted, err = parseField(fields[2], domField); err != nil {
+		return nil, err
+	}
+	if s.month, _, err = parseField(fields[3], monthField); err != nil {
+		return nil, err
+	}
+	if s.dow, s.dowRestricted, err = parseField(fields[4], dowField); err != nil {
+		return nil, err
+	}
+
+	// A 7 is vasárnapot jelent
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	return &s, nil
+}
+
+// parseField parses a comma separated cron field into a bit set; restricted is false for "*"
+func parseField(value string, f field) (uint64, bool, error) {
+	var bits uint64
+	restricted := value != "*"
+
+	for _, part := range strings.Split(value, ",") {
+		rangePart, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			rangePart = part[:i]
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n <= 0 {
+				return 0, false, fmt.Errorf("%w: invalid step %q in %s field", ErrInvalidSchedule, part[i+1:], f.name)
+			}
+			step = n
+		}
+
+		start, end := f.min, f.max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if start, err = f.value(bounds[0]); err != nil {
+				return 0, false, err
+			}
+			if end, err = f.value(bounds[1]); err != nil {
+				return 0, false, err
+			}
+			if start > end {
+				return 0, false, fmt.Errorf("%w: range %q is reversed in %s field", ErrInvalidSchedule, rangePart, f.name)
+			}
+		default:
+			var err error
+			if start, err = f.value(rangePart); err != nil {
+				return 0, false, err
+			}
+			// "5/15": 5-től a mező végéig, egyébként egyetlen érték
+			if step == 1 {
+				end = start
+			}
+		}
+
+		for v := start; v <= end; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, restricted, nil
+}
+
+// value parses a single number or name of the field
+func (f field) value(raw string) (int, error) {
+	if n, ok := f.names[raw]; ok {
+		return n, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid value %q in %s field", ErrInvalidSchedule, raw, f.name)
+	}
+	if n < f.min || n > f.max {
+		return 0, fmt.Errorf("%w: %s must be between %d and %d", ErrInvalidSchedule, f.name, f.min, f.max)
+	}
+	return n, nil
+}
+
+// dayMatches checks the day-of-month and day-of-week fields for the given day
+func (s *Schedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domRestricted && s.dowRestricted {
+		return domMatch || dowMatch
+	}
+	return domMatch && dowMatch
+}
+
+// matches reports whether the schedule runs in the minute of t
+func (s *Schedule) matches(t time.Time) bool {
+	return s.month&(1<<uint(t.Month())) != 0 && s.dayMatches(t) &&
+		s.hour&(1<<uint(t.Hour())) != 0 && s.minute&(1<<uint(t.Minute())) != 0
+}
+
+// Next returns the first matching minute strictly after the given time, in its location.
+// It returns the zero time when nothing matches within the next five years.
+// Runs whose local time is skipped by a spring-forward change happen right after the
+// change; in the repeated hour of a fall-back change a fixed time only runs once.
+func (s *Schedule) Next(after time.Time) time.Time {
+	loc := after.Location()
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(searchYears, 0, 0)
+
+	for t.Before(limit) {
+		var next time.Time
+		switch {
+		case s.month&(1<<uint(t.Month())) == 0:
+			next = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !s.dayMatches(t):
+			next = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			next = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			next = t.Add(time.Minute)
+		default:
+			return t
+		}
+
+		if s.matchesSkipped(t, next) {
+			return next
+		}
+		t = next
+	}
+	return time.Time{}
+}
+
+// matchesSkipped reports whether a run falls into local times that a spring-forward change
+// skipped between from and to
+func (s *Schedule) matchesSkipped(from, to time.Time) bool {
+	wallTo := wallClock(to)
+	skipped := wallTo.Sub(wallClock(from)) - to.Sub(from)
+	for w := wallTo.Add(-skipped); w.Before(wallTo); w = w.Add(time.Minute) {
+		if s.matches(w) {
+			return true
+		}
+	}
+	return false
+}
+
+// wallClock returns the local date and time of t as if it were UTC, so differences between
+// two wall clocks include the hour skipped by a daylight saving change
+func wallClock(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, time.UTC)
+}
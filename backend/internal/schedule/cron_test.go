@@ -0,0 +1,311 @@
+package schedule
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func mustLoad(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("LoadLocation(%s): %v", name, err)
+	}
+	return loc
+}
+
+func TestParseRejectsInvalidExpressions(t *testing.T) {
+	tests := []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"-1 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * 32 * *",
+		"* * * 0 *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"*/-5 * * * *",
+		"*/x * * * *",
+		"1,,2 * * * *",
+		"* * * foo *",
+		"* * * * funday",
+		"@every 5m",
+	}
+
+	for _, expr := range tests {
+		if _, err := Parse(expr); !errors.Is(err, ErrInvalidSchedule) {
+			t.Errorf("Parse(%q): err = %v, want ErrInvalidSchedule", expr, err)
+		}
+	}
+}
+
+func TestParseFields(t *testing.T) {
+	bits := func(values ...int) uint64 {
+		var b uint64
+		for _, v := range values {
+			b |= 1 << uint(v)
+		}
+		return b
+	}
+
+	tests := []struct {
+		name  string
+		value string
+		field field
+		want  uint64
+	}{
+		{"minimum", "0", minuteField, bits(0)},
+		{"maximum", "59", minuteField, bits(59)},
+		{"range", "9-12", hourField, bits(9, 10, 11, 12)},
+		{"list", "1,15,31", domField, bits(1, 15, 31)},
+		{"step over all", "*/15", minuteField, bits(0, 15, 30, 45)},
+		{"step over range", "9-17/4", hourField, bits(9, 13, 17)},
+		{"step from start", "5/20", minuteField, bits(5, 25, 45)},
+		{"list of ranges and steps", "1-3,10-20/5,30", minuteField, bits(1, 2, 3, 10, 15, 20, 30)},
+		{"month names", "jan,jun-aug", monthField, bits(1, 6, 7, 8)},
+		{"day names", "mon-fri", dowField, bits(1, 2, 3, 4, 5)},
+		{"day of month starts at 1", "*", domField, bits(1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31)},
+	}
+
+	for _, tt := range tests {
+		got, _, err := parseField(tt.value, tt.field)
+		if err != nil {
+			t.Errorf("%s: parseField(%q): %v", tt.name, tt.value, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s: parseField(%q) = %b, want %b", tt.name, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestParseSundayAsSeven(t *testing.T) {
+	seven, err := Parse("0 0 * * 7")
+	if err != nil {
+		t.Fatal(err)
+	}
+	zero, _ := Parse("0 0 * * 0")
+	if seven.dow&1 == 0 || zero.dow&1 == 0 {
+		t.Errorf("7 and 0 must both mean Sunday: %b %b", seven.dow, zero.dow)
+	}
+}
+
+func TestNext(t *testing.T) {
+	utc := time.UTC
+	// 2026-01-01 csütörtök
+	start := time.Date(2026, 1, 1, 10, 7, 30, 0, utc)
+
+	tests := []struct {
+		name  string
+		expr  string
+		after time.Time
+		want  time.Time
+	}{
+		{"every minute", "* * * * *", start, time.Date(2026, 1, 1, 10, 8, 0, 0, utc)},
+		{"strictly after a match", "8 10 * * *", time.Date(2026, 1, 1, 10, 8, 0, 0, utc), time.Date(2026, 1, 2, 10, 8, 0, 0, utc)},
+		{"step", "*/15 * * * *", start, time.Date(2026, 1, 1, 10, 15, 0, 0, utc)},
+		{"next hour", "5 * * * *", start, time.Date(2026, 1, 1, 11, 5, 0, 0, utc)},
+		{"list", "0 9,17 * * *", start, time.Date(2026, 1, 1, 17, 0, 0, 0, utc)},
+		{"weekdays from thursday", "0 9 * * mon-fri", start, time.Date(2026, 1, 2, 9, 0, 0, 0, utc)},
+		{"weekend", "0 9 * * sat,sun", start, time.Date(2026, 1, 3, 9, 0, 0, 0, utc)},
+		{"month end skips short months", "0 0 31 * *", time.Date(2026, 2, 1, 0, 0, 0, 0, utc), time.Date(2026, 3, 31, 0, 0, 0, 0, utc)},
+		{"leap day", "0 0 29 2 *", start, time.Date(2028, 2, 29, 0, 0, 0, 0, utc)},
+		{"year rollover", "0 0 1 1 *", start, time.Date(2027, 1, 1, 0, 0, 0, 0, utc)},
+		{"macro", "@weekly", start, time.Date(2026, 1, 4, 0, 0, 0, 0, utc)},
+		{"names are case insensitive", "0 12 * JAN THU", start, time.Date(2026, 1, 1, 12, 0, 0, 0, utc)},
+	}
+
+	for _, tt := range tests {
+		s, err := Parse(tt.expr)
+		if err != nil {
+			t.Fatalf("%s: Parse(%q): %v", tt.name, tt.expr, err)
+		}
+		if got := s.Next(tt.after); !got.Equal(tt.want) {
+			t.Errorf("%s: Next(%s) for %q = %s, want %s", tt.name, tt.after, tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestNextDayOfMonthOrDayOfWeek(t *testing.T) {
+	utc := time.UTC
+
+	tests := []struct {
+		name string
+		expr string
+		want []time.Time
+	}{
+		{
+			// Mindkét nap mező korlátozott: bármelyik teljesülése elég
+			name: "both restricted",
+			expr: "0 0 13 * fri",
+			want: []time.Time{
+				time.Date(2026, 1, 2, 0, 0, 0, 0, utc),  // péntek
+				time.Date(2026, 1, 9, 0, 0, 0, 0, utc),  // péntek
+				time.Date(2026, 1, 13, 0, 0, 0, 0, utc), // 13-a, kedd
+				time.Date(2026, 1, 16, 0, 0, 0, 0, utc), // péntek
+			},
+		},
+		{
+			name: "only day of month restricted",
+			expr: "0 0 13 * *",
+			want: []time.Time{
+				time.Date(2026, 1, 13, 0, 0, 0, 0, utc),
+				time.Date(2026, 2, 13, 0, 0, 0, 0, utc),
+			},
+		},
+		{
+			name: "only day of week restricted",
+			expr: "0 0 * * fri",
+			want: []time.Time{
+				time.Date(2026, 1, 2, 0, 0, 0, 0, utc),
+				time.Date(2026, 1, 9, 0, 0, 0, 0, utc),
+			},
+		},
+		{
+			// A "*/1" korlátozásnak számít, így a pénteki napok mellett minden nap illeszkedik
+			name: "step counts as restricted",
+			expr: "0 0 */1 * fri",
+			want: []time.Time{
+				time.Date(2026, 1, 2, 0, 0, 0, 0, utc),
+				time.Date(2026, 1, 3, 0, 0, 0, 0, utc),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s, err := Parse(tt.expr)
+		if err != nil {
+			t.Fatalf("%s: Parse(%q): %v", tt.name, tt.expr, err)
+		}
+		cur := time.Date(2026, 1, 1, 0, 0, 0, 0, utc)
+		for i, want := range tt.want {
+			cur = s.Next(cur)
+			if !cur.Equal(want) {
+				t.Errorf("%s: run %d of %q = %s, want %s", tt.name, i+1, tt.expr, cur, want)
+				break
+			}
+		}
+	}
+}
+
+func TestNextNeverMatches(t *testing.T) {
+	s, err := Parse("0 0 30 2 *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := s.Next(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)); !got.IsZero() {
+		t.Errorf("Next = %s, want zero time", got)
+	}
+}
+
+func TestNextSpringForward(t *testing.T) {
+	// Budapest: 2026-03-29 02:00 CET -> 03:00 CEST, a 02:xx helyi idő kimarad
+	loc := mustLoad(t, "Europe/Budapest")
+	before := time.Date(2026, 3, 29, 0, 30, 0, 0, loc)
+
+	tests := []struct {
+		name string
+		expr string
+		want []time.Time
+	}{
+		{
+			// A kimaradt időpont futása az átállás után azonnal megtörténik
+			name: "daily run in the skipped hour",
+			expr: "30 2 * * *",
+			want: []time.Time{
+				time.Date(2026, 3, 29, 3, 0, 0, 0, loc),
+				time.Date(2026, 3, 30, 2, 30, 0, 0, loc),
+			},
+		},
+		{
+			name: "hourly",
+			expr: "0 * * * *",
+			want: []time.Time{
+				time.Date(2026, 3, 29, 1, 0, 0, 0, loc),
+				time.Date(2026, 3, 29, 3, 0, 0, 0, loc),
+				time.Date(2026, 3, 29, 4, 0, 0, 0, loc),
+			},
+		},
+		{
+			name: "run outside the gap is unaffected",
+			expr: "30 4 * * *",
+			want: []time.Time{
+				time.Date(2026, 3, 29, 4, 30, 0, 0, loc),
+				time.Date(2026, 3, 30, 4, 30, 0, 0, loc),
+			},
+		},
+		{
+			name: "other days are unaffected",
+			expr: "30 2 * * mon",
+			want: []time.Time{
+				time.Date(2026, 3, 30, 2, 30, 0, 0, loc),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s, err := Parse(tt.expr)
+		if err != nil {
+			t.Fatalf("%s: Parse(%q): %v", tt.name, tt.expr, err)
+		}
+		cur := before
+		for i, want := range tt.want {
+			cur = s.Next(cur)
+			if !cur.Equal(want) {
+				t.Errorf("%s: run %d of %q = %s, want %s", tt.name, i+1, tt.expr, cur, want)
+				break
+			}
+		}
+	}
+}
+
+func TestNextFallBack(t *testing.T) {
+	// Budapest: 2026-10-25 03:00 CEST -> 02:00 CET, a 02:xx helyi idő kétszer fordul elő
+	loc := mustLoad(t, "Europe/Budapest")
+	dayStart := time.Date(2026, 10, 25, 0, 0, 0, 0, loc)
+	dayEnd := time.Date(2026, 10, 26, 0, 0, 0, 0, loc)
+
+	runs := func(expr string) []time.Time {
+		s, err := Parse(expr)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", expr, err)
+		}
+		var result []time.Time
+		for cur := s.Next(dayStart); cur.Before(dayEnd); cur = s.Next(cur) {
+			result = append(result, cur)
+		}
+		return result
+	}
+
+	// Fix időpont a megismételt órában is csak egyszer fut
+	if got := runs("30 2 * * *"); len(got) != 1 {
+		t.Errorf("daily 02:30 ran %d times on the fall-back day: %v", len(got), got)
+	}
+
+	// Az óránkénti futás valós időben óránként marad: a nap 25 órás
+	hourly := runs("0 * * * *")
+	if len(hourly) != 24 {
+		t.Errorf("hourly ran %d times on the fall-back day, want 24 (00:00 is excluded)", len(hourly))
+	}
+	for i := 1; i < len(hourly); i++ {
+		if gap := hourly[i].Sub(hourly[i-1]); gap != time.Hour {
+			t.Errorf("hourly runs %s and %s are %s apart", hourly[i-1], hourly[i], gap)
+		}
+	}
+
+	// Minden futás időben előre halad
+	for _, expr := range []string{"*/20 * * * *", "30 2 * * *", "0 * * * *"} {
+		got := runs(expr)
+		for i := 1; i < len(got); i++ {
+			if !got[i].After(got[i-1]) {
+				t.Errorf("%q: run %s is not after %s", expr, got[i], got[i-1])
+			}
+		}
+	}
+}
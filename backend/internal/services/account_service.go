@@ -0,0 +1,309 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"dev-bridge-manager/internal/database"
+	"dev-bridge-manager/internal/mailer"
+	"dev-bridge-manager/internal/models"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrInvalidUserToken is returned for unknown, expired or already used reset and verification tokens
+	ErrInvalidUserToken = errors.New("invalid or expired link")
+	// ErrEmailAlreadyVerified is returned when requesting a verification email for a verified address
+	ErrEmailAlreadyVerified = errors.New("email address is already verified")
+	// ErrTokenRecentlySent is returned when an email of the same kind was sent moments ago
+	ErrTokenRecentlySent = errors.New("an email was sent recently, please check your inbox or try again later")
+)
+
+// userTokenResendInterval is the minimum time between two emails of the same kind to a user
+const userTokenResendInterval = time.Minute
+
+// AccountService handles the emailed account flows: password reset and email verification.
+// The tokens are single-use, time-limited and stored only as SHA-256 hashes.
+type AccountService struct {
+	db                  *gorm.DB
+	mailer              mailer.Mailer
+	sessionService      *SessionService
+	loginAttemptService *LoginAttemptService
+	resetTTL            time.Duration
+	verificationTTL     time.Duration
+	frontendURL         string
+}
+
+func NewAccountService() *AccountService {
+	frontendURL := os.Getenv("FRONTEND_URL")
+	if frontendURL == "" {
+		frontendURL = "http://localhost:3000"
+	}
+
+	return &AccountService{
+		db:                  database.GetDB(),
+		mailer:              mailer.Default(),
+		sessionService:      NewSessionService(),
+		loginAttemptService: NewLoginAttemptService(),
+		resetTTL:            time.Duration(envNonNegativeInt("PASSWORD_RESET_TOKEN_MINUTES", 60)) * time.Minute,
+		verificationTTL:     time.Duration(envNonNegativeInt("EMAIL_VERIFICATION_TOKEN_HOURS", 48)) * time.Hour,
+		frontendURL:         strings.TrimRight(frontendURL, "/"),
+	}
+}
+
+// hashUserToken returns the stored form of a reset or verification token
+func hashUserToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// link builds the frontend URL that receives the token
+func (s *AccountService) link(path, token string) string {
+	return s.frontendURL + path + "?token=" + url.QueryEscape(token)
+}
+
+// RequestPasswordReset emails a password reset link in the background when an account uses the
+// address, and returns at once. The account lookup, the token and the delivery all happen after
+// the response, so neither its content nor its timing tells whether an account exists.
+// Failures are logged.
+func (s *AccountService) RequestPasswordReset(email string) {
+	go func() {
+		if err := s.sendPasswordReset(email); err != nil {
+			log.Printf("❌ Password reset request failed: %v", err)
+		}
+	}()
+}
+
+// sendPasswordReset issues a reset token and emails it. Unknown addresses and repeated
+// requests are silently ignored.
+func (s *AccountService) sendPasswordReset(email string) error {
+	var user models.User
+	err := s.db.Where("email = ?", strings.TrimSpace(email)).First(&user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	token, err := s.issueToken(&user, models.UserTokenPasswordReset, s.resetTTL)
+	if errors.Is(err, ErrTokenRecentlySent) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	err = s.mailer.Send(context.Background(), mailer.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hi %s,\n\n"+
+			"We received a request to reset the password of your Dev Bridge Manager account.\n"+
+			"Open the link below to choose a new password. The link expires in %s and can be used once.\n\n"+
+			"%s\n\n"+
+			"If you did not request this, you can ignore this email; your password stays unchanged.\n",
+			user.Name, formatTTL(s.resetTTL), s.link("/reset-password", token)),
+	})
+	if err != nil {
+		return fmt.Errorf("email to user %d: %w", user.ID, err)
+	}
+	return nil
+}
+
+// ResetPassword sets a new password with a reset token. Every session and issued access token
+// of the user is revoked, and the account's failed login counter is cleared. Since the link
+// reached the mailbox, an unverified address counts as verified afterwards.
+func (s *AccountService) ResetPassword(token, newPassword string) (*models.User, error) {
+	hashed := models.User{Password: newPassword}
+	if err := hashed.HashPassword(); err != nil {
+		return nil, err
+	}
+
+	var user models.User
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		userToken, err := s.consumeToken(tx, token, models.UserTokenPasswordReset)
+		if err != nil {
+			return err
+		}
+		if err := tx.First(&user, userToken.UserID).Error; err != nil {
+			return err
+		}
+		if !strings.EqualFold(user.Email, userToken.Email) {
+			return ErrInvalidUserToken
+		}
+
+		updates := map[string]interface{}{"password": hashed.Password}
+		if user.EmailVerifiedAt == nil {
+			updates["email_verified_at"] = time.Now()
+		}
+		if err := tx.Model(&user).Updates(updates).Error; err != nil {
+			return err
+		}
+		// A többi függő visszaállító link is érvényét veszti
+		return tx.Where("user_id = ? AND purpose = ? AND used_at IS NULL", user.ID, models.UserTokenPasswordReset).
+			Delete(&models.UserToken{}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.sessionService.InvalidateUserTokens(user.ID, SessionRevokedPasswordReset); err != nil {
+		return nil, err
+	}
+	if err := s.loginAttemptService.ResetAccount(user.Email); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// SendVerification emails an email verification link to the user's current address
+func (s *AccountService) SendVerification(user *models.User) error {
+	if user.EmailVerified() {
+		return ErrEmailAlreadyVerified
+	}
+
+	token, err := s.issueToken(user, models.UserTokenEmailVerification, s.verificationTTL)
+	if err != nil {
+		return err
+	}
+
+	return s.mailer.Send(context.Background(), mailer.Message{
+		To:      user.Email,
+		Subject: "Confirm your email address",
+		Body: fmt.Sprintf("Hi %s,\n\n"+
+			"Please confirm the email address of your Dev Bridge Manager account by opening the link below.\n"+
+			"Until then your account has limited access. The link expires in %s.\n\n"+
+			"%s\n\n"+
+			"If you did not create an account, you can ignore this email.\n",
+			user.Name, formatTTL(s.verificationTTL), s.link("/verify-email", token)),
+	})
+}
+
+// VerifyEmail marks the address the token was sent to as verified. Tokens sent to an earlier
+// address of the user are rejected.
+func (s *AccountService) VerifyEmail(token string) (*models.User, error) {
+	var user models.User
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		userToken, err := s.consumeToken(tx, token, models.UserTokenEmailVerification)
+		if err != nil {
+			return err
+		}
+		if err := tx.First(&user, userToken.UserID).Error; err != nil {
+			return err
+		}
+		if !strings.EqualFold(user.Email, userToken.Email) {
+			return ErrInvalidUserToken
+		}
+		if user.EmailVerifiedAt != nil {
+			return nil
+		}
+
+		now := time.Now()
+		if err := tx.Model(&user).Update("email_verified_at", now).Error; err != nil {
+			return err
+		}
+		user.EmailVerifiedAt = &now
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// issueToken creates a new token of the purpose for the user's current address and drops the
+// earlier unused ones, so only the newest link works. A new email to the same address is only
+// sent after userTokenResendInterval.
+func (s *AccountService) issueToken(user *models.User, purpose string, ttl time.Duration) (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(buf)
+
+	now := time.Now()
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		// A user sor zárolása: párhuzamos kérésekből is csak egy email megy ki
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&models.User{}, user.ID).Error; err != nil {
+			return err
+		}
+
+		var recent int64
+		err := tx.Model(&models.UserToken{}).
+			Where("user_id = ? AND purpose = ? AND email = ? AND created_at > ?", user.ID, purpose, user.Email, now.Add(-userTokenResendInterval)).
+			Count(&recent).Error
+		if err != nil {
+			return err
+		}
+		if recent > 0 {
+			return ErrTokenRecentlySent
+		}
+
+		if err := tx.Where("user_id = ? AND purpose = ? AND used_at IS NULL", user.ID, purpose).Delete(&models.UserToken{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.UserToken{
+			UserID:    user.ID,
+			Purpose:   purpose,
+			TokenHash: hashUserToken(token),
+			Email:     user.Email,
+			ExpiresAt: now.Add(ttl),
+			CreatedAt: now,
+		}).Error
+	})
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// userTokenUsable checks that a token was not used yet and has not expired at now
+func userTokenUsable(token *models.UserToken, now time.Time) bool {
+	return token.UsedAt == nil && now.Before(token.ExpiresAt)
+}
+
+// consumeToken marks a valid token of the purpose as used and returns it
+func (s *AccountService) consumeToken(tx *gorm.DB, token, purpose string) (*models.UserToken, error) {
+	var userToken models.UserToken
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("token_hash = ? AND purpose = ?", hashUserToken(strings.TrimSpace(token)), purpose).
+		First(&userToken).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrInvalidUserToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if !userTokenUsable(&userToken, now) {
+		return nil, ErrInvalidUserToken
+	}
+	if err := tx.Model(&userToken).Update("used_at", now).Error; err != nil {
+		return nil, err
+	}
+	return &userToken, nil
+}
+
+// formatTTL renders a token lifetime for the email text, e.g. "1 hour" or "90 minutes"
+func formatTTL(ttl time.Duration) string {
+	count, unit := int(ttl/time.Minute), "minute"
+	if ttl >= time.Hour && ttl%time.Hour == 0 {
+		count, unit = int(ttl/time.Hour), "hour"
+	}
+	if count != 1 {
+		unit += "s"
+	}
+	return fmt.Sprintf("%d %s", count, unit)
+}
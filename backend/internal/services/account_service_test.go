@@ -0,0 +1,72 @@
+package services
+
+import (
+	"dev-bridge-manager/internal/models"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestUserTokenUsable(t *testing.T) {
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	used := now.Add(-time.Minute)
+
+	tests := []struct {
+		name  string
+		token models.UserToken
+		want  bool
+	}{
+		{"fresh", models.UserToken{ExpiresAt: now.Add(time.Hour)}, true},
+		{"expires now", models.UserToken{ExpiresAt: now}, false},
+		{"expired", models.UserToken{ExpiresAt: now.Add(-time.Second)}, false},
+		{"used", models.UserToken{ExpiresAt: now.Add(time.Hour), UsedAt: &used}, false},
+	}
+
+	for _, tt := range tests {
+		if got := userTokenUsable(&tt.token, now); got != tt.want {
+			t.Errorf("%s: userTokenUsable = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestHashUserToken(t *testing.T) {
+	hash := hashUserToken("token")
+	if len(hash) != 64 || hash == "token" {
+		t.Errorf("hashUserToken = %q, want a hex SHA-256", hash)
+	}
+	if hashUserToken("token") != hash || hashUserToken("token2") == hash {
+		t.Error("hashUserToken is not a stable, distinct hash")
+	}
+}
+
+func TestAccountLink(t *testing.T) {
+	s := &AccountService{frontendURL: "https://app.example.com"}
+	link := s.link("/reset-password", "a+b/c=")
+
+	parsed, err := url.Parse(link)
+	if err != nil {
+		t.Fatalf("parse %q: %v", link, err)
+	}
+	if parsed.Host != "app.example.com" || parsed.Path != "/reset-password" || parsed.Query().Get("token") != "a+b/c=" {
+		t.Errorf("link = %q", link)
+	}
+}
+
+func TestFormatTTL(t *testing.T) {
+	tests := []struct {
+		ttl  time.Duration
+		want string
+	}{
+		{time.Minute, "1 minute"},
+		{30 * time.Minute, "30 minutes"},
+		{time.Hour, "1 hour"},
+		{90 * time.Minute, "90 minutes"},
+		{48 * time.Hour, "48 hours"},
+	}
+
+	for _, tt := range tests {
+		if got := formatTTL(tt.ttl); got != tt.want {
+			t.Errorf("formatTTL(%s) = %q, want %q", tt.ttl, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,245 @@
+package services
+
+import (
+	"dev-bridge-manager/internal/database"
+	"dev-bridge-manager/internal/models"
+	"math"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AnalyticsDefaultDays is the analytics range when no start date is given
+const AnalyticsDefaultDays = 90
+
+type AnalyticsService struct {
+	db *gorm.DB
+}
+
+func NewAnalyticsService() *AnalyticsService {
+	return &AnalyticsService{
+		db: database.GetDB(),
+	}
+}
+
+// TaskHistory returns a task's column transitions in order
+func (s *AnalyticsService) TaskHistory(taskID uint) ([]models.TaskStatusHistory, error) {
+	var history []models.TaskStatusHistory
+	err := s.db.Where("task_id = ?", taskID).
+		Order("changed_at ASC, id ASC").
+		Find(&history).Error
+	return history, err
+}
+
+// roundHours rounds an hour value to two decimals
+func roundHours(hours float64) float64 {
+	return math.Round(hours*100) / 100
+}
+
+// percentile returns the nearest-rank percentile of sorted values
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// durationStats summarizes durations given in hours
+func durationStats(hours []float64) models.DurationStats {
+	stats := models.DurationStats{Count: len(hours)}
+	if len(hours) == 0 {
+		return stats
+	}
+
+	sort.Float64s(hours)
+	var total float64
+	for _, h := range hours {
+		total += h
+	}
+	stats.AverageHours = roundHours(total / float64(len(hours)))
+	stats.P50Hours = roundHours(percentile(hours, 50))
+	stats.P85Hours = roundHours(percentile(hours, 85))
+	stats.P95Hours = roundHours(percentile(hours, 95))
+	return stats
+}
+
+// weekStart returns the Monday of the day's week
+func weekStart(day time.Time) time.Time {
+	day = SnapshotDay(day)
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+// overlapHours returns how many hours of [start, end) fall into [from, to)
+func overlapHours(start, end, from, to time.Time) float64 {
+	if start.Before(from) {
+		start = from
+	}
+	if end.After(to) {
+		end = to
+	}
+	if !end.After(start) {
+		return 0
+	}
+	return end.Sub(start).Hours()
+}
+
+// ProjectAnalytics computes the flow metrics of the project between from and to (inclusive days)
+// from the task status history. Only tasks whose moves were recorded take part: a task counts as
+// completed when it currently sits in a done column, at the time it last entered one.
+func (s *AnalyticsService) ProjectAnalytics(projectID uint, from, to time.Time) (*models.ProjectAnalytics, error) {
+	from = SnapshotDay(from)
+	to = SnapshotDay(to)
+	rangeEnd := to.AddDate(0, 0, 1)
+	now := time.Now()
+
+	var columns []models.KanbanColumn
+	err := s.db.Joins("JOIN kanban_boards ON kanban_boards.id = kanban_columns.board_id").
+		Where("kanban_boards.project_id = ?", projectID).
+		Order("kanban_columns.position ASC").
+		Find(&columns).Error
+	if err != nil {
+		return nil, err
+	}
+	columnByID := make(map[uint]*models.KanbanColumn, len(columns))
+	for i := range columns {
+		columnByID[columns[i].ID] = &columns[i]
+	}
+
+	var tasks []models.Task
+	err = s.db.Select("id", "column_id", "created_at", "archived_at").
+		Where("project_id = ? AND created_at < ?", projectID, rangeEnd).
+		Find(&tasks).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var history []models.TaskStatusHistory
+	err = s.db.Where("project_id = ? AND changed_at < ?", projectID, rangeEnd).
+		Order("task_id ASC, changed_at ASC, id ASC").
+		Find(&history).Error
+	if err != nil {
+		return nil, err
+	}
+	historyByTask := make(map[uint][]models.TaskStatusHistory)
+	for _, entry := range history {
+		historyByTask[entry.TaskID] = append(historyByTask[entry.TaskID], entry)
+	}
+
+	var cycleTimes, leadTimes []float64
+	completedByWeek := make(map[string]int)
+	columnHours := make(map[uint]float64)
+	columnTasks := make(map[uint]map[uint]bool)
+
+	for i := range tasks {
+		task := &tasks[i]
+		transitions := historyByTask[task.ID]
+		if len(transitions) == 0 {
+			continue
+		}
+
+		// Munka kezdete: az első in_progress/review oszlopba lépés, befejezés: az utolsó done-ba lépés
+		var startedAt, completedAt *time.Time
+		for j := range transitions {
+			entry := &transitions[j]
+			switch entry.ToCategory {
+			case "in_progress", "review":
+				if startedAt == nil {
+					startedAt = &entry.ChangedAt
+				}
+			case "done":
+				completedAt = &entry.ChangedAt
+			}
+		}
+		if current, ok := columnByID[task.ColumnID]; !ok || !current.IsDone() {
+			completedAt = nil
+		}
+
+		if completedAt != nil && !completedAt.Before(from) && completedAt.Before(rangeEnd) {
+			leadTimes = append(leadTimes, completedAt.Sub(task.CreatedAt).Hours())
+			if startedAt != nil && !startedAt.After(*completedAt) {
+				cycleTimes = append(cycleTimes, completedAt.Sub(*startedAt).Hours())
+			}
+			completedByWeek[weekStart(*completedAt).Format(models.TimeEntryDateLayout)]++
+		}
+
+		// Oszlop szakaszok: a létrehozás előtti (nem rögzített) állapot a korábbi oszlopban kezdődik
+		segmentEnd := now
+		if task.ArchivedAt != nil {
+			segmentEnd = *task.ArchivedAt
+		}
+		addSegment := func(columnID *uint, start, end time.Time) {
+			if columnID == nil {
+				return
+			}
+			if hours := overlapHours(start, end, from, rangeEnd); hours > 0 {
+				columnHours[*columnID] += hours
+				if columnTasks[*columnID] == nil {
+					columnTasks[*columnID] = make(map[uint]bool)
+				}
+				columnTasks[*columnID][task.ID] = true
+			}
+		}
+		if first := transitions[0]; first.FromColumnID != nil {
+			addSegment(first.FromColumnID, task.CreatedAt, first.ChangedAt)
+		}
+		for j := range transitions {
+			end := segmentEnd
+			if j+1 < len(transitions) {
+				end = transitions[j+1].ChangedAt
+			}
+			addSegment(transitions[j].ToColumnID, transitions[j].ChangedAt, end)
+		}
+	}
+
+	analytics := &models.ProjectAnalytics{
+		From:        from.Format(models.TimeEntryDateLayout),
+		To:          to.Format(models.TimeEntryDateLayout),
+		CycleTime:   durationStats(cycleTimes),
+		LeadTime:    durationStats(leadTimes),
+		Throughput:  []models.ThroughputWeek{},
+		ColumnTimes: make([]models.ColumnTime, 0, len(columns)),
+	}
+
+	for week := weekStart(from); week.Before(rangeEnd); week = week.AddDate(0, 0, 7) {
+		key := week.Format(models.TimeEntryDateLayout)
+		analytics.Throughput = append(analytics.Throughput, models.ThroughputWeek{
+			WeekStart: key,
+			Completed: completedByWeek[key],
+		})
+	}
+
+	for i := range columns {
+		column := &columns[i]
+		item := models.ColumnTime{
+			ColumnID:   column.ID,
+			Title:      column.Title,
+			Category:   column.Category,
+			TaskCount:  len(columnTasks[column.ID]),
+			TotalHours: roundHours(columnHours[column.ID]),
+		}
+		if item.TaskCount > 0 {
+			item.AverageHours = roundHours(columnHours[column.ID] / float64(item.TaskCount))
+		}
+		analytics.ColumnTimes = append(analytics.ColumnTimes, item)
+	}
+
+	// Szűk keresztmetszet: a munkában lévő oszlopok közül a legnagyobb átlagos idő
+	for i := range analytics.ColumnTimes {
+		item := &analytics.ColumnTimes[i]
+		if item.Category != "in_progress" && item.Category != "review" || item.TaskCount == 0 {
+			continue
+		}
+		if analytics.Bottleneck == nil || item.AverageHours > analytics.Bottleneck.AverageHours {
+			bottleneck := *item
+			analytics.Bottleneck = &bottleneck
+		}
+	}
+
+	return analytics, nil
+}
@@ -0,0 +1,83 @@
+package services
+
+import (
+	"dev-bridge-manager/internal/models"
+	"testing"
+	"time"
+)
+
+func TestPercentile(t *testing.T) {
+	values := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+	tests := []struct {
+		p    float64
+		want float64
+	}{
+		{0, 1},
+		{10, 1},
+		{50, 5},
+		{85, 9},
+		{95, 10},
+		{100, 10},
+	}
+
+	for _, tt := range tests {
+		if got := percentile(values, tt.p); got != tt.want {
+			t.Errorf("percentile(%v) = %v, want %v", tt.p, got, tt.want)
+		}
+	}
+	if got := percentile(nil, 50); got != 0 {
+		t.Errorf("percentile of no values = %v, want 0", got)
+	}
+}
+
+func TestDurationStats(t *testing.T) {
+	got := durationStats([]float64{10, 2, 4.005, 1})
+	want := models.DurationStats{Count: 4, AverageHours: 4.25, P50Hours: 2, P85Hours: 10, P95Hours: 10}
+	if got != want {
+		t.Errorf("durationStats = %+v, want %+v", got, want)
+	}
+
+	if got := durationStats(nil); got != (models.DurationStats{}) {
+		t.Errorf("durationStats(nil) = %+v, want zero", got)
+	}
+}
+
+func TestWeekStart(t *testing.T) {
+	monday := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	for offset := 0; offset < 7; offset++ {
+		day := monday.AddDate(0, 0, offset).Add(15 * time.Hour)
+		if got := weekStart(day); !got.Equal(monday) {
+			t.Errorf("weekStart(%s) = %s, want %s", day.Weekday(), got, monday)
+		}
+	}
+	if got := weekStart(monday.AddDate(0, 0, 7)); !got.Equal(monday.AddDate(0, 0, 7)) {
+		t.Errorf("next Monday starts a new week, got %s", got)
+	}
+}
+
+func TestOverlapHours(t *testing.T) {
+	at := func(h int) time.Time { return time.Date(2026, 3, 2, h, 0, 0, 0, time.UTC) }
+	from, to := at(10), at(20)
+
+	tests := []struct {
+		name       string
+		start, end time.Time
+		want       float64
+	}{
+		{"inside", at(12), at(15), 3},
+		{"starts before", at(8), at(12), 2},
+		{"ends after", at(18), at(23), 2},
+		{"covers the range", at(0), at(23), 10},
+		{"before", at(1), at(9), 0},
+		{"after", at(21), at(22), 0},
+		{"touching end", at(20), at(22), 0},
+		{"reversed", at(15), at(12), 0},
+	}
+
+	for _, tt := range tests {
+		if got := overlapHours(tt.start, tt.end, from, to); got != tt.want {
+			t.Errorf("%s: overlapHours = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
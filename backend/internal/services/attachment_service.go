@@ -0,0 +1,266 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"dev-bridge-manager/internal/database"
+	"dev-bridge-manager/internal/models"
+	"dev-bridge-manager/internal/storage"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gabriel-vasile/mimetype"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrAttachmentTooLarge is returned when an upload exceeds the maximum file size
+var ErrAttachmentTooLarge = errors.New("attachment exceeds the maximum file size")
+
+// QuotaError is returned when an upload would exceed the project's storage quota
+type QuotaError struct {
+	UsedBytes  int64
+	QuotaBytes int64
+	Size       int64
+}
+
+func (e *QuotaError) Error() string {
+	return fmt.Sprintf("Project attachment quota exceeded: %d of %d bytes used, upload needs %d", e.UsedBytes, e.QuotaBytes, e.Size)
+}
+
+type AttachmentService struct {
+	db      *gorm.DB
+	storage storage.Storage
+	// MaxSize is the largest accepted file (ATTACHMENT_MAX_SIZE_MB, default 25)
+	MaxSize int64
+	// ProjectQuota is the total size allowed per project (PROJECT_ATTACHMENT_QUOTA_MB, default 1024, 0 = unlimited)
+	ProjectQuota int64
+	// URLTTL is the lifetime of signed download URLs (ATTACHMENT_URL_TTL_MINUTES, default 15)
+	URLTTL time.Duration
+}
+
+func NewAttachmentService() *AttachmentService {
+	return &AttachmentService{
+		db:           database.GetDB(),
+		storage:      storage.Default(),
+		MaxSize:      MaxAttachmentSize(),
+		ProjectQuota: int64(envNonNegativeInt("PROJECT_ATTACHMENT_QUOTA_MB", 1024)) << 20,
+		URLTTL:       time.Duration(envNonNegativeInt("ATTACHMENT_URL_TTL_MINUTES", 15)) * time.Minute,
+	}
+}
+
+// MaxAttachmentSize returns the configured maximum upload size in bytes
+func MaxAttachmentSize() int64 {
+	return int64(envNonNegativeInt("ATTACHMENT_MAX_SIZE_MB", 25)) << 20
+}
+
+func envNonNegativeInt(key string, defaultValue int) int {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := strconv.Atoi(value); err == nil && parsed >= 0 {
+			return parsed
+		}
+	}
+	return defaultValue
+}
+
+// Usage returns the total size of a project's attachments
+func (s *AttachmentService) Usage(tx *gorm.DB, projectID uint) (int64, error) {
+	var used int64
+	err := tx.Model(&models.TaskAttachment{}).
+		Where("project_id = ?", projectID).
+		Select("COALESCE(SUM(size), 0)").
+		Scan(&used).Error
+	return used, err
+}
+
+// checkQuota rejects an upload that would push the project over its quota
+func (s *AttachmentService) checkQuota(tx *gorm.DB, projectID uint, size int64) error {
+	if s.ProjectQuota <= 0 {
+		return nil
+	}
+	used, err := s.Usage(tx, projectID)
+	if err != nil {
+		return err
+	}
+	return quotaExceeded(used, size, s.ProjectQuota)
+}
+
+// quotaExceeded returns a QuotaError when size more bytes do not fit into the quota
+func quotaExceeded(used, size, quota int64) error {
+	if quota > 0 && used+size > quota {
+		return &QuotaError{UsedBytes: used, QuotaBytes: quota, Size: size}
+	}
+	return nil
+}
+
+// Upload sniffs the file's real MIME type, stores it and records the attachment.
+// The quota is re-checked under a project row lock so parallel uploads cannot overshoot it.
+func (s *AttachmentService) Upload(ctx context.Context, projectID, taskID, userID uint, header *multipart.FileHeader) (*models.TaskAttachment, error) {
+	if header.Size > s.MaxSize {
+		return nil, ErrAttachmentTooLarge
+	}
+	if err := s.checkQuota(s.db, projectID, header.Size); err != nil {
+		return nil, err
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	detected, err := detectMIME(file)
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := randomHex(16)
+	if err != nil {
+		return nil, err
+	}
+	filename := name + detected.Extension()
+	attachment := models.TaskAttachment{
+		TaskID:       taskID,
+		ProjectID:    projectID,
+		StorageKey:   fmt.Sprintf("projects/%d/tasks/%d/%s", projectID, taskID, filename),
+		Filename:     filename,
+		OriginalName: cleanOriginalName(header.Filename, filename),
+		MimeType:     detected.String(),
+		Size:         header.Size,
+		UploadedBy:   &userID,
+	}
+
+	if err := s.storage.Put(ctx, attachment.StorageKey, file, header.Size, attachment.MimeType); err != nil {
+		return nil, err
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		var project models.Project
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&project, projectID).Error; err != nil {
+			return err
+		}
+		if err := s.checkQuota(tx, projectID, attachment.Size); err != nil {
+			return err
+		}
+		return tx.Create(&attachment).Error
+	})
+	if err != nil {
+		if deleteErr := s.storage.Delete(ctx, attachment.StorageKey); deleteErr != nil {
+			log.Printf("⚠️  Failed to remove orphaned attachment %s: %v", attachment.StorageKey, deleteErr)
+		}
+		return nil, err
+	}
+
+	return &attachment, nil
+}
+
+// List returns a task's attachments, newest first
+func (s *AttachmentService) List(projectID, taskID uint) ([]models.TaskAttachment, error) {
+	var attachments []models.TaskAttachment
+	err := s.db.Where("project_id = ? AND task_id = ?", projectID, taskID).
+		Order("created_at DESC, id DESC").
+		Find(&attachments).Error
+	return attachments, err
+}
+
+// Load loads a task's attachment
+func (s *AttachmentService) Load(projectID, taskID, attachmentID uint) (*models.TaskAttachment, error) {
+	var attachment models.TaskAttachment
+	err := s.db.Where("id = ? AND project_id = ? AND task_id = ?", attachmentID, projectID, taskID).
+		First(&attachment).Error
+	if err != nil {
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+// Open returns the stored content of an attachment
+func (s *AttachmentService) Open(ctx context.Context, attachment *models.TaskAttachment) (io.ReadCloser, error) {
+	return s.storage.Open(ctx, attachment.StorageKey)
+}
+
+// SignedURL returns an expiring download URL for the attachment
+func (s *AttachmentService) SignedURL(attachment *models.TaskAttachment) (string, time.Time, error) {
+	expiresAt := time.Now().Add(s.URLTTL)
+	url, err := s.storage.SignedURL(attachment.StorageKey, attachment.OriginalName, s.URLTTL)
+	return url, expiresAt, err
+}
+
+// Delete removes the attachment record and its stored file
+func (s *AttachmentService) Delete(ctx context.Context, attachment *models.TaskAttachment) error {
+	if err := s.db.Delete(&models.TaskAttachment{}, attachment.ID).Error; err != nil {
+		return err
+	}
+	removeStoredFiles(ctx, s.storage, []string{attachment.StorageKey})
+	return nil
+}
+
+// attachmentKeys returns the storage keys of the attachments matching the condition.
+// Call it inside the transaction that deletes the rows, before they cascade away.
+func attachmentKeys(tx *gorm.DB, query interface{}, args ...interface{}) ([]string, error) {
+	var keys []string
+	err := tx.Model(&models.TaskAttachment{}).Where(query, args...).Pluck("storage_key", &keys).Error
+	return keys, err
+}
+
+// removeStoredFiles deletes stored files whose attachment rows are already gone.
+// A failure only leaves an orphaned file behind, so it is logged instead of returned.
+func removeStoredFiles(ctx context.Context, store storage.Storage, keys []string) {
+	for _, key := range keys {
+		if err := store.Delete(ctx, key); err != nil {
+			log.Printf("⚠️  Failed to remove attachment file %s: %v", key, err)
+		}
+	}
+}
+
+// cleanOriginalName keeps only the base name without control characters
+func cleanOriginalName(name, fallback string) string {
+	name = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f {
+			return -1
+		}
+		return r
+	}, filepath.Base(strings.ReplaceAll(name, "\\", "/")))
+	name = strings.TrimSpace(name)
+	if name == "" || name == "." || name == "/" {
+		return fallback
+	}
+	if len(name) > 255 {
+		ext := filepath.Ext(name)
+		if len(ext) > 20 {
+			ext = ""
+		}
+		name = strings.ToValidUTF8(name[:255-len(ext)], "") + ext
+	}
+	return name
+}
+
+// detectMIME sniffs the MIME type from the content and rewinds the file
+func detectMIME(file io.ReadSeeker) (*mimetype.MIME, error) {
+	// A MIME típus a tartalomból származik, nem a kliens által küldött Content-Type-ból
+	detected, err := mimetype.DetectReader(file)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	return detected, nil
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
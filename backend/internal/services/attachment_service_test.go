@@ -0,0 +1,159 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"dev-bridge-manager/internal/storage"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func newTestStorage(t *testing.T) *storage.LocalStorage {
+	t.Helper()
+	store, err := storage.NewLocalStorage(t.TempDir(), "http://localhost:8080"+storage.LocalDownloadPath, []byte("test-key"))
+	if err != nil {
+		t.Fatalf("NewLocalStorage: %v", err)
+	}
+	return store
+}
+
+func TestRemoveStoredFilesDeletesBlobs(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStorage(t)
+
+	keys := []string{"projects/1/tasks/1/a.txt", "projects/1/tasks/2/b.txt"}
+	for _, key := range keys {
+		if err := store.Put(ctx, key, strings.NewReader("content"), 7, "text/plain"); err != nil {
+			t.Fatalf("Put %s: %v", key, err)
+		}
+	}
+	kept := "projects/2/tasks/3/c.txt"
+	if err := store.Put(ctx, kept, strings.NewReader("content"), 7, "text/plain"); err != nil {
+		t.Fatalf("Put %s: %v", kept, err)
+	}
+
+	// A hiányzó és az érvénytelen kulcs nem állítja meg a többi törlését
+	removeStoredFiles(ctx, store, append([]string{"projects/1/tasks/9/missing.txt", "../escape"}, keys...))
+
+	for _, key := range keys {
+		if _, err := store.Open(ctx, key); !errors.Is(err, storage.ErrNotFound) {
+			t.Errorf("Open(%s) after removal: err = %v, want ErrNotFound", key, err)
+		}
+	}
+	file, err := store.Open(ctx, kept)
+	if err != nil {
+		t.Fatalf("unrelated file was removed: %v", err)
+	}
+	file.Close()
+}
+
+func TestCleanOriginalName(t *testing.T) {
+	long := strings.Repeat("a", 300)
+
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"plain", "report.pdf", "report.pdf"},
+		{"unix path", "/home/user/report.pdf", "report.pdf"},
+		{"windows path", `C:\Users\me\report.pdf`, "report.pdf"},
+		{"traversal", "../../etc/passwd", "passwd"},
+		{"control characters", "re\r\nport\x00.pdf", "report.pdf"},
+		{"surrounding spaces", "  report.pdf  ", "report.pdf"},
+		{"empty", "", "fallback.bin"},
+		{"trailing slash", "docs/", "docs"},
+		{"root", "/", "fallback.bin"},
+		{"dot", ".", "fallback.bin"},
+		{"long name keeps extension", long + ".pdf", long[:251] + ".pdf"},
+		{"long extension is dropped", "a." + long, ("a." + long)[:255]},
+	}
+
+	for _, tt := range tests {
+		if got := cleanOriginalName(tt.in, "fallback.bin"); got != tt.want {
+			t.Errorf("%s: cleanOriginalName(%q) = %q, want %q", tt.name, tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestCleanOriginalNameKeepsValidUTF8(t *testing.T) {
+	// A 255 bájtos vágás nem hagyhat félbevágott UTF-8 karaktert
+	got := cleanOriginalName(strings.Repeat("é", 200)+".txt", "fallback.bin")
+	if len(got) > 255 || !strings.HasSuffix(got, ".txt") || !utf8.ValidString(got) {
+		t.Errorf("cleanOriginalName produced %q (%d bytes)", got, len(got))
+	}
+}
+
+func TestDetectMIMEUsesContent(t *testing.T) {
+	png := append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, 32)...)
+
+	tests := []struct {
+		name     string
+		content  []byte
+		wantMIME string
+		wantExt  string
+	}{
+		{"png", png, "image/png", ".png"},
+		{"pdf", []byte("%PDF-1.7\n%\xe2\xe3\xcf\xd3\n"), "application/pdf", ".pdf"},
+		{"html is not trusted as text", []byte("<html><script>alert(1)</script></html>"), "text/html; charset=utf-8", ".html"},
+		{"plain text", []byte("hello world"), "text/plain; charset=utf-8", ".txt"},
+	}
+
+	for _, tt := range tests {
+		file := bytes.NewReader(tt.content)
+		detected, err := detectMIME(file)
+		if err != nil {
+			t.Fatalf("%s: detectMIME: %v", tt.name, err)
+		}
+		if detected.String() != tt.wantMIME || detected.Extension() != tt.wantExt {
+			t.Errorf("%s: detected %s %s, want %s %s", tt.name, detected, detected.Extension(), tt.wantMIME, tt.wantExt)
+		}
+
+		// A tárolás a fájl elejéről indul
+		rest, _ := io.ReadAll(file)
+		if !bytes.Equal(rest, tt.content) {
+			t.Errorf("%s: file was not rewound after sniffing", tt.name)
+		}
+	}
+}
+
+func TestQuotaExceeded(t *testing.T) {
+	tests := []struct {
+		name       string
+		used, size int64
+		quota      int64
+		wantErr    bool
+	}{
+		{"fits", 10, 20, 100, false},
+		{"fills exactly", 80, 20, 100, false},
+		{"one byte over", 80, 21, 100, true},
+		{"already over", 150, 1, 100, true},
+		{"unlimited", 1 << 40, 1 << 40, 0, false},
+	}
+
+	for _, tt := range tests {
+		err := quotaExceeded(tt.used, tt.size, tt.quota)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: quotaExceeded(%d, %d, %d) = %v, wantErr %v", tt.name, tt.used, tt.size, tt.quota, err, tt.wantErr)
+			continue
+		}
+		var quotaErr *QuotaError
+		if err != nil && (!errors.As(err, &quotaErr) || quotaErr.UsedBytes != tt.used || quotaErr.Size != tt.size) {
+			t.Errorf("%s: unexpected error %#v", tt.name, err)
+		}
+	}
+}
+
+func TestRandomHex(t *testing.T) {
+	first, err := randomHex(16)
+	if err != nil {
+		t.Fatalf("randomHex: %v", err)
+	}
+	second, _ := randomHex(16)
+	if len(first) != 32 || first == second {
+		t.Errorf("randomHex(16) = %q, %q; want two different 32 character strings", first, second)
+	}
+}
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"dev-bridge-manager/internal/models"
 	"errors"
 	"os"
 	"strconv"
@@ -9,6 +10,37 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// ErrAccountDeactivated is returned when the user's role has been deactivated
+var ErrAccountDeactivated = errors.New("account is deactivated")
+
+// ErrMFASetupRequired is returned when refreshing a session of a user whose role now requires MFA
+var ErrMFASetupRequired = errors.New("two-factor authentication must be set up, please log in again")
+
+// ErrInvalidMFAChallenge is returned for expired, foreign or outdated MFA challenge tokens
+var ErrInvalidMFAChallenge = errors.New("invalid or expired MFA challenge, please log in again")
+
+// MFA challenge célok
+const (
+	MFAChallengeVerify = "mfa_verify"
+	MFAChallengeSetup  = "mfa_setup"
+)
+
+// mfaChallengeTTL is how long the second login step may take
+const mfaChallengeTTL = 5 * time.Minute
+
+// mfaAudience separates MFA challenge tokens from access tokens
+const mfaAudience = "mfa"
+
+// MFAChallengeClaims is the short-lived token returned by the password step of an MFA login.
+// It carries no permissions and no session, so it is rejected as an access token.
+type MFAChallengeClaims struct {
+	UserID  uint   `json:"user_id"`
+	Purpose string `json:"purpose"`
+	Device  string `json:"device,omitempty"`
+	Version int    `json:"tv"`
+	jwt.RegisteredClaims
+}
+
 type JWTClaims struct {
 	UserID      uint     `json:"user_id"`
 	Email       string   `json:"email"`
@@ -16,12 +48,24 @@ type JWTClaims struct {
 	RoleID      uint     `json:"role_id"`
 	RoleName    string   `json:"role_name"`
 	Permissions []string `json:"permissions"`
+	SessionID   uint     `json:"sid,omitempty"`
+	Version     int      `json:"tv"`
 	jwt.RegisteredClaims
 }
 
+// TokenPair is an access token with the refresh token of its session
+type TokenPair struct {
+	AccessToken  string
+	ExpiresAt    time.Time
+	RefreshToken string
+	SessionID    uint
+}
+
 type AuthService struct {
 	secretKey         []byte
+	accessTTL         time.Duration
 	permissionService *PermissionService
+	sessionService    *SessionService
 }
 
 func NewAuthService() *AuthService {
@@ -30,26 +74,33 @@ func NewAuthService() *AuthService {
 		secret = "default-secret-key-change-in-production"
 	}
 
+	// A webes kliens még nem frissíti az access tokent, ezért az alapértelmezés a korábbi 24 óra;
+	// a /auth/refresh-t használó kliensekhez rövidebb JWT_ACCESS_TOKEN_MINUTES állítható
+	accessTTL := 24 * time.Hour
+	if minutes := os.Getenv("JWT_ACCESS_TOKEN_MINUTES"); minutes != "" {
+		if parsed, err := strconv.Atoi(minutes); err == nil && parsed > 0 {
+			accessTTL = time.Duration(parsed) * time.Minute
+		}
+	}
+
 	return &AuthService{
 		secretKey:         []byte(secret),
+		accessTTL:         accessTTL,
 		permissionService: NewPermissionService(),
+		sessionService:    NewSessionService(),
 	}
 }
 
-// GenerateToken creates a new JWT token with role and permissions
-func (s *AuthService) GenerateToken(userID uint, email, name string) (string, error) {
+// GenerateToken creates a new JWT access token with role and permissions for the session
+func (s *AuthService) GenerateToken(userID uint, email, name string, sessionID uint) (string, time.Time, error) {
 	// Get user with role and permissions
 	user, err := s.permissionService.GetUserWithPermissions(userID)
 	if err != nil {
-		return "", err
+		return "", time.Time{}, err
 	}
 
-	expirationTime := time.Now().Add(24 * time.Hour)
-	if expStr := os.Getenv("JWT_EXPIRATION_HOURS"); expStr != "" {
-		if hours, err := strconv.Atoi(expStr); err == nil {
-			expirationTime = time.Now().Add(time.Duration(hours) * time.Hour)
-		}
-	}
+	now := time.Now()
+	expirationTime := now.Add(s.accessTTL)
 
 	claims := &JWTClaims{
 		UserID:      userID,
@@ -58,17 +109,23 @@ func (s *AuthService) GenerateToken(userID uint, email, name string) (string, er
 		RoleID:      user.RoleID,
 		RoleName:    user.Role.Name,
 		Permissions: user.GetPermissions(),
+		SessionID:   sessionID,
+		Version:     user.TokenVersion,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(expirationTime),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			NotBefore: jwt.NewNumericDate(time.Now()),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
 			Issuer:    "dev-bridge-manager",
 			Subject:   strconv.Itoa(int(userID)),
 		},
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(s.secretKey)
+	signed, err := token.SignedString(s.secretKey)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return signed, expirationTime, nil
 }
 
 // ValidateToken validates and parses JWT token
@@ -88,13 +145,119 @@ func (s *AuthService) ValidateToken(tokenString string) (*JWTClaims, error) {
 	return nil, errors.New("invalid token")
 }
 
-// RefreshToken creates a new token with updated permissions
-func (s *AuthService) RefreshToken(oldTokenString string) (string, error) {
-	claims, err := s.ValidateToken(oldTokenString)
+// CheckRevocation rejects tokens of revoked sessions and tokens issued before the user's
+// tokens were invalidated (password or role change). Tokens without a session are rejected.
+func (s *AuthService) CheckRevocation(claims *JWTClaims) error {
+	if claims.SessionID == 0 {
+		return ErrTokenRevoked
+	}
+	return s.sessionService.CheckAccessToken(claims.UserID, claims.SessionID, claims.Version)
+}
+
+// StartSession creates a new session for a user who just logged in or registered
+// and returns its first access and refresh tokens
+func (s *AuthService) StartSession(user *models.User, meta SessionMeta) (*TokenPair, error) {
+	session, refreshToken, err := s.sessionService.CreateSession(user.ID, meta)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	// Generate new token with current permissions
-	return s.GenerateToken(claims.UserID, claims.Email, claims.Name)
+	accessToken, expiresAt, err := s.GenerateToken(user.ID, user.Email, user.Name, session.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenPair{
+		AccessToken:  accessToken,
+		ExpiresAt:    expiresAt,
+		RefreshToken: refreshToken,
+		SessionID:    session.ID,
+	}, nil
+}
+
+// RefreshSession rotates the refresh token and issues a new access token with the user's
+// current permissions. Sessions of users whose role was deactivated, or whose role started to
+// require MFA before they set it up, are revoked.
+func (s *AuthService) RefreshSession(refreshToken string, meta SessionMeta) (*TokenPair, *models.User, error) {
+	session, newRefreshToken, err := s.sessionService.RotateRefreshToken(refreshToken, meta)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	user, err := s.permissionService.GetUserWithPermissions(session.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !user.Role.IsActive {
+		if err := s.sessionService.RevokeSession(session, SessionRevokedDeactivated); err != nil {
+			return nil, nil, err
+		}
+		return nil, nil, ErrAccountDeactivated
+	}
+	if user.Role.MFARequired && !user.MFAEnabled {
+		if err := s.sessionService.RevokeSession(session, SessionRevokedMFARequired); err != nil {
+			return nil, nil, err
+		}
+		return nil, nil, ErrMFASetupRequired
+	}
+
+	accessToken, expiresAt, err := s.GenerateToken(user.ID, user.Email, user.Name, session.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &TokenPair{
+		AccessToken:  accessToken,
+		ExpiresAt:    expiresAt,
+		RefreshToken: newRefreshToken,
+		SessionID:    session.ID,
+	}, user, nil
+}
+
+// IssueMFAChallenge creates the token for the second login step: MFAChallengeVerify asks for a
+// code, MFAChallengeSetup lets a user whose role requires MFA enrol before the first session
+func (s *AuthService) IssueMFAChallenge(user *models.User, purpose, device string) (string, error) {
+	now := time.Now()
+	claims := &MFAChallengeClaims{
+		UserID:  user.ID,
+		Purpose: purpose,
+		Device:  device,
+		Version: user.TokenVersion,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(now.Add(mfaChallengeTTL)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			Issuer:    "dev-bridge-manager",
+			Subject:   strconv.Itoa(int(user.ID)),
+			Audience:  jwt.ClaimStrings{mfaAudience},
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(s.secretKey)
+}
+
+// ValidateMFAChallenge checks a challenge token of the given purpose and returns the user
+// (with role and permissions) and the device name given at login
+func (s *AuthService) ValidateMFAChallenge(tokenString, purpose string) (*models.User, string, error) {
+	claims := &MFAChallengeClaims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		return s.secretKey, nil
+	}, jwt.WithAudience(mfaAudience))
+	if err != nil || !token.Valid || claims.Purpose != purpose {
+		return nil, "", ErrInvalidMFAChallenge
+	}
+
+	user, err := s.permissionService.GetUserWithPermissions(claims.UserID)
+	if err != nil {
+		return nil, "", ErrInvalidMFAChallenge
+	}
+	// Jelszócsere vagy szerepkör váltás után a challenge sem használható
+	if user.TokenVersion != claims.Version {
+		return nil, "", ErrInvalidMFAChallenge
+	}
+	if !user.Role.IsActive {
+		return nil, "", ErrAccountDeactivated
+	}
+	return user, claims.Device, nil
 }
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"dev-bridge-manager/internal/models"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func newTestAuthService() *AuthService {
+	return &AuthService{secretKey: []byte("test-secret"), accessTTL: time.Minute}
+}
+
+func signTestToken(t *testing.T, secret string, claims jwt.Claims) string {
+	t.Helper()
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	return signed
+}
+
+func accessClaims(sessionID uint, expiresAt time.Time) *JWTClaims {
+	return &JWTClaims{
+		UserID:           4,
+		SessionID:        sessionID,
+		Version:          2,
+		RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(expiresAt)},
+	}
+}
+
+func TestValidateToken(t *testing.T) {
+	s := newTestAuthService()
+	valid := signTestToken(t, "test-secret", accessClaims(7, time.Now().Add(time.Minute)))
+
+	claims, err := s.ValidateToken(valid)
+	if err != nil || claims.UserID != 4 || claims.SessionID != 7 || claims.Version != 2 {
+		t.Fatalf("ValidateToken = %+v, %v", claims, err)
+	}
+
+	rejected := map[string]string{
+		"other secret": signTestToken(t, "other-secret", accessClaims(7, time.Now().Add(time.Minute))),
+		"expired":      signTestToken(t, "test-secret", accessClaims(7, time.Now().Add(-time.Minute))),
+		"tampered":     valid[:len(valid)-2] + "xx",
+		"garbage":      "not.a.token",
+	}
+	for name, token := range rejected {
+		if _, err := s.ValidateToken(token); err == nil {
+			t.Errorf("%s token was accepted", name)
+		}
+	}
+}
+
+func TestCheckRevocationRejectsTokensWithoutSession(t *testing.T) {
+	s := newTestAuthService()
+
+	// Az MFA challenge token aláírása érvényes, de session nélkül nem használható access tokenként
+	challenge, err := s.IssueMFAChallenge(&models.User{ID: 4}, MFAChallengeVerify, "laptop")
+	if err != nil {
+		t.Fatalf("IssueMFAChallenge: %v", err)
+	}
+	claims, err := s.ValidateToken(challenge)
+	if err != nil {
+		t.Fatalf("ValidateToken: %v", err)
+	}
+	if err := s.CheckRevocation(claims); !errors.Is(err, ErrTokenRevoked) {
+		t.Errorf("CheckRevocation of a challenge token = %v, want ErrTokenRevoked", err)
+	}
+}
@@ -0,0 +1,164 @@
+package services
+
+import (
+	"dev-bridge-manager/internal/database"
+	"dev-bridge-manager/internal/models"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ChecklistService struct {
+	db *gorm.DB
+}
+
+func NewChecklistService() *ChecklistService {
+	return &ChecklistService{
+		db: database.GetDB(),
+	}
+}
+
+// lockChecklist locks the owning task row so concurrent checklist edits renumber one at a time
+func (s *ChecklistService) lockChecklist(tx *gorm.DB, taskID uint) ([]models.TaskChecklistItem, error) {
+	var task models.Task
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&task, taskID).Error; err != nil {
+		return nil, err
+	}
+	return s.listItems(tx, taskID)
+}
+
+func (s *ChecklistService) listItems(tx *gorm.DB, taskID uint) ([]models.TaskChecklistItem, error) {
+	var items []models.TaskChecklistItem
+	err := tx.Where("task_id = ?", taskID).Order("position ASC, id ASC").Find(&items).Error
+	return items, err
+}
+
+// writeItemPositions renumbers items 0..n-1 in slice order, touching only changed rows
+func (s *ChecklistService) writeItemPositions(tx *gorm.DB, items []models.TaskChecklistItem) error {
+	for i := range items {
+		if items[i].Position == i {
+			continue
+		}
+		items[i].Position = i
+		err := tx.Model(&models.TaskChecklistItem{}).Where("id = ?", items[i].ID).UpdateColumn("position", i).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// clampPosition limits a requested position to 0..max; nil means the end of the list
+func clampPosition(position *int, max int) int {
+	if position == nil || *position > max {
+		return max
+	}
+	if *position < 0 {
+		return 0
+	}
+	return *position
+}
+
+// ListItems returns a task's checklist in order
+func (s *ChecklistService) ListItems(taskID uint) ([]models.TaskChecklistItem, error) {
+	return s.listItems(s.db, taskID)
+}
+
+// LoadItem loads a checklist item of the task
+func (s *ChecklistService) LoadItem(taskID, itemID uint) (*models.TaskChecklistItem, error) {
+	var item models.TaskChecklistItem
+	if err := s.db.Where("id = ? AND task_id = ?", itemID, taskID).First(&item).Error; err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// CreateItem inserts an item at the given position (nil = end of the list)
+func (s *ChecklistService) CreateItem(item *models.TaskChecklistItem, position *int) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		items, err := s.lockChecklist(tx, item.TaskID)
+		if err != nil {
+			return err
+		}
+
+		item.Position = len(items)
+		if err := tx.Create(item).Error; err != nil {
+			return err
+		}
+
+		// Az ideiglenes -1 pozíció garantálja, hogy writeItemPositions frissíti az új elemet
+		inserted := *item
+		inserted.Position = -1
+		items = slices.Insert(items, clampPosition(position, len(items)), inserted)
+		if err := s.writeItemPositions(tx, items); err != nil {
+			return err
+		}
+		return tx.First(item, item.ID).Error
+	})
+}
+
+// UpdateItem changes an item's title, done flag and/or position
+func (s *ChecklistService) UpdateItem(item *models.TaskChecklistItem, req *models.ChecklistItemUpdateRequest, userID uint) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		items, err := s.lockChecklist(tx, item.TaskID)
+		if err != nil {
+			return err
+		}
+
+		current := slices.IndexFunc(items, func(i models.TaskChecklistItem) bool { return i.ID == item.ID })
+		if current < 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		updates := map[string]interface{}{}
+		if req.Title != nil {
+			updates["title"] = *req.Title
+		}
+		if req.IsDone != nil && *req.IsDone != items[current].IsDone {
+			updates["is_done"] = *req.IsDone
+			if *req.IsDone {
+				updates["done_at"] = time.Now()
+				updates["done_by"] = userID
+			} else {
+				updates["done_at"] = nil
+				updates["done_by"] = nil
+			}
+		}
+		if len(updates) > 0 {
+			if err := tx.Model(&models.TaskChecklistItem{}).Where("id = ?", item.ID).Updates(updates).Error; err != nil {
+				return err
+			}
+		}
+
+		if req.Position != nil {
+			moved := items[current]
+			items = slices.Delete(items, current, current+1)
+			moved.Position = -1
+			items = slices.Insert(items, clampPosition(req.Position, len(items)), moved)
+			if err := s.writeItemPositions(tx, items); err != nil {
+				return err
+			}
+		}
+
+		return tx.First(item, item.ID).Error
+	})
+}
+
+// DeleteItem deletes an item and closes the gap it leaves
+func (s *ChecklistService) DeleteItem(item *models.TaskChecklistItem) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if _, err := s.lockChecklist(tx, item.TaskID); err != nil {
+			return err
+		}
+		if err := tx.Delete(&models.TaskChecklistItem{}, item.ID).Error; err != nil {
+			return err
+		}
+		items, err := s.listItems(tx, item.TaskID)
+		if err != nil {
+			return err
+		}
+		return s.writeItemPositions(tx, items)
+	})
+}
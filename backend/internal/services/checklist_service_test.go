@@ -0,0 +1,28 @@
+package services
+
+import "testing"
+
+func TestClampPosition(t *testing.T) {
+	pos := func(p int) *int { return &p }
+
+	tests := []struct {
+		name     string
+		position *int
+		max      int
+		want     int
+	}{
+		{"nil appends", nil, 3, 3},
+		{"first", pos(0), 3, 0},
+		{"middle", pos(2), 3, 2},
+		{"end", pos(3), 3, 3},
+		{"past the end", pos(10), 3, 3},
+		{"negative", pos(-1), 3, 0},
+		{"empty list", pos(5), 0, 0},
+	}
+
+	for _, tt := range tests {
+		if got := clampPosition(tt.position, tt.max); got != tt.want {
+			t.Errorf("%s: clampPosition = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,245 @@
+package services
+
+import (
+	"dev-bridge-manager/internal/database"
+	"dev-bridge-manager/internal/models"
+	"errors"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrInvalidEmoji is returned when a reaction is not exactly one emoji
+var ErrInvalidEmoji = errors.New("a single emoji is required")
+
+type CommentService struct {
+	db *gorm.DB
+}
+
+func NewCommentService() *CommentService {
+	return &CommentService{
+		db: database.GetDB(),
+	}
+}
+
+// withCommentDetails preloads the author and the reactions of comments
+func withCommentDetails(db *gorm.DB) *gorm.DB {
+	return db.Preload("User").Preload("Reactions", func(db *gorm.DB) *gorm.DB {
+		return db.Order("created_at ASC, id ASC")
+	})
+}
+
+// ListTaskComments returns a task's comments, pinned ones first, then oldest first
+func (s *CommentService) ListTaskComments(projectID, taskID uint) ([]models.TaskComment, error) {
+	var comments []models.TaskComment
+	err := s.db.Scopes(withCommentDetails).
+		Where("project_id = ? AND task_id = ?", projectID, taskID).
+		Order("is_pinned DESC, pinned_at DESC, created_at ASC, id ASC").
+		Find(&comments).Error
+	return comments, err
+}
+
+// LoadComment loads a project's comment with its author and reactions
+func (s *CommentService) LoadComment(projectID, commentID uint) (*models.TaskComment, error) {
+	var comment models.TaskComment
+	err := s.db.Scopes(withCommentDetails).
+		Where("id = ? AND project_id = ?", commentID, projectID).
+		First(&comment).Error
+	if err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// CreateComment stores a new comment
+func (s *CommentService) CreateComment(comment *models.TaskComment) error {
+	return s.db.Create(comment).Error
+}
+
+// UpdateComment keeps the current content as a revision and replaces it.
+// Unchanged content is a no-op and does not mark the comment as edited.
+func (s *CommentService) UpdateComment(comment *models.TaskComment, content, htmlContent string, userID uint) error {
+	if comment.Content == content && comment.HTMLContent == htmlContent {
+		return nil
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		revision := models.TaskCommentRevision{
+			CommentID:   comment.ID,
+			Content:     comment.Content,
+			HTMLContent: comment.HTMLContent,
+			EditedBy:    &userID,
+		}
+		if err := tx.Create(&revision).Error; err != nil {
+			return err
+		}
+
+		updates := map[string]interface{}{
+			"content":      content,
+			"html_content": htmlContent,
+			"is_edited":    true,
+		}
+		return tx.Model(&models.TaskComment{}).Where("id = ?", comment.ID).Updates(updates).Error
+	})
+}
+
+// DeleteComment deletes a comment with its revisions and reactions
+func (s *CommentService) DeleteComment(commentID uint) error {
+	return s.db.Delete(&models.TaskComment{}, commentID).Error
+}
+
+// GetRevisions returns the previous versions of a comment, newest first
+func (s *CommentService) GetRevisions(commentID uint) ([]models.TaskCommentRevision, error) {
+	var revisions []models.TaskCommentRevision
+	err := s.db.Where("comment_id = ?", commentID).
+		Order("created_at DESC, id DESC").
+		Find(&revisions).Error
+	return revisions, err
+}
+
+// SetReaction adds (add=true), removes (add=false) or toggles (add=nil) a user's emoji reaction
+func (s *CommentService) SetReaction(commentID, userID uint, emoji string, add *bool) error {
+	if !ValidEmoji(emoji) {
+		return ErrInvalidEmoji
+	}
+
+	if add == nil || !*add {
+		result := s.db.Where("comment_id = ? AND user_id = ? AND emoji = ?", commentID, userID, emoji).
+			Delete(&models.TaskCommentReaction{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if add != nil || result.RowsAffected > 0 {
+			return nil
+		}
+	}
+
+	// A UNIQUE(comment_id, user_id, emoji) megszorítás miatt a párhuzamos kérések sem duplikálnak
+	reaction := models.TaskCommentReaction{
+		CommentID: commentID,
+		UserID:    userID,
+		Emoji:     emoji,
+	}
+	return s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&reaction).Error
+}
+
+// TogglePin pins or unpins a comment
+func (s *CommentService) TogglePin(comment *models.TaskComment, userID uint) error {
+	updates := map[string]interface{}{
+		"is_pinned": !comment.IsPinned,
+		"pinned_at": nil,
+		"pinned_by": nil,
+	}
+	if !comment.IsPinned {
+		updates["pinned_at"] = time.Now()
+		updates["pinned_by"] = userID
+	}
+	return s.db.Model(&models.TaskComment{}).Where("id = ?", comment.ID).UpdateColumns(updates).Error
+}
+
+// emojiBase lists the code points that can start an emoji
+var emojiBase = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x00a9, Hi: 0x00ae, Stride: 5},
+		{Lo: 0x203c, Hi: 0x2049, Stride: 13},
+		{Lo: 0x2122, Hi: 0x2139, Stride: 23},
+		{Lo: 0x2194, Hi: 0x2199, Stride: 1},
+		{Lo: 0x21a9, Hi: 0x21aa, Stride: 1},
+		{Lo: 0x231a, Hi: 0x23ff, Stride: 1},
+		{Lo: 0x24c2, Hi: 0x24c2, Stride: 1},
+		{Lo: 0x25aa, Hi: 0x25fe, Stride: 1},
+		{Lo: 0x2600, Hi: 0x27bf, Stride: 1},
+		{Lo: 0x2934, Hi: 0x2935, Stride: 1},
+		{Lo: 0x2b05, Hi: 0x2b55, Stride: 1},
+		{Lo: 0x3030, Hi: 0x303d, Stride: 13},
+		{Lo: 0x3297, Hi: 0x3299, Stride: 2},
+	},
+	LatinOffset: 1,
+	R32: []unicode.Range32{
+		{Lo: 0x1f000, Hi: 0x1f0ff, Stride: 1},
+		{Lo: 0x1f170, Hi: 0x1f1e5, Stride: 1},
+		{Lo: 0x1f200, Hi: 0x1f251, Stride: 1},
+		{Lo: 0x1f300, Hi: 0x1f3fa, Stride: 1},
+		{Lo: 0x1f400, Hi: 0x1f64f, Stride: 1},
+		{Lo: 0x1f680, Hi: 0x1f6ff, Stride: 1},
+		{Lo: 0x1f7e0, Hi: 0x1f7f0, Stride: 1},
+		{Lo: 0x1f900, Hi: 0x1f9ff, Stride: 1},
+		{Lo: 0x1fa70, Hi: 0x1faff, Stride: 1},
+	},
+}
+
+const (
+	zeroWidthJoiner   = '\u200d'
+	variationSelector = '\ufe0f'
+	combiningKeycap   = '\u20e3'
+	skinToneFirst     = '\U0001f3fb'
+	skinToneLast      = '\U0001f3ff'
+	regionalFirst     = '\U0001f1e6'
+	regionalLast      = '\U0001f1ff'
+	tagFirst          = '\U000e0020'
+	tagLast           = '\U000e007e'
+	cancelTag         = '\U000e007f'
+	blackFlag         = '\U0001f3f4'
+)
+
+// ValidEmoji reports whether s is exactly one emoji: a single pictograph with optional
+// variation selector and skin tone, a ZWJ sequence of those, a flag or a keycap
+func ValidEmoji(s string) bool {
+	if s == "" || len(s) > 32 || !utf8.ValidString(s) {
+		return false
+	}
+	runes := []rune(s)
+
+	// Zászló: pontosan két regionális jelző
+	if isRegional(runes[0]) {
+		return len(runes) == 2 && isRegional(runes[1])
+	}
+
+	// Keycap: számjegy, # vagy *, opcionális variáció választó, majd a keycap jel
+	if r := runes[0]; r == '#' || r == '*' || (r >= '0' && r <= '9') {
+		rest := runes[1:]
+		if len(rest) > 0 && rest[0] == variationSelector {
+			rest = rest[1:]
+		}
+		return len(rest) == 1 && rest[0] == combiningKeycap
+	}
+
+	// Területi zászló (pl. 🏴󠁧󠁢󠁳󠁣󠁴󠁿): fekete zászló, tag karakterek, lezáró tag
+	if runes[0] == blackFlag && len(runes) > 2 {
+		tags := runes[1 : len(runes)-1]
+		for _, r := range tags {
+			if r < tagFirst || r > tagLast {
+				return false
+			}
+		}
+		return runes[len(runes)-1] == cancelTag
+	}
+
+	// Egy vagy több ZWJ-vel összekapcsolt elem
+	for i := 0; ; {
+		if i >= len(runes) || !unicode.Is(emojiBase, runes[i]) {
+			return false
+		}
+		i++
+		if i < len(runes) && runes[i] == variationSelector {
+			i++
+		}
+		if i < len(runes) && runes[i] >= skinToneFirst && runes[i] <= skinToneLast {
+			i++
+		}
+		if i == len(runes) {
+			return true
+		}
+		if runes[i] != zeroWidthJoiner {
+			return false
+		}
+		i++
+	}
+}
+
+func isRegional(r rune) bool {
+	return r >= regionalFirst && r <= regionalLast
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidEmoji(t *testing.T) {
+	tests := []struct {
+		name  string
+		emoji string
+		want  bool
+	}{
+		{"thumbs up", "👍", true},
+		{"heart with variation selector", "❤️", true},
+		{"heart without variation selector", "❤", true},
+		{"skin tone", "👍🏽", true},
+		{"zwj family", "👨‍👩‍👧‍👦", true},
+		{"zwj with skin tone", "🧑🏻‍💻", true},
+		{"rainbow flag", "🏳️‍🌈", true},
+		{"country flag", "🇭🇺", true},
+		{"keycap", "1️⃣", true},
+		{"keycap without variation selector", "#⃣", true},
+		{"subdivision flag", "🏴\U000e0067\U000e0062\U000e0073\U000e0063\U000e0074\U000e007f", true},
+		{"star", "⭐", true},
+		{"copyright", "©️", true},
+
+		{"empty", "", false},
+		{"plain text", "like", false},
+		{"letter", "a", false},
+		{"digit", "1", false},
+		{"two emojis", "👍👍", false},
+		{"emoji and text", "👍x", false},
+		{"leading space", " 👍", false},
+		{"html", "<b>", false},
+		{"single regional indicator", "🇭", false},
+		{"three regional indicators", "🇭🇺🇭", false},
+		{"lone skin tone", "🏽", false},
+		{"lone variation selector", "️", false},
+		{"trailing zwj", "👍‍", false},
+		{"double zwj", "👨‍‍👩", false},
+		{"zwj with text", "👨‍x", false},
+		{"unterminated tag sequence", "🏴\U000e0067\U000e0062", false},
+		{"invalid utf-8", "\xf0\x9f", false},
+		{"too long", "👨‍👩‍👧‍👦‍👨‍👩‍👧‍👦", false},
+	}
+
+	for _, tt := range tests {
+		if got := ValidEmoji(tt.emoji); got != tt.want {
+			t.Errorf("%s: ValidEmoji(%q) = %v, want %v", tt.name, tt.emoji, got, tt.want)
+		}
+	}
+}
+
+func TestSetReactionRejectsInvalidEmojiBeforeDelete(t *testing.T) {
+	// Toggle módban (add == nil) az első lépés a reakció törlése; érvénytelen emojinál ennek
+	// el kell maradnia, különben a nil db pánikot okozna
+	s := &CommentService{}
+	if err := s.SetReaction(1, 1, "not an emoji", nil); !errors.Is(err, ErrInvalidEmoji) {
+		t.Errorf("toggling %q: err = %v, want ErrInvalidEmoji", "not an emoji", err)
+	}
+}
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"dev-bridge-manager/internal/database"
+	"dev-bridge-manager/internal/models"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type KanbanService struct {
+	db *gorm.DB
+}
+
+func NewKanbanService() *KanbanService {
+	return &KanbanService{
+		db: database.GetDB(),
+	}
+}
+
+// CreateDefaultBoard creates a board with the default columns for a project.
+// Pass a transaction handle to create it together with the project.
+func (s *KanbanService) CreateDefaultBoard(tx *gorm.DB, projectID uint) (*models.KanbanBoard, error) {
+	board := models.KanbanBoard{
+		ProjectID: projectID,
+		Settings:  models.DefaultKanbanSettings,
+	}
+	if err := tx.Create(&board).Error; err != nil {
+		return nil, err
+	}
+	if err := s.createDefaultColumns(tx, &board); err != nil {
+		return nil, err
+	}
+	return &board, nil
+}
+
+// createDefaultColumns adds the default columns to a new board
+func (s *KanbanService) createDefaultColumns(tx *gorm.DB, board *models.KanbanBoard) error {
+	for _, column := range models.DefaultKanbanColumns {
+		column.BoardID = board.ID
+		if err := tx.Create(&column).Error; err != nil {
+			return err
+		}
+		board.Columns = append(board.Columns, column)
+	}
+	return nil
+}
+
+// loadBoard loads the project's board with ordered columns
+func (s *KanbanService) loadBoard(tx *gorm.DB, projectID uint) (*models.KanbanBoard, error) {
+	var board models.KanbanBoard
+	err := tx.Preload("Columns", func(db *gorm.DB) *gorm.DB {
+		return db.Order("kanban_columns.position ASC, kanban_columns.id ASC")
+	}).Where("project_id = ?", projectID).First(&board).Error
+	if err != nil {
+		return nil, err
+	}
+	return &board, nil
+}
+
+// GetOrCreateBoard returns the project's board with ordered columns,
+// creating the default board for projects that predate kanban support
+func (s *KanbanService) GetOrCreateBoard(projectID uint) (*models.KanbanBoard, error) {
+	board, err := s.loadBoard(s.db, projectID)
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return board, err
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		var txErr error
+		board, txErr = s.GetOrCreateBoardTx(tx, projectID)
+		return txErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return board, nil
+}
+
+// GetOrCreateBoardTx is GetOrCreateBoard inside an existing transaction. Concurrent first
+// requests cannot create two boards: the insert skips on the unique project_id and the
+// request that lost the race reads back the board of the winner.
+func (s *KanbanService) GetOrCreateBoardTx(tx *gorm.DB, projectID uint) (*models.KanbanBoard, error) {
+	board, err := s.loadBoard(tx, projectID)
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return board, err
+	}
+
+	created := models.KanbanBoard{
+		ProjectID: projectID,
+		Settings:  models.DefaultKanbanSettings,
+	}
+	result := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "project_id"}},
+		DoNothing: true,
+	}).Create(&created)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		// Egy párhuzamos kérés már létrehozta (az insert megvárta a commitját)
+		return s.loadBoard(tx, projectID)
+	}
+
+	if err := s.createDefaultColumns(tx, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// GetProjectColumn returns a column only if it belongs to the project's board
+func (s *KanbanService) GetProjectColumn(tx *gorm.DB, projectID, columnID uint) (*models.KanbanColumn, error) {
+	var column models.KanbanColumn
+	err := tx.Joins("JOIN kanban_boards ON kanban_boards.id = kanban_columns.board_id").
+		Where("kanban_columns.id = ? AND kanban_boards.project_id = ?", columnID, projectID).
+		First(&column).Error
+	if err != nil {
+		return nil, err
+	}
+	return &column, nil
+}
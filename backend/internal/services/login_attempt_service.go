@@ -0,0 +1,223 @@
+package services
+
+import (
+	"context"
+	"dev-bridge-manager/internal/database"
+	"dev-bridge-manager/internal/models"
+	"dev-bridge-manager/internal/ratelimit"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// LoginLockoutListLimit is the maximum number of lockout events returned at once
+const LoginLockoutListLimit = 100
+
+// LoginAttemptService counts failed logins per account and per IP address and locks them
+// temporarily with exponential backoff. The counters live in the configured ratelimit.Limiter,
+// the lockout events in login_lockouts.
+type LoginAttemptService struct {
+	db            *gorm.DB
+	limiter       ratelimit.Limiter
+	accountPolicy ratelimit.Policy
+	ipPolicy      ratelimit.Policy
+}
+
+func NewLoginAttemptService() *LoginAttemptService {
+	window := time.Duration(envNonNegativeInt("LOGIN_ATTEMPT_WINDOW_MINUTES", 15)) * time.Minute
+	baseLockout := time.Duration(envNonNegativeInt("LOGIN_LOCKOUT_MINUTES", 1)) * time.Minute
+	maxLockout := time.Duration(envNonNegativeInt("LOGIN_LOCKOUT_MAX_MINUTES", 1440)) * time.Minute
+	if maxLockout < baseLockout {
+		maxLockout = baseLockout
+	}
+
+	return &LoginAttemptService{
+		db:      database.GetDB(),
+		limiter: ratelimit.Default(),
+		accountPolicy: ratelimit.Policy{
+			MaxAttempts: envNonNegativeInt("LOGIN_MAX_ATTEMPTS", 5),
+			Window:      window,
+			BaseLockout: baseLockout,
+			MaxLockout:  maxLockout,
+		},
+		// Egy IP mögött több felhasználó is lehet (NAT), ezért magasabb küszöb
+		ipPolicy: ratelimit.Policy{
+			MaxAttempts: envNonNegativeInt("LOGIN_IP_MAX_ATTEMPTS", 20),
+			Window:      window,
+			BaseLockout: baseLockout,
+			MaxLockout:  maxLockout,
+		},
+	}
+}
+
+// accountKey is the limiter key of an account; the email is used, so unknown accounts are
+// throttled the same way and the responses do not reveal which emails exist
+func accountKey(email string) string {
+	return "account:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+func ipKey(ip string) string {
+	return "ip:" + ip
+}
+
+// LoginAttempt is a login attempt already counted on the account and IP address counters.
+// The credentials are verified afterwards and the outcome settles the attempt with Fail,
+// Succeed or Refund.
+type LoginAttempt struct {
+	service  *LoginAttemptService
+	email    string
+	ip       string
+	now      time.Time
+	reserved []reservedCounter
+}
+
+// reservedCounter is a counter with the state its Fail returned for the attempt
+type reservedCounter struct {
+	attemptCounter
+	state  ratelimit.State
+	locked bool
+}
+
+// Begin counts a login attempt on the email's and the IP address's counters before the
+// credentials are verified, so parallel guesses cannot get past the threshold. If a counter is
+// already locked, nothing stays counted and the attempt is nil; the returned time tells until
+// when logins are locked.
+func (s *LoginAttemptService) Begin(email, ip string, now time.Time) (*LoginAttempt, time.Time, error) {
+	attempt := &LoginAttempt{service: s, email: email, ip: ip, now: now}
+	for _, counter := range s.counters(email, ip) {
+		state, locked, err := s.limiter.Fail(context.Background(), counter.key, counter.policy, now)
+		if err != nil {
+			attempt.Refund()
+			return nil, time.Time{}, err
+		}
+		// A már zárolt számláló nem számolja a kísérletet; a többi számlálóról visszavesszük
+		if !locked && state.IsLocked(now) {
+			if err := attempt.Refund(); err != nil {
+				return nil, time.Time{}, err
+			}
+			return nil, state.LockedUntil, nil
+		}
+		attempt.reserved = append(attempt.reserved, reservedCounter{attemptCounter: counter, state: state, locked: locked})
+	}
+	return attempt, time.Time{}, nil
+}
+
+// Fail settles the attempt as a failed login and records a lockout event for every counter the
+// attempt locked. It returns until when logins are locked now; zero if they are not. userID is
+// nil for unknown accounts.
+func (a *LoginAttempt) Fail(userID *uint) (time.Time, error) {
+	var until time.Time
+	for _, counter := range a.reserved {
+		if counter.locked {
+			lockout := models.LoginLockout{
+				UserID:       userID,
+				Email:        strings.ToLower(strings.TrimSpace(a.email)),
+				IPAddress:    a.ip,
+				Scope:        counter.scope,
+				LockoutCount: counter.state.Lockouts,
+				LockedUntil:  counter.state.LockedUntil,
+			}
+			if err := a.service.db.Create(&lockout).Error; err != nil {
+				return time.Time{}, err
+			}
+		}
+		if counter.state.IsLocked(a.now) && counter.state.LockedUntil.After(until) {
+			until = counter.state.LockedUntil
+		}
+	}
+	return until, nil
+}
+
+// Succeed settles the attempt as a completed login: the account's counter is reset, while the
+// IP address only gets this attempt back, otherwise one valid account would let an attacker
+// reset it between guesses.
+func (a *LoginAttempt) Succeed() error {
+	for _, counter := range a.reserved {
+		var err error
+		if counter.scope == models.LockoutScopeAccount {
+			err = a.service.limiter.Reset(context.Background(), counter.key)
+		} else {
+			err = a.service.limiter.Refund(context.Background(), counter.key, counter.policy, counter.state, counter.locked)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Refund takes the attempt back from every counter when it turned out not to be a guess, e.g.
+// a correct password before the second step or a server error during the verification
+func (a *LoginAttempt) Refund() error {
+	for _, counter := range a.reserved {
+		if err := a.service.limiter.Refund(context.Background(), counter.key, counter.policy, counter.state, counter.locked); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ResetAccount resets the account's counter and lifts its lockout, e.g. after a password reset
+func (s *LoginAttemptService) ResetAccount(email string) error {
+	return s.limiter.Reset(context.Background(), accountKey(email))
+}
+
+// AccountLockedUntil returns until when the user's account is locked; zero if it is not
+func (s *LoginAttemptService) AccountLockedUntil(user *models.User, now time.Time) (time.Time, error) {
+	state, err := s.limiter.Status(context.Background(), accountKey(user.Email))
+	if err != nil || !state.IsLocked(now) {
+		return time.Time{}, err
+	}
+	return state.LockedUntil, nil
+}
+
+// Unlock lifts the account lockout of the user, resets the backoff and marks the open
+// account lockout events as unlocked by the administrator
+func (s *LoginAttemptService) Unlock(user *models.User, adminID uint, now time.Time) error {
+	if err := s.limiter.Reset(context.Background(), accountKey(user.Email)); err != nil {
+		return err
+	}
+	return s.db.Model(&models.LoginLockout{}).
+		Where("user_id = ? AND scope = ? AND unlocked_at IS NULL AND locked_until > ?", user.ID, models.LockoutScopeAccount, now).
+		Updates(map[string]interface{}{
+			"unlocked_at": now,
+			"unlocked_by": adminID,
+		}).Error
+}
+
+// ListLockouts returns the user's newest lockout events
+func (s *LoginAttemptService) ListLockouts(userID uint) ([]models.LoginLockout, error) {
+	var lockouts []models.LoginLockout
+	err := s.db.Where("user_id = ?", userID).
+		Order("created_at DESC, id DESC").
+		Limit(LoginLockoutListLimit).
+		Find(&lockouts).Error
+	return lockouts, err
+}
+
+// PruneStale drops the counters that can no longer lock anything: unlocked ones whose last
+// failure is outside both the window and the backoff reset period
+func (s *LoginAttemptService) PruneStale(now time.Time) (int64, error) {
+	retention := s.accountPolicy.Window
+	if s.accountPolicy.MaxLockout > retention {
+		retention = s.accountPolicy.MaxLockout
+	}
+	return s.limiter.Prune(context.Background(), now.Add(-retention))
+}
+
+// attemptCounter is one limiter key of a login attempt with its policy
+type attemptCounter struct {
+	key    string
+	scope  string
+	policy ratelimit.Policy
+}
+
+// counters returns the account and IP address counters of a login attempt
+func (s *LoginAttemptService) counters(email, ip string) []attemptCounter {
+	counters := []attemptCounter{{key: accountKey(email), scope: models.LockoutScopeAccount, policy: s.accountPolicy}}
+	if ip != "" {
+		counters = append(counters, attemptCounter{key: ipKey(ip), scope: models.LockoutScopeIP, policy: s.ipPolicy})
+	}
+	return counters
+}
@@ -0,0 +1,235 @@
+package services
+
+import (
+	"context"
+	"dev-bridge-manager/internal/ratelimit"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newTestLoginAttemptService(t *testing.T) *LoginAttemptService {
+	t.Helper()
+	db, _, _ := dryRunDB(t)
+	return &LoginAttemptService{
+		db:            db,
+		limiter:       ratelimit.NewMemoryLimiter(),
+		accountPolicy: ratelimit.Policy{MaxAttempts: 3, Window: 15 * time.Minute, BaseLockout: time.Minute, MaxLockout: time.Hour},
+		ipPolicy:      ratelimit.Policy{MaxAttempts: 5, Window: 15 * time.Minute, BaseLockout: time.Minute, MaxLockout: time.Hour},
+	}
+}
+
+// failLogin egy teljes sikertelen kísérlet; a visszatérés a zárolás vége, ha a kísérlet el sem indult vagy zárolt
+func failLogin(t *testing.T, s *LoginAttemptService, email, ip string, now time.Time) time.Time {
+	t.Helper()
+	attempt, until, err := s.Begin(email, ip, now)
+	if err != nil {
+		t.Fatalf("Begin(%s, %s): %v", email, ip, err)
+	}
+	if attempt == nil {
+		return until
+	}
+	if until, err = attempt.Fail(nil); err != nil {
+		t.Fatalf("Fail(%s, %s): %v", email, ip, err)
+	}
+	return until
+}
+
+// lockedUntil megnézi, elindulhatna-e egy kísérlet, a számlálók változtatása nélkül
+func lockedUntil(t *testing.T, s *LoginAttemptService, email, ip string, now time.Time) time.Time {
+	t.Helper()
+	attempt, until, err := s.Begin(email, ip, now)
+	if err != nil {
+		t.Fatalf("Begin(%s, %s): %v", email, ip, err)
+	}
+	if attempt != nil {
+		if err := attempt.Refund(); err != nil {
+			t.Fatalf("Refund: %v", err)
+		}
+	}
+	return until
+}
+
+func counterFailures(t *testing.T, s *LoginAttemptService, key string) int {
+	t.Helper()
+	state, err := s.limiter.Status(context.Background(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return state.Failures
+}
+
+func TestLoginAttemptsLockTheAccount(t *testing.T) {
+	s := newTestLoginAttemptService(t)
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+
+	// Az email kis- és nagybetűs alakja ugyanazt a számlálót növeli, különböző IP-kről is
+	emails := []string{"jane@example.com", "JANE@example.com", " Jane@Example.com "}
+	ips := []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"}
+	var until time.Time
+	for i := range emails {
+		until = failLogin(t, s, emails[i], ips[i], now)
+	}
+	if !until.Equal(now.Add(time.Minute)) {
+		t.Fatalf("account locked until %s, want %s", until, now.Add(time.Minute))
+	}
+
+	if locked := lockedUntil(t, s, "jane@example.com", "10.9.9.9", now); !locked.Equal(until) {
+		t.Errorf("attempt from another IP locked until %s, want %s", locked, until)
+	}
+	if locked := lockedUntil(t, s, "john@example.com", "10.0.0.1", now); !locked.IsZero() {
+		t.Errorf("another account is locked until %s", locked)
+	}
+	if locked := lockedUntil(t, s, "jane@example.com", "10.0.0.1", until); !locked.IsZero() {
+		t.Errorf("lock did not expire: %s", locked)
+	}
+}
+
+func TestLoginAttemptsLockTheIPAddress(t *testing.T) {
+	s := newTestLoginAttemptService(t)
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+
+	// Különböző fiókok próbálgatása egy IP-ről az IP számlálót zárolja
+	for i, email := range []string{"a@x.io", "b@x.io", "c@x.io", "d@x.io", "e@x.io"} {
+		until := failLogin(t, s, email, "10.0.0.1", now)
+		if locked := !until.IsZero(); locked != (i == 4) {
+			t.Errorf("attempt %d: locked until %s", i+1, until)
+		}
+	}
+
+	if locked := lockedUntil(t, s, "new@x.io", "10.0.0.1", now); locked.IsZero() {
+		t.Error("a new account from the locked IP is not blocked")
+	}
+	if locked := lockedUntil(t, s, "new@x.io", "10.0.0.2", now); !locked.IsZero() {
+		t.Error("another IP is blocked")
+	}
+}
+
+func TestLoginSuccessKeepsTheIPCounter(t *testing.T) {
+	s := newTestLoginAttemptService(t)
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 2; i++ {
+		failLogin(t, s, "jane@example.com", "10.0.0.1", now)
+	}
+	attempt, _, err := s.Begin("JANE@example.com", "10.0.0.1", now)
+	if err != nil || attempt == nil {
+		t.Fatalf("Begin: %v, %v", attempt, err)
+	}
+	if err := attempt.Succeed(); err != nil {
+		t.Fatal(err)
+	}
+
+	// A fiók számlálója nullázódott, az IP-é csak a sikeres kísérletet kapta vissza
+	if got := counterFailures(t, s, accountKey("jane@example.com")); got != 0 {
+		t.Errorf("account failures after a successful login = %d, want 0", got)
+	}
+	if got := counterFailures(t, s, ipKey("10.0.0.1")); got != 2 {
+		t.Errorf("IP failures after a successful login = %d, want 2", got)
+	}
+
+	// Az IP számláló megmaradt (2 hiba), így további 3 hiba már zárol
+	var until time.Time
+	for _, email := range []string{"a@example.com", "b@example.com", "c@example.com"} {
+		until = failLogin(t, s, email, "10.0.0.1", now)
+	}
+	if until.IsZero() {
+		t.Error("a successful login reset the IP counter")
+	}
+}
+
+func TestLoginAttemptsAreReservedBeforeVerification(t *testing.T) {
+	s := newTestLoginAttemptService(t)
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+
+	// Párhuzamos találgatásból csak a küszöbnyi kísérlet jut el az ellenőrzésig
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		attempts []*LoginAttempt
+	)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			attempt, _, err := s.Begin("jane@example.com", "", now)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if attempt != nil {
+				mu.Lock()
+				attempts = append(attempts, attempt)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(attempts) != s.accountPolicy.MaxAttempts {
+		t.Fatalf("%d parallel attempts got through, want %d", len(attempts), s.accountPolicy.MaxAttempts)
+	}
+	var until time.Time
+	for _, attempt := range attempts {
+		locked, err := attempt.Fail(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if locked.After(until) {
+			until = locked
+		}
+	}
+	if !until.Equal(now.Add(time.Minute)) {
+		t.Errorf("account locked until %s, want %s", until, now.Add(time.Minute))
+	}
+}
+
+func TestLoginAttemptThatLockedAndSucceededLiftsTheLock(t *testing.T) {
+	s := newTestLoginAttemptService(t)
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+
+	for _, email := range []string{"a@x.io", "b@x.io", "c@x.io", "d@x.io"} {
+		failLogin(t, s, email, "10.0.0.1", now)
+	}
+
+	// Az ötödik kísérlet foglalása zárolja az IP-t, a többi kísérlet addig vár
+	attempt, _, err := s.Begin("jane@x.io", "10.0.0.1", now)
+	if err != nil || attempt == nil {
+		t.Fatalf("Begin: %v, %v", attempt, err)
+	}
+	if locked := lockedUntil(t, s, "john@x.io", "10.0.0.1", now); locked.IsZero() {
+		t.Error("the IP is not locked while the locking attempt is verified")
+	}
+
+	// A helyes jelszó visszaadja a kísérletet, a zárolás feloldódik
+	if err := attempt.Succeed(); err != nil {
+		t.Fatal(err)
+	}
+	if locked := lockedUntil(t, s, "john@x.io", "10.0.0.1", now); !locked.IsZero() {
+		t.Errorf("the IP is still locked until %s after the attempt succeeded", locked)
+	}
+	if got := counterFailures(t, s, ipKey("10.0.0.1")); got != 4 {
+		t.Errorf("IP failures = %d, want 4", got)
+	}
+	if until := failLogin(t, s, "e@x.io", "10.0.0.1", now); until.IsZero() {
+		t.Error("the next failure did not lock the IP")
+	}
+}
+
+func TestRejectedLoginAttemptIsNotCounted(t *testing.T) {
+	s := newTestLoginAttemptService(t)
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 3; i++ {
+		failLogin(t, s, "jane@example.com", "10.0.0.1", now)
+	}
+
+	// A zárolt fiók elutasítja a kísérletet, az IP számlálóján sem marad nyoma
+	attempt, until, err := s.Begin("jane@example.com", "10.0.0.1", now)
+	if err != nil || attempt != nil || until.IsZero() {
+		t.Fatalf("Begin on a locked account = %v, %s, %v", attempt, until, err)
+	}
+	if got := counterFailures(t, s, ipKey("10.0.0.1")); got != 3 {
+		t.Errorf("IP failures = %d, want 3", got)
+	}
+}
@@ -0,0 +1,345 @@
+package services
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"dev-bridge-manager/internal/database"
+	"dev-bridge-manager/internal/models"
+	"dev-bridge-manager/internal/totp"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrMFAAlreadyEnabled is returned when starting a setup for a user who already uses MFA
+	ErrMFAAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	// ErrMFANotEnabled is returned for MFA operations of users without MFA
+	ErrMFANotEnabled = errors.New("two-factor authentication is not enabled")
+	// ErrMFANoPendingSetup is returned when confirming without a started setup
+	ErrMFANoPendingSetup = errors.New("start the two-factor setup first")
+	// ErrInvalidMFACode is returned for wrong, expired or already used codes
+	ErrInvalidMFACode = errors.New("invalid or already used verification code")
+	// ErrMFARequiredByRole is returned when disabling MFA that the user's role requires
+	ErrMFARequiredByRole = errors.New("two-factor authentication is required for your role")
+	// ErrMFANotConfigured is returned for MFA operations while MFA_ENCRYPTION_KEY is unset
+	ErrMFANotConfigured = errors.New("two-factor authentication is not configured on this server")
+)
+
+// recoveryCodeCount is how many recovery codes a user gets at once
+const recoveryCodeCount = 10
+
+var recoveryEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+type MFAService struct {
+	db     *gorm.DB
+	key    []byte
+	issuer string
+}
+
+func NewMFAService() *MFAService {
+	issuer := os.Getenv("MFA_ISSUER")
+	if issuer == "" {
+		issuer = "Dev Bridge Manager"
+	}
+
+	return &MFAService{
+		db:     database.GetDB(),
+		key:    mfaEncryptionKey(),
+		issuer: issuer,
+	}
+}
+
+// mfaEncryptionKey derives the AES key of the TOTP secrets from MFA_ENCRYPTION_KEY.
+// There is deliberately no fallback: a key shared with another secret would expose
+// every TOTP secret together with it. Nil means MFA is not configured.
+func mfaEncryptionKey() []byte {
+	secret := os.Getenv("MFA_ENCRYPTION_KEY")
+	if secret == "" {
+		return nil
+	}
+	key := sha256.Sum256([]byte(secret))
+	return key[:]
+}
+
+// MFAConfigured reports whether MFA_ENCRYPTION_KEY is set
+func MFAConfigured() bool {
+	return os.Getenv("MFA_ENCRYPTION_KEY") != ""
+}
+
+// CheckConfiguration fails when MFA is in use but MFA_ENCRYPTION_KEY is missing,
+// so the server refuses to start instead of locking those users out
+func (s *MFAService) CheckConfiguration() error {
+	if s.key != nil {
+		return nil
+	}
+
+	var users, roles int64
+	if err := s.db.Model(&models.User{}).Where("mfa_enabled OR mfa_secret IS NOT NULL").Count(&users).Error; err != nil {
+		return err
+	}
+	if err := s.db.Model(&models.Role{}).Where("mfa_required").Count(&roles).Error; err != nil {
+		return err
+	}
+	if users > 0 || roles > 0 {
+		return fmt.Errorf("MFA_ENCRYPTION_KEY is not set, but %d users and %d roles use two-factor authentication", users, roles)
+	}
+	return nil
+}
+
+// encryptSecret encrypts a TOTP secret with AES-GCM for storage
+func (s *MFAService) encryptSecret(secret string) (string, error) {
+	if s.key == nil {
+		return "", ErrMFANotConfigured
+	}
+	block, err := aes.NewCipher(s.key)
+	if err != nil {
+		return "", err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := gcm.Seal(nonce, nonce, []byte(secret), nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// decryptSecret decrypts a stored TOTP secret
+func (s *MFAService) decryptSecret(stored string) (string, error) {
+	if s.key == nil {
+		return "", ErrMFANotConfigured
+	}
+	data, err := base64.StdEncoding.DecodeString(stored)
+	if err != nil {
+		return "", err
+	}
+	block, err := aes.NewCipher(s.key)
+	if err != nil {
+		return "", err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", err
+	}
+	if len(data) < gcm.NonceSize() {
+		return "", errors.New("stored MFA secret is corrupt")
+	}
+	plain, err := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", err
+	}
+	return string(plain), nil
+}
+
+// normalizeRecoveryCode drops separators and case, so codes can be typed either way
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
+
+// hashRecoveryCode returns the stored form of a recovery code
+func hashRecoveryCode(code string) string {
+	sum := sha256.Sum256([]byte(normalizeRecoveryCode(code)))
+	return hex.EncodeToString(sum[:])
+}
+
+// BeginSetup generates a new pending TOTP secret for the user and returns it with its
+// provisioning URI. MFA stays disabled until ConfirmSetup; starting again replaces the secret.
+func (s *MFAService) BeginSetup(user *models.User) (string, string, error) {
+	if user.MFAEnabled {
+		return "", "", ErrMFAAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return "", "", err
+	}
+	encrypted, err := s.encryptSecret(secret)
+	if err != nil {
+		return "", "", err
+	}
+	if err := s.db.Model(user).Update("mfa_secret", encrypted).Error; err != nil {
+		return "", "", err
+	}
+	return secret, totp.ProvisioningURI(secret, s.issuer, user.Email), nil
+}
+
+// ConfirmSetup enables MFA when the code matches the pending secret and returns the new recovery codes
+func (s *MFAService) ConfirmSetup(user *models.User, code string) ([]string, error) {
+	if user.MFAEnabled {
+		return nil, ErrMFAAlreadyEnabled
+	}
+	if user.MFASecret == "" {
+		return nil, ErrMFANoPendingSetup
+	}
+
+	secret, err := s.decryptSecret(user.MFASecret)
+	if err != nil {
+		return nil, err
+	}
+	step, ok := totp.Validate(secret, code, time.Now())
+	if !ok {
+		return nil, ErrInvalidMFACode
+	}
+
+	var codes []string
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(user).Updates(map[string]interface{}{
+			"mfa_enabled":    true,
+			"mfa_enabled_at": time.Now(),
+			"mfa_last_step":  step,
+		}).Error
+		if err != nil {
+			return err
+		}
+		codes, err = s.replaceRecoveryCodes(tx, user.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	user.MFAEnabled = true
+	return codes, nil
+}
+
+// VerifyCode checks a TOTP code of a user with MFA enabled. A code is accepted once: the
+// matching time step is stored and codes of that or earlier steps are rejected afterwards.
+func (s *MFAService) VerifyCode(user *models.User, code string) error {
+	if !user.MFAEnabled || user.MFASecret == "" {
+		return ErrMFANotEnabled
+	}
+
+	secret, err := s.decryptSecret(user.MFASecret)
+	if err != nil {
+		return err
+	}
+	step, ok := totp.Validate(secret, code, time.Now())
+	if !ok {
+		return ErrInvalidMFACode
+	}
+
+	// Feltételes frissítés: párhuzamos kérésekből is csak egy használhatja fel a kódot
+	result := s.db.Model(&models.User{}).
+		Where("id = ? AND (mfa_last_step IS NULL OR mfa_last_step < ?)", user.ID, step).
+		Update("mfa_last_step", step)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrInvalidMFACode
+	}
+	return nil
+}
+
+// UseRecoveryCode consumes one of the user's unused recovery codes
+func (s *MFAService) UseRecoveryCode(user *models.User, code string) error {
+	if !user.MFAEnabled {
+		return ErrMFANotEnabled
+	}
+
+	result := s.db.Model(&models.UserRecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", user.ID, hashRecoveryCode(code)).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrInvalidMFACode
+	}
+	return nil
+}
+
+// VerifySecondFactor accepts either a TOTP code or a recovery code
+func (s *MFAService) VerifySecondFactor(user *models.User, code, recoveryCode string) error {
+	if strings.TrimSpace(code) != "" {
+		return s.VerifyCode(user, code)
+	}
+	if strings.TrimSpace(recoveryCode) != "" {
+		return s.UseRecoveryCode(user, recoveryCode)
+	}
+	return ErrInvalidMFACode
+}
+
+// RegenerateRecoveryCodes replaces all recovery codes of the user
+func (s *MFAService) RegenerateRecoveryCodes(user *models.User) ([]string, error) {
+	if !user.MFAEnabled {
+		return nil, ErrMFANotEnabled
+	}
+
+	var codes []string
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		codes, err = s.replaceRecoveryCodes(tx, user.ID)
+		return err
+	})
+	return codes, err
+}
+
+// RemainingRecoveryCodes counts the user's unused recovery codes
+func (s *MFAService) RemainingRecoveryCodes(userID uint) (int64, error) {
+	var count int64
+	err := s.db.Model(&models.UserRecoveryCode{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Count(&count).Error
+	return count, err
+}
+
+// Disable turns MFA off and drops the secret and recovery codes. Users whose role requires MFA
+// cannot disable it themselves; pass force for an administrator reset.
+func (s *MFAService) Disable(user *models.User, force bool) error {
+	if !force && user.Role.MFARequired {
+		return ErrMFARequiredByRole
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.User{}).Where("id = ?", user.ID).Updates(map[string]interface{}{
+			"mfa_enabled":    false,
+			"mfa_secret":     nil,
+			"mfa_enabled_at": nil,
+			"mfa_last_step":  nil,
+		}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Where("user_id = ?", user.ID).Delete(&models.UserRecoveryCode{}).Error
+	})
+}
+
+// replaceRecoveryCodes deletes the user's recovery codes and stores a new set, returning them in plain text
+func (s *MFAService) replaceRecoveryCodes(tx *gorm.DB, userID uint) ([]string, error) {
+	if err := tx.Where("user_id = ?", userID).Delete(&models.UserRecoveryCode{}).Error; err != nil {
+		return nil, err
+	}
+
+	codes := make([]string, 0, recoveryCodeCount)
+	rows := make([]models.UserRecoveryCode, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		buf := make([]byte, 7)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, err
+		}
+		// 10 karakter (50 bit) két ötös csoportban, pl. "k3xq7-p2m9a"
+		raw := strings.ToLower(recoveryEncoding.EncodeToString(buf))[:10]
+		code := raw[:5] + "-" + raw[5:]
+		codes = append(codes, code)
+		rows = append(rows, models.UserRecoveryCode{UserID: userID, CodeHash: hashRecoveryCode(code)})
+	}
+
+	if err := tx.Create(&rows).Error; err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
@@ -0,0 +1,197 @@
+package services
+
+import (
+	"dev-bridge-manager/internal/models"
+	"dev-bridge-manager/internal/totp"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func newTestMFAService(t *testing.T) *MFAService {
+	t.Helper()
+	t.Setenv("MFA_ENCRYPTION_KEY", "test-key")
+	return &MFAService{key: mfaEncryptionKey(), issuer: "Test"}
+}
+
+// dryRunDB returns a Postgres GORM handle that only builds statements, and the last UPDATE it built
+func dryRunDB(t *testing.T) (*gorm.DB, *string, *[]interface{}) {
+	t.Helper()
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=127.0.0.1 port=1"}), &gorm.Config{
+		DryRun:                 true,
+		SkipDefaultTransaction: true,
+		DisableAutomaticPing:   true,
+	})
+	if err != nil {
+		t.Fatalf("open dry run db: %v", err)
+	}
+	var sql string
+	var vars []interface{}
+	err = db.Callback().Update().After("gorm:update").Register("test:capture", func(tx *gorm.DB) {
+		sql = tx.Statement.SQL.String()
+		vars = tx.Statement.Vars
+	})
+	if err != nil {
+		t.Fatalf("register callback: %v", err)
+	}
+	return db, &sql, &vars
+}
+
+func TestSecretEncryptionRoundTrip(t *testing.T) {
+	s := newTestMFAService(t)
+
+	stored, err := s.encryptSecret("JBSWY3DPEHPK3PXP")
+	if err != nil {
+		t.Fatalf("encryptSecret: %v", err)
+	}
+	if strings.Contains(stored, "JBSWY3DPEHPK3PXP") {
+		t.Error("secret is stored in plain text")
+	}
+	if again, _ := s.encryptSecret("JBSWY3DPEHPK3PXP"); again == stored {
+		t.Error("encryption is not randomized")
+	}
+
+	plain, err := s.decryptSecret(stored)
+	if err != nil || plain != "JBSWY3DPEHPK3PXP" {
+		t.Errorf("decryptSecret = %q, %v", plain, err)
+	}
+
+	// Más kulccsal vagy módosított adattal a visszafejtés sikertelen
+	t.Setenv("MFA_ENCRYPTION_KEY", "other-key")
+	other := &MFAService{key: mfaEncryptionKey()}
+	if _, err := other.decryptSecret(stored); err == nil {
+		t.Error("secret decrypted with another key")
+	}
+	tampered := []byte(stored)
+	tampered[len(tampered)/2] ^= 1
+	if _, err := s.decryptSecret(string(tampered)); err == nil {
+		t.Error("tampered secret decrypted")
+	}
+	if _, err := s.decryptSecret("AAAA"); err == nil {
+		t.Error("truncated secret decrypted")
+	}
+}
+
+func TestSecretEncryptionRequiresKey(t *testing.T) {
+	t.Setenv("MFA_ENCRYPTION_KEY", "")
+	s := &MFAService{key: mfaEncryptionKey()}
+
+	if MFAConfigured() {
+		t.Error("MFAConfigured without a key")
+	}
+	if _, err := s.encryptSecret("x"); !errors.Is(err, ErrMFANotConfigured) {
+		t.Errorf("encryptSecret err = %v, want ErrMFANotConfigured", err)
+	}
+	if _, err := s.decryptSecret("x"); !errors.Is(err, ErrMFANotConfigured) {
+		t.Errorf("decryptSecret err = %v, want ErrMFANotConfigured", err)
+	}
+}
+
+func TestCheckConfigurationWithKey(t *testing.T) {
+	// Beállított kulcs mellett az adatbázist sem kell lekérdezni
+	if err := newTestMFAService(t).CheckConfiguration(); err != nil {
+		t.Errorf("CheckConfiguration = %v", err)
+	}
+}
+
+func TestVerifyCodeRejectsReplayedSteps(t *testing.T) {
+	s := newTestMFAService(t)
+	db, sql, vars := dryRunDB(t)
+	s.db = db
+
+	secret, _ := totp.GenerateSecret()
+	stored, err := s.encryptSecret(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	user := &models.User{ID: 3, MFAEnabled: true, MFASecret: stored}
+	now := time.Now()
+	code, _ := totp.Code(secret, totp.Step(now))
+
+	// Száraz futásban egy sor sem frissül, ez ugyanaz, mintha a lépést már felhasználták volna
+	if err := s.VerifyCode(user, code); !errors.Is(err, ErrInvalidMFACode) {
+		t.Errorf("VerifyCode without an updated row = %v, want ErrInvalidMFACode", err)
+	}
+	if !strings.Contains(*sql, "mfa_last_step IS NULL OR mfa_last_step <") {
+		t.Errorf("the step is not claimed conditionally: %s", *sql)
+	}
+	step := totp.Step(now)
+	found := false
+	for _, v := range *vars {
+		if v == step {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("step %d is not bound in %v", step, *vars)
+	}
+}
+
+func TestVerifyCodeRejectsBeforeClaimingAStep(t *testing.T) {
+	s := newTestMFAService(t)
+	db, sql, _ := dryRunDB(t)
+	s.db = db
+
+	secret, _ := totp.GenerateSecret()
+	stored, _ := s.encryptSecret(secret)
+
+	if err := s.VerifyCode(&models.User{ID: 3, MFASecret: stored}, "123456"); !errors.Is(err, ErrMFANotEnabled) {
+		t.Errorf("disabled MFA: err = %v, want ErrMFANotEnabled", err)
+	}
+	if err := s.VerifyCode(&models.User{ID: 3, MFAEnabled: true, MFASecret: stored}, "12345"); !errors.Is(err, ErrInvalidMFACode) {
+		t.Errorf("short code: err = %v, want ErrInvalidMFACode", err)
+	}
+	if *sql != "" {
+		t.Errorf("an invalid code claimed a step: %s", *sql)
+	}
+	if err := s.VerifySecondFactor(&models.User{ID: 3, MFAEnabled: true}, " ", ""); !errors.Is(err, ErrInvalidMFACode) {
+		t.Errorf("empty second factor: err = %v, want ErrInvalidMFACode", err)
+	}
+}
+
+func TestRecoveryCodeNormalization(t *testing.T) {
+	want := hashRecoveryCode("abcd-efgh")
+	for _, code := range []string{"ABCD-EFGH", " abcdefgh ", "abcd efgh", "AbCd-EfGh"} {
+		if got := hashRecoveryCode(code); got != want {
+			t.Errorf("hashRecoveryCode(%q) differs from the canonical form", code)
+		}
+	}
+	if hashRecoveryCode("abcd-efgi") == want {
+		t.Error("different recovery codes share a hash")
+	}
+}
+
+func TestValidateMFAChallengeRejectsOtherTokens(t *testing.T) {
+	s := newTestAuthService()
+
+	setup, err := s.IssueMFAChallenge(&models.User{ID: 4}, MFAChallengeSetup, "")
+	if err != nil {
+		t.Fatalf("IssueMFAChallenge: %v", err)
+	}
+	access := signTestToken(t, "test-secret", accessClaims(7, time.Now().Add(time.Minute)))
+	expired := signTestToken(t, "test-secret", &MFAChallengeClaims{
+		UserID:  4,
+		Purpose: MFAChallengeVerify,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(-time.Second)),
+			Audience:  jwt.ClaimStrings{mfaAudience},
+		},
+	})
+
+	// Ezek az adatbázis elérése előtt elbuknak
+	tests := map[string]string{
+		"setup challenge used for verification": setup,
+		"access token":                          access,
+		"expired challenge":                     expired,
+	}
+	for name, token := range tests {
+		if _, _, err := s.ValidateMFAChallenge(token, MFAChallengeVerify); !errors.Is(err, ErrInvalidMFAChallenge) {
+			t.Errorf("%s: err = %v, want ErrInvalidMFAChallenge", name, err)
+		}
+	}
+}
@@ -0,0 +1,252 @@
+package services
+
+import (
+	"dev-bridge-manager/internal/database"
+	"dev-bridge-manager/internal/models"
+	"fmt"
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Határidő emlékeztető küszöbök (task_reminders.threshold)
+const (
+	reminderDueSoon    = "due_soon"
+	reminderOverdue    = "overdue"
+	reminderEscalation = "escalation"
+)
+
+// NotificationListLimit is the default and maximum number of notifications returned at once
+const NotificationListLimit = 100
+
+// claimRemindersSQL records the reminder of every open task of an active project matching the
+// condition and returns the tasks that did not have it yet. The unique (task, due date, threshold)
+// index makes the claim atomic, so each reminder is sent once even with several server instances.
+const claimRemindersSQL = `INSERT INTO task_reminders (task_id, due_date, threshold)
+	SELECT tasks.id, tasks.due_date, ?
+	FROM tasks
+	JOIN kanban_columns ON kanban_columns.id = tasks.column_id
+	JOIN projects ON projects.id = tasks.project_id
+	WHERE tasks.archived_at IS NULL
+		AND tasks.due_date IS NOT NULL
+		AND kanban_columns.category <> 'done'
+		AND projects.status = 'active'
+		AND %s
+	ON CONFLICT (task_id, due_date, threshold) DO NOTHING
+	RETURNING task_id`
+
+// ReminderResult counts the notifications created by one reminder run
+type ReminderResult struct {
+	DueSoon     int
+	Overdue     int
+	Escalations int
+}
+
+type NotificationService struct {
+	db *gorm.DB
+}
+
+func NewNotificationService() *NotificationService {
+	return &NotificationService{
+		db: database.GetDB(),
+	}
+}
+
+// ListNotifications returns the user's newest notifications
+func (s *NotificationService) ListNotifications(userID uint, unreadOnly bool, limit int) ([]models.Notification, error) {
+	if limit <= 0 || limit > NotificationListLimit {
+		limit = NotificationListLimit
+	}
+
+	query := s.db.Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+
+	var notifications []models.Notification
+	err := query.Order("created_at DESC, id DESC").Limit(limit).Find(&notifications).Error
+	return notifications, err
+}
+
+// UnreadCount returns how many unread notifications the user has
+func (s *NotificationService) UnreadCount(userID uint) (int64, error) {
+	var count int64
+	err := s.db.Model(&models.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Count(&count).Error
+	return count, err
+}
+
+// MarkRead marks one of the user's notifications as read
+func (s *NotificationService) MarkRead(userID, notificationID uint) error {
+	result := s.db.Model(&models.Notification{}).
+		Where("id = ? AND user_id = ?", notificationID, userID).
+		Update("read_at", gorm.Expr("COALESCE(read_at, NOW())"))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// MarkAllRead marks every unread notification of the user as read
+func (s *NotificationService) MarkAllRead(userID uint) (int64, error) {
+	result := s.db.Model(&models.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Update("read_at", gorm.Expr("NOW()"))
+	return result.RowsAffected, result.Error
+}
+
+// SendDueReminders notifies assignees about open tasks due within the window and about overdue
+// tasks, and escalates tasks overdue for at least escalateAfter to the project's managers and
+// owners. Every threshold fires once per task and due date; moving the due date re-arms them.
+// A zero escalateAfter disables escalation.
+func (s *NotificationService) SendDueReminders(now time.Time, window, escalateAfter time.Duration) (*ReminderResult, error) {
+	now = now.UTC()
+	result := &ReminderResult{}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		// Már lejárt task nem kap "hamarosan lejár" emlékeztetőt
+		dueSoon, err := s.claimReminders(tx, reminderDueSoon,
+			"tasks.assignee_id IS NOT NULL AND tasks.due_date > ? AND tasks.due_date <= ?", now, now.Add(window))
+		if err != nil {
+			return err
+		}
+		if result.DueSoon, err = s.notifyAssignees(tx, dueSoon, models.NotificationTaskDueSoon, now); err != nil {
+			return err
+		}
+
+		overdue, err := s.claimReminders(tx, reminderOverdue,
+			"tasks.assignee_id IS NOT NULL AND tasks.due_date <= ?", now)
+		if err != nil {
+			return err
+		}
+		if result.Overdue, err = s.notifyAssignees(tx, overdue, models.NotificationTaskOverdue, now); err != nil {
+			return err
+		}
+
+		if escalateAfter <= 0 {
+			return nil
+		}
+		escalated, err := s.claimReminders(tx, reminderEscalation, "tasks.due_date <= ?", now.Add(-escalateAfter))
+		if err != nil {
+			return err
+		}
+		result.Escalations, err = s.notifyManagers(tx, escalated, now)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// claimReminders records the threshold for the matching tasks and loads the newly claimed ones
+func (s *NotificationService) claimReminders(tx *gorm.DB, threshold, condition string, args ...interface{}) ([]models.Task, error) {
+	var taskIDs []uint
+	params := append([]interface{}{threshold}, args...)
+	if err := tx.Raw(fmt.Sprintf(claimRemindersSQL, condition), params...).Scan(&taskIDs).Error; err != nil {
+		return nil, err
+	}
+	if len(taskIDs) == 0 {
+		return nil, nil
+	}
+
+	var tasks []models.Task
+	err := tx.Preload("Project").Where("id IN ?", taskIDs).Order("id ASC").Find(&tasks).Error
+	return tasks, err
+}
+
+// notifyAssignees creates one notification per task for its assignee
+func (s *NotificationService) notifyAssignees(tx *gorm.DB, tasks []models.Task, notificationType string, now time.Time) (int, error) {
+	notifications := make([]models.Notification, 0, len(tasks))
+	for i := range tasks {
+		task := &tasks[i]
+		if task.AssigneeID == nil {
+			continue
+		}
+		notifications = append(notifications, taskNotification(task, *task.AssigneeID, notificationType, now))
+	}
+	return len(notifications), createNotifications(tx, notifications)
+}
+
+// notifyManagers creates an escalation notification for every active manager and owner of the
+// task's project
+func (s *NotificationService) notifyManagers(tx *gorm.DB, tasks []models.Task, now time.Time) (int, error) {
+	if len(tasks) == 0 {
+		return 0, nil
+	}
+
+	projectIDs := make([]uint, 0, len(tasks))
+	for i := range tasks {
+		projectIDs = append(projectIDs, tasks[i].ProjectID)
+	}
+
+	var assignments []models.ProjectAssignment
+	err := tx.Where("project_id IN ? AND role IN ? AND is_active = ?", projectIDs, []string{"manager", "owner"}, true).
+		Find(&assignments).Error
+	if err != nil {
+		return 0, err
+	}
+	managers := make(map[uint][]uint)
+	for _, assignment := range assignments {
+		managers[assignment.ProjectID] = append(managers[assignment.ProjectID], assignment.UserID)
+	}
+
+	var notifications []models.Notification
+	for i := range tasks {
+		task := &tasks[i]
+		for _, userID := range managers[task.ProjectID] {
+			notifications = append(notifications, taskNotification(task, userID, models.NotificationTaskEscalation, now))
+		}
+	}
+	return len(notifications), createNotifications(tx, notifications)
+}
+
+// taskNotification builds the due date notification of a task
+func taskNotification(task *models.Task, userID uint, notificationType string, now time.Time) models.Notification {
+	due := task.DueDate.UTC().Format("2006-01-02 15:04 UTC")
+
+	// A task címe az üzenetbe kerül, így a cím a 255 karakteres korlát alatt marad
+	var title, message string
+	switch notificationType {
+	case models.NotificationTaskDueSoon:
+		title = "Task due soon"
+		message = fmt.Sprintf("%q in project %q is due on %s.", task.Title, task.Project.Name, due)
+	case models.NotificationTaskOverdue:
+		title = "Task overdue"
+		message = fmt.Sprintf("%q in project %q was due on %s.", task.Title, task.Project.Name, due)
+	default:
+		days := int(math.Floor(now.Sub(*task.DueDate).Hours() / 24))
+		unit := "days"
+		if days == 1 {
+			unit = "day"
+		}
+		title = fmt.Sprintf("Task overdue for %d %s", days, unit)
+		message = fmt.Sprintf("%q in project %q was due on %s and is still open.", task.Title, task.Project.Name, due)
+		if task.AssigneeID == nil {
+			message += " The task has no assignee."
+		}
+	}
+
+	projectID, taskID := task.ProjectID, task.ID
+	return models.Notification{
+		UserID:    userID,
+		ProjectID: &projectID,
+		TaskID:    &taskID,
+		Type:      notificationType,
+		Title:     title,
+		Message:   message,
+	}
+}
+
+// createNotifications inserts the notifications in one statement
+func createNotifications(tx *gorm.DB, notifications []models.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+	return tx.Create(&notifications).Error
+}
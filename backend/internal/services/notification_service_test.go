@@ -0,0 +1,60 @@
+package services
+
+import (
+	"dev-bridge-manager/internal/models"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTaskNotification(t *testing.T) {
+	due := time.Date(2026, 3, 2, 9, 30, 0, 0, time.FixedZone("CET", 60*60))
+	assignee := uint(3)
+	task := &models.Task{
+		ID:         11,
+		ProjectID:  5,
+		Title:      "Fix login",
+		DueDate:    &due,
+		AssigneeID: &assignee,
+		Project:    models.Project{Name: "Bridge"},
+	}
+
+	tests := []struct {
+		name      string
+		typ       string
+		now       time.Time
+		wantTitle string
+		wantText  string
+	}{
+		{"due soon", models.NotificationTaskDueSoon, due.Add(-time.Hour), "Task due soon", `"Fix login" in project "Bridge" is due on 2026-03-02 08:30 UTC.`},
+		{"overdue", models.NotificationTaskOverdue, due.Add(time.Hour), "Task overdue", `"Fix login" in project "Bridge" was due on 2026-03-02 08:30 UTC.`},
+		{"escalation after one day", models.NotificationTaskEscalation, due.Add(30 * time.Hour), "Task overdue for 1 day", "is still open."},
+		{"escalation after days", models.NotificationTaskEscalation, due.Add(74 * time.Hour), "Task overdue for 3 days", "is still open."},
+	}
+
+	for _, tt := range tests {
+		n := taskNotification(task, 9, tt.typ, tt.now)
+		if n.Title != tt.wantTitle || !strings.Contains(n.Message, tt.wantText) {
+			t.Errorf("%s: got %q / %q, want %q / containing %q", tt.name, n.Title, n.Message, tt.wantTitle, tt.wantText)
+		}
+		if n.UserID != 9 || n.Type != tt.typ || n.ProjectID == nil || *n.ProjectID != 5 || n.TaskID == nil || *n.TaskID != 11 {
+			t.Errorf("%s: notification fields = %+v", tt.name, n)
+		}
+	}
+}
+
+func TestTaskNotificationEscalationWithoutAssignee(t *testing.T) {
+	due := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	task := &models.Task{ID: 1, ProjectID: 1, Title: "Orphan", DueDate: &due}
+
+	n := taskNotification(task, 2, models.NotificationTaskEscalation, due.Add(50*time.Hour))
+	if !strings.HasSuffix(n.Message, " The task has no assignee.") {
+		t.Errorf("message = %q, want the missing assignee noted", n.Message)
+	}
+
+	// A hosszú task cím csak az üzenetbe kerül, a cím rövid marad
+	task.Title = strings.Repeat("x", 300)
+	if n := taskNotification(task, 2, models.NotificationTaskOverdue, due); len(n.Title) > 255 {
+		t.Errorf("title is %d characters long", len(n.Title))
+	}
+}
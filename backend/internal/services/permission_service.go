@@ -4,28 +4,57 @@ import (
 	"dev-bridge-manager/internal/database"
 	"dev-bridge-manager/internal/models"
 	"gorm.io/gorm"
+	"os"
+	"strings"
 )
 
+// defaultUnverifiedPermissions is what accounts with an unverified email keep by default
+const defaultUnverifiedPermissions = "profile.read,profile.update"
+
 type PermissionService struct {
-	db *gorm.DB
+	db                    *gorm.DB
+	unverifiedPermissions []string
 }
 
 func NewPermissionService() *PermissionService {
+	// UNVERIFIED_PERMISSIONS: vesszővel elválasztott lista; a szerepkör ezeken felüli jogosultságai
+	// csak az email cím megerősítése után érvényesek
+	value, ok := os.LookupEnv("UNVERIFIED_PERMISSIONS")
+	if !ok {
+		value = defaultUnverifiedPermissions
+	}
+	var unverified []string
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			unverified = append(unverified, name)
+		}
+	}
+
 	return &PermissionService{
-		db: database.GetDB(),
+		db:                    database.GetDB(),
+		unverifiedPermissions: unverified,
 	}
 }
 
-// GetUserWithPermissions gets user with role and permissions loaded
+// GetUserWithPermissions gets user with role and permissions loaded.
+// Unverified accounts only get the permissions allowed by UNVERIFIED_PERMISSIONS.
 func (s *PermissionService) GetUserWithPermissions(userID uint) (*models.User, error) {
 	var user models.User
 	err := s.db.Preload("Role.Permissions").First(&user, userID).Error
 	if err != nil {
 		return nil, err
 	}
+	s.LimitUnverified(&user)
 	return &user, nil
 }
 
+// LimitUnverified drops the loaded permissions an unverified account may not use yet
+func (s *PermissionService) LimitUnverified(user *models.User) {
+	if !user.EmailVerified() {
+		user.RestrictPermissions(s.unverifiedPermissions)
+	}
+}
+
 // CheckUserPermission checks if user has specific permission
 func (s *PermissionService) CheckUserPermission(userID uint, permissionName string) (bool, error) {
 	user, err := s.GetUserWithPermissions(userID)
@@ -82,3 +111,83 @@ func (s *PermissionService) AssignPermissionsToRole(roleID uint, permissionIDs [
 func (s *PermissionService) AssignRoleToUser(userID, roleID uint) error {
 	return s.db.Model(&models.User{}).Where("id = ?", userID).Update("role_id", roleID).Error
 }
+
+// projectRoleRanks orders project roles from least to most privileged
+var projectRoleRanks = map[string]int{
+	"viewer":   1,
+	"member":   2,
+	"reviewer": 3,
+	"manager":  4,
+	"owner":    5,
+}
+
+// IsAdmin checks if user has a system-wide admin role and a verified email
+func (s *PermissionService) IsAdmin(userID uint) (bool, error) {
+	user, err := s.GetUserWithPermissions(userID)
+	if err != nil {
+		return false, err
+	}
+	// Megerősítetlen email címmel az admin szerepkör sem ad projekt hozzáférést
+	return user.EmailVerified() && (user.HasRole("admin") || user.HasRole("super_admin")), nil
+}
+
+// GetProjectRole returns the user's active role on a project, or "" if not assigned
+func (s *PermissionService) GetProjectRole(userID, projectID uint) (string, error) {
+	var assignment models.ProjectAssignment
+	err := s.db.Where("project_id = ? AND user_id = ? AND is_active = ?", projectID, userID, true).
+		Limit(1).Find(&assignment).Error
+	if err != nil {
+		return "", err
+	}
+	return assignment.Role, nil
+}
+
+// VisibleProjects reports which of the given projects the user can see (any active role).
+// Admins and super admins can see every project.
+func (s *PermissionService) VisibleProjects(userID uint, projectIDs []uint) (map[uint]bool, error) {
+	visible := make(map[uint]bool, len(projectIDs))
+	if len(projectIDs) == 0 {
+		return visible, nil
+	}
+
+	isAdmin, err := s.IsAdmin(userID)
+	if err != nil {
+		return nil, err
+	}
+	if isAdmin {
+		for _, id := range projectIDs {
+			visible[id] = true
+		}
+		return visible, nil
+	}
+
+	var assigned []uint
+	err = s.db.Model(&models.ProjectAssignment{}).
+		Where("user_id = ? AND is_active = ? AND project_id IN ?", userID, true, projectIDs).
+		Pluck("project_id", &assigned).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range assigned {
+		visible[id] = true
+	}
+	return visible, nil
+}
+
+// HasProjectRole checks if user has at least minRole on a project.
+// Admins and super admins have access to every project.
+func (s *PermissionService) HasProjectRole(userID, projectID uint, minRole string) (bool, error) {
+	isAdmin, err := s.IsAdmin(userID)
+	if err != nil {
+		return false, err
+	}
+	if isAdmin {
+		return true, nil
+	}
+
+	role, err := s.GetProjectRole(userID, projectID)
+	if err != nil {
+		return false, err
+	}
+	return role != "" && projectRoleRanks[role] >= projectRoleRanks[minRole], nil
+}
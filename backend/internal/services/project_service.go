@@ -0,0 +1,41 @@
+package services
+
+import (
+	"context"
+	"dev-bridge-manager/internal/database"
+	"dev-bridge-manager/internal/models"
+	"dev-bridge-manager/internal/storage"
+
+	"gorm.io/gorm"
+)
+
+type ProjectService struct {
+	db      *gorm.DB
+	storage storage.Storage
+}
+
+func NewProjectService() *ProjectService {
+	return &ProjectService{
+		db:      database.GetDB(),
+		storage: storage.Default(),
+	}
+}
+
+// DeleteProject deletes a project with everything that cascades from it.
+// The stored files of its attachments are removed after the commit.
+func (s *ProjectService) DeleteProject(ctx context.Context, projectID uint) error {
+	var keys []string
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if keys, err = attachmentKeys(tx, "project_id = ?", projectID); err != nil {
+			return err
+		}
+		return tx.Delete(&models.Project{}, projectID).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	removeStoredFiles(ctx, s.storage, keys)
+	return nil
+}
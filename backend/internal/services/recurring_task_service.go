@@ -0,0 +1,300 @@
+package services
+
+import (
+	"dev-bridge-manager/internal/database"
+	"dev-bridge-manager/internal/models"
+	"dev-bridge-manager/internal/sanitize"
+	"dev-bridge-manager/internal/schedule"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrInvalidTimezone is returned for unknown IANA timezone names
+var ErrInvalidTimezone = errors.New("invalid timezone")
+
+// ErrScheduleNeverRuns is returned for schedules without any upcoming run (e.g. "0 0 30 2 *")
+var ErrScheduleNeverRuns = errors.New("the schedule has no upcoming runs")
+
+// recurringSchedulerLockKey identifies the scheduler's transaction level advisory lock,
+// so only one server instance creates recurring tasks at a time
+const recurringSchedulerLockKey = "recurring_task_scheduler"
+
+// recurringBatchSize limits how many due templates one scheduler run processes
+const recurringBatchSize = 100
+
+type RecurringTaskService struct {
+	db            *gorm.DB
+	taskService   *TaskService
+	kanbanService *KanbanService
+}
+
+func NewRecurringTaskService() *RecurringTaskService {
+	return &RecurringTaskService{
+		db:            database.GetDB(),
+		taskService:   NewTaskService(),
+		kanbanService: NewKanbanService(),
+	}
+}
+
+// NextRun returns the first run of the schedule strictly after the given time, in UTC.
+// The cron expression is evaluated in the given timezone (empty means UTC).
+func NextRun(expr, timezone string, after time.Time) (time.Time, error) {
+	sched, err := schedule.Parse(expr)
+	if err != nil {
+		return time.Time{}, err
+	}
+	loc, err := loadTimezone(timezone)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	next := sched.Next(after.In(loc))
+	if next.IsZero() {
+		return time.Time{}, ErrScheduleNeverRuns
+	}
+	return next.UTC(), nil
+}
+
+// loadTimezone loads an IANA timezone, defaulting to UTC
+func loadTimezone(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidTimezone, name)
+	}
+	return loc, nil
+}
+
+// UpcomingRuns returns the template's next n run times starting at its next_run_at.
+// Inactive templates have no upcoming runs.
+func (s *RecurringTaskService) UpcomingRuns(template *models.RecurringTaskTemplate, n int) []time.Time {
+	runs := []time.Time{}
+	if !template.IsActive || n <= 0 {
+		return runs
+	}
+
+	sched, err := schedule.Parse(template.Schedule)
+	if err != nil {
+		return runs
+	}
+	loc, err := loadTimezone(template.Timezone)
+	if err != nil {
+		return runs
+	}
+
+	next := template.NextRunAt.UTC()
+	for len(runs) < n && !next.IsZero() {
+		runs = append(runs, next)
+		next = sched.Next(next.In(loc)).UTC()
+	}
+	return runs
+}
+
+// ToResponse attaches the next n run times to the template
+func (s *RecurringTaskService) ToResponse(template *models.RecurringTaskTemplate, n int) models.RecurringTaskResponse {
+	return models.RecurringTaskResponse{
+		RecurringTaskTemplate: *template,
+		UpcomingRuns:          s.UpcomingRuns(template, n),
+	}
+}
+
+// ListTemplates returns the project's recurring task templates
+func (s *RecurringTaskService) ListTemplates(projectID uint) ([]models.RecurringTaskTemplate, error) {
+	var templates []models.RecurringTaskTemplate
+	err := s.db.Where("project_id = ?", projectID).
+		Order("title ASC, id ASC").
+		Find(&templates).Error
+	return templates, err
+}
+
+// LoadTemplate returns a template only if it belongs to the project
+func (s *RecurringTaskService) LoadTemplate(projectID, templateID uint) (*models.RecurringTaskTemplate, error) {
+	var template models.RecurringTaskTemplate
+	if err := s.db.Where("id = ? AND project_id = ?", templateID, projectID).First(&template).Error; err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// CreateTemplate validates the schedule and stores the template with its first run time
+func (s *RecurringTaskService) CreateTemplate(template *models.RecurringTaskTemplate) error {
+	if template.Timezone == "" {
+		template.Timezone = "UTC"
+	}
+	next, err := NextRun(template.Schedule, template.Timezone, time.Now())
+	if err != nil {
+		return err
+	}
+	template.NextRunAt = next
+	return s.db.Create(template).Error
+}
+
+// UpdateTemplate saves the template. When the schedule or timezone changed, or the template
+// was reactivated, the next run is recomputed from now, so missed runs are not caught up.
+func (s *RecurringTaskService) UpdateTemplate(template *models.RecurringTaskTemplate, reschedule bool) error {
+	if template.Timezone == "" {
+		template.Timezone = "UTC"
+	}
+	if reschedule {
+		next, err := NextRun(template.Schedule, template.Timezone, time.Now())
+		if err != nil {
+			return err
+		}
+		template.NextRunAt = next
+	}
+	return s.db.Save(template).Error
+}
+
+// DeleteTemplate removes the template; the tasks it created are kept
+func (s *RecurringTaskService) DeleteTemplate(template *models.RecurringTaskTemplate) error {
+	return s.db.Delete(template).Error
+}
+
+// RunDueTemplates creates the tasks of every active template whose next run is due and returns
+// how many were created. The run holds a Postgres advisory lock for its transaction, so when
+// several server instances run the scheduler only one of them works at a time; the others skip.
+// Each run is also recorded in recurring_task_runs under a unique (template, scheduled time)
+// index, so a slot never produces two tasks, even across restarts. When the server was down for
+// several slots only one task is created and the template moves on to the next future slot.
+func (s *RecurringTaskService) RunDueTemplates(now time.Time) (int, error) {
+	now = now.UTC()
+	created := 0
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var locked bool
+		if err := tx.Raw("SELECT pg_try_advisory_xact_lock(hashtext(?))", recurringSchedulerLockKey).Scan(&locked).Error; err != nil {
+			return err
+		}
+		if !locked {
+			return nil
+		}
+
+		var templates []models.RecurringTaskTemplate
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("is_active = ? AND next_run_at <= ?", true, now).
+			Order("next_run_at ASC, id ASC").
+			Limit(recurringBatchSize).
+			Find(&templates).Error
+		if err != nil {
+			return err
+		}
+
+		// Sablononként külön savepoint: egy hibás sablon nem gátolja a többit
+		for i := range templates {
+			template := &templates[i]
+			var taskCreated bool
+			err := tx.Transaction(func(stx *gorm.DB) error {
+				var runErr error
+				taskCreated, runErr = s.runTemplate(stx, template, now)
+				return runErr
+			})
+			if err != nil {
+				log.Printf("❌ Recurring task template %d failed: %v", template.ID, err)
+				continue
+			}
+			if taskCreated {
+				created++
+			}
+		}
+		return nil
+	})
+	return created, err
+}
+
+// runTemplate creates the task of the template's due slot and schedules the next run.
+// It reports false when the slot already had a run.
+func (s *RecurringTaskService) runTemplate(tx *gorm.DB, template *models.RecurringTaskTemplate, now time.Time) (bool, error) {
+	scheduledFor := template.NextRunAt.UTC()
+	updates := map[string]interface{}{
+		"updated_at": now,
+	}
+
+	// Hibás ütemezésű sablon kikapcsol, különben minden futásnál újra próbálkozna
+	next, err := NextRun(template.Schedule, template.Timezone, now)
+	if err != nil {
+		log.Printf("⚠️  Recurring task template %d deactivated: %v", template.ID, err)
+		updates["is_active"] = false
+	} else {
+		updates["next_run_at"] = next
+	}
+
+	run := models.RecurringTaskRun{
+		TemplateID:   template.ID,
+		ScheduledFor: scheduledFor,
+	}
+	inserted := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&run)
+	if inserted.Error != nil {
+		return false, inserted.Error
+	}
+	if inserted.RowsAffected == 0 {
+		return false, tx.Model(template).Updates(updates).Error
+	}
+
+	task, err := s.buildTask(tx, template, scheduledFor)
+	if err != nil {
+		return false, err
+	}
+	if err := s.taskService.CreateTaskTx(tx, task); err != nil {
+		return false, err
+	}
+
+	if err := tx.Model(&run).Update("task_id", task.ID).Error; err != nil {
+		return false, err
+	}
+	updates["last_run_at"] = now
+	updates["last_task_id"] = task.ID
+	if err := tx.Model(template).Updates(updates).Error; err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// buildTask prepares the task of a run. Without a target column (or when it was deleted) the
+// task goes to the first column of the project board.
+func (s *RecurringTaskService) buildTask(tx *gorm.DB, template *models.RecurringTaskTemplate, scheduledFor time.Time) (*models.Task, error) {
+	var columnID uint
+	if template.ColumnID != nil {
+		if column, err := s.kanbanService.GetProjectColumn(tx, template.ProjectID, *template.ColumnID); err == nil {
+			columnID = column.ID
+		}
+	}
+	if columnID == 0 {
+		board, err := s.kanbanService.GetOrCreateBoardTx(tx, template.ProjectID)
+		if err != nil {
+			return nil, err
+		}
+		if len(board.Columns) == 0 {
+			return nil, errors.New("the project board has no columns")
+		}
+		columnID = board.Columns[0].ID
+	}
+
+	priority := template.Priority
+	if priority == "" {
+		priority = "medium"
+	}
+
+	task := &models.Task{
+		ProjectID:      template.ProjectID,
+		ColumnID:       columnID,
+		Title:          template.Title,
+		Description:    sanitize.PlainText(template.Description),
+		Priority:       priority,
+		AssigneeID:     template.AssigneeID,
+		EstimatedHours: template.EstimatedHours,
+		CreatedBy:      template.CreatedBy,
+		UpdatedBy:      template.CreatedBy,
+	}
+	if template.DueInDays != nil {
+		dueDate := scheduledFor.AddDate(0, 0, *template.DueInDays)
+		task.DueDate = &dueDate
+	}
+	return task, nil
+}
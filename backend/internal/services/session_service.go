@@ -0,0 +1,274 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"dev-bridge-manager/internal/database"
+	"dev-bridge-manager/internal/models"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"os"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrInvalidRefreshToken is returned for unknown, expired or revoked refresh tokens
+var ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+
+// ErrRefreshTokenReused is returned when an already rotated refresh token is presented again.
+// The whole session is revoked, since either the client or an attacker holds a stolen token.
+var ErrRefreshTokenReused = errors.New("refresh token reuse detected, the session has been revoked")
+
+// ErrTokenRevoked is returned for access tokens of revoked sessions or of an older token version
+var ErrTokenRevoked = errors.New("token has been revoked")
+
+// Session visszavonási okok
+const (
+	SessionRevokedReuse           = "reuse_detected"
+	SessionRevokedDeactivated     = "account_deactivated"
+	SessionRevokedLogout          = "logout"
+	SessionRevokedByUser          = "revoked"
+	SessionRevokedPasswordChanged = "password_changed"
+	SessionRevokedPasswordReset   = "password_reset"
+	SessionRevokedRoleChanged     = "role_changed"
+	SessionRevokedMFAReset        = "mfa_reset"
+	SessionRevokedMFARequired     = "mfa_required"
+)
+
+// SessionMeta describes the client a session was created or refreshed from
+type SessionMeta struct {
+	Device    string
+	IPAddress string
+	UserAgent string
+}
+
+type SessionService struct {
+	db         *gorm.DB
+	refreshTTL time.Duration
+}
+
+func NewSessionService() *SessionService {
+	days := 30
+	if value := os.Getenv("REFRESH_TOKEN_DAYS"); value != "" {
+		if parsed, err := strconv.Atoi(value); err == nil && parsed > 0 {
+			days = parsed
+		}
+	}
+
+	return &SessionService{
+		db:         database.GetDB(),
+		refreshTTL: time.Duration(days) * 24 * time.Hour,
+	}
+}
+
+// newRefreshToken returns a random opaque refresh token and its SHA-256 hash
+func newRefreshToken() (string, string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(buf)
+	return token, hashRefreshToken(token), nil
+}
+
+// hashRefreshToken returns the stored form of a refresh token
+func hashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// truncate cuts a client supplied value to the column size without splitting a UTF-8 sequence
+func truncate(value string, size int) string {
+	if len(value) <= size {
+		return value
+	}
+	for size > 0 && !utf8.RuneStart(value[size]) {
+		size--
+	}
+	return value[:size]
+}
+
+// CreateSession starts a new session for the user and returns it with its first refresh token
+func (s *SessionService) CreateSession(userID uint, meta SessionMeta) (*models.Session, string, error) {
+	token, hash, err := newRefreshToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	now := time.Now()
+	session := models.Session{
+		UserID:     userID,
+		Device:     truncate(meta.Device, 255),
+		IPAddress:  truncate(meta.IPAddress, 64),
+		UserAgent:  meta.UserAgent,
+		ExpiresAt:  now.Add(s.refreshTTL),
+		LastUsedAt: now,
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&session).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.SessionRefreshToken{SessionID: session.ID, TokenHash: hash}).Error
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return &session, token, nil
+}
+
+// checkRefreshToken decides whether a presented refresh token may be rotated. Tokens of inactive
+// sessions are invalid; a token that was already rotated means it was reused.
+func checkRefreshToken(session *models.Session, token *models.SessionRefreshToken, now time.Time) error {
+	if !session.IsActive(now) {
+		return ErrInvalidRefreshToken
+	}
+	if token.UsedAt != nil {
+		return ErrRefreshTokenReused
+	}
+	return nil
+}
+
+// RotateRefreshToken exchanges a refresh token for a new one in the same session and extends the
+// session's expiry. Presenting a token that was already rotated revokes the whole session.
+func (s *SessionService) RotateRefreshToken(token string, meta SessionMeta) (*models.Session, string, error) {
+	newToken, newHash, err := newRefreshToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	var session models.Session
+	reused := false
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		// A token sor zárolása: párhuzamos refresh-ből csak az egyik nyerhet
+		var current models.SessionRefreshToken
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("token_hash = ?", hashRefreshToken(token)).
+			First(&current).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrInvalidRefreshToken
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&session, current.SessionID).Error; err != nil {
+			return err
+		}
+		now := time.Now()
+		if err := checkRefreshToken(&session, &current, now); err != nil {
+			// Újrafelhasznált token: a teljes család visszavonása, a tranzakció commitolódik
+			if errors.Is(err, ErrRefreshTokenReused) {
+				reused = true
+				return s.revoke(tx, &session, SessionRevokedReuse)
+			}
+			return err
+		}
+
+		if err := tx.Model(&current).Update("used_at", now).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&models.SessionRefreshToken{SessionID: session.ID, TokenHash: newHash}).Error; err != nil {
+			return err
+		}
+
+		updates := map[string]interface{}{
+			"expires_at":   now.Add(s.refreshTTL),
+			"last_used_at": now,
+		}
+		if meta.IPAddress != "" {
+			updates["ip_address"] = truncate(meta.IPAddress, 64)
+		}
+		if meta.UserAgent != "" {
+			updates["user_agent"] = meta.UserAgent
+		}
+		return tx.Model(&session).Updates(updates).Error
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	if reused {
+		return nil, "", ErrRefreshTokenReused
+	}
+	return &session, newToken, nil
+}
+
+// RevokeSession revokes an active session with the given reason
+func (s *SessionService) RevokeSession(session *models.Session, reason string) error {
+	return s.revoke(s.db, session, reason)
+}
+
+// revoke marks the session revoked, after which none of its refresh tokens is accepted
+func (s *SessionService) revoke(tx *gorm.DB, session *models.Session, reason string) error {
+	now := time.Now()
+	err := tx.Model(session).
+		Where("revoked_at IS NULL").
+		Updates(map[string]interface{}{"revoked_at": now, "revoked_reason": reason}).Error
+	if err != nil {
+		return err
+	}
+	session.RevokedAt = &now
+	session.RevokedReason = reason
+	return nil
+}
+
+// ListActiveSessions returns the user's sessions that can still be refreshed, most recently used first
+func (s *SessionService) ListActiveSessions(userID uint) ([]models.Session, error) {
+	var sessions []models.Session
+	err := s.db.Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, time.Now()).
+		Order("last_used_at DESC, id DESC").
+		Find(&sessions).Error
+	return sessions, err
+}
+
+// RevokeUserSession revokes one of the user's active sessions
+func (s *SessionService) RevokeUserSession(userID, sessionID uint, reason string) error {
+	var session models.Session
+	err := s.db.Where("id = ? AND user_id = ? AND revoked_at IS NULL", sessionID, userID).First(&session).Error
+	if err != nil {
+		return err
+	}
+	return s.revoke(s.db, &session, reason)
+}
+
+// InvalidateUserTokens increments the user's token version, so every access token issued so far
+// is rejected, and revokes all of the user's sessions
+func (s *SessionService) InvalidateUserTokens(userID uint, reason string) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.User{}).Where("id = ?", userID).
+			Update("token_version", gorm.Expr("token_version + 1")).Error
+		if err != nil {
+			return err
+		}
+		return tx.Model(&models.Session{}).
+			Where("user_id = ? AND revoked_at IS NULL", userID).
+			Updates(map[string]interface{}{"revoked_at": time.Now(), "revoked_reason": reason}).Error
+	})
+}
+
+// CheckAccessToken rejects access tokens whose session was revoked or whose token version is
+// older than the user's current one
+func (s *SessionService) CheckAccessToken(userID, sessionID uint, tokenVersion int) error {
+	var state struct {
+		TokenVersion  int
+		SessionActive bool
+	}
+	err := s.db.Raw(`SELECT users.token_version,
+			(sessions.id IS NOT NULL AND sessions.revoked_at IS NULL) AS session_active
+		FROM users
+		LEFT JOIN sessions ON sessions.id = ? AND sessions.user_id = users.id
+		WHERE users.id = ?`, sessionID, userID).
+		Scan(&state).Error
+	if err != nil {
+		return err
+	}
+	if state.TokenVersion != tokenVersion || !state.SessionActive {
+		return ErrTokenRevoked
+	}
+	return nil
+}
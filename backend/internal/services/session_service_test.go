@@ -0,0 +1,74 @@
+package services
+
+import (
+	"dev-bridge-manager/internal/models"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewRefreshToken(t *testing.T) {
+	token, hash, err := newRefreshToken()
+	if err != nil {
+		t.Fatalf("newRefreshToken: %v", err)
+	}
+	if len(token) != 43 {
+		t.Errorf("token length = %d, want 43 (32 random bytes)", len(token))
+	}
+	if hash != hashRefreshToken(token) || len(hash) != 64 {
+		t.Errorf("hash %q does not match the token", hash)
+	}
+	if hash == token {
+		t.Error("the token is stored in plain text")
+	}
+
+	other, otherHash, _ := newRefreshToken()
+	if other == token || otherHash == hash {
+		t.Error("two refresh tokens are equal")
+	}
+}
+
+func TestCheckRefreshToken(t *testing.T) {
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Minute)
+
+	active := models.Session{ExpiresAt: now.Add(time.Hour)}
+	expired := models.Session{ExpiresAt: now}
+	revoked := models.Session{ExpiresAt: now.Add(time.Hour), RevokedAt: &past}
+
+	fresh := models.SessionRefreshToken{}
+	used := models.SessionRefreshToken{UsedAt: &past}
+
+	tests := []struct {
+		name    string
+		session models.Session
+		token   models.SessionRefreshToken
+		want    error
+	}{
+		{"current token", active, fresh, nil},
+		{"rotated token is reuse", active, used, ErrRefreshTokenReused},
+		{"expired session", expired, fresh, ErrInvalidRefreshToken},
+		{"revoked session", revoked, fresh, ErrInvalidRefreshToken},
+		// A már visszavont session régi tokenje nem vált ki újabb visszavonást
+		{"rotated token of a revoked session", revoked, used, ErrInvalidRefreshToken},
+	}
+
+	for _, tt := range tests {
+		if err := checkRefreshToken(&tt.session, &tt.token, now); !errors.Is(err, tt.want) {
+			t.Errorf("%s: err = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	if got := truncate("abcdef", 4); got != "abcd" {
+		t.Errorf("truncate = %q", got)
+	}
+	if got := truncate("abc", 4); got != "abc" {
+		t.Errorf("truncate of a short value = %q", got)
+	}
+	// Az "ő" két bájtos, a vágás nem eshet a közepére
+	if got := truncate("abőc", 3); got != "ab" {
+		t.Errorf("truncate inside a rune = %q, want %q", got, "ab")
+	}
+}
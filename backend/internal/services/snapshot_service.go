@@ -0,0 +1,253 @@
+package services
+
+import (
+	"dev-bridge-manager/internal/database"
+	"dev-bridge-manager/internal/models"
+	"fmt"
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// snapshotAggregates selects the snapshot counters of the joined, not archived tasks;
+// a task is completed when its column is a done column
+const snapshotAggregates = `COUNT(tasks.id) AS task_count,
+	COUNT(tasks.id) FILTER (WHERE kanban_columns.category = 'done') AS completed_count,
+	COALESCE(SUM(tasks.estimated_hours), 0) AS estimated_hours,
+	COALESCE(SUM(tasks.estimated_hours) FILTER (WHERE kanban_columns.category = 'done'), 0) AS completed_hours`
+
+// snapshotUpsert overwrites the day's row, so a snapshot keeps the last state of the day
+const snapshotUpsert = `DO UPDATE SET
+	task_count = EXCLUDED.task_count,
+	completed_count = EXCLUDED.completed_count,
+	estimated_hours = EXCLUDED.estimated_hours,
+	completed_hours = EXCLUDED.completed_hours,
+	updated_at = NOW()`
+
+// BurnupDefaultDays is the burnup range when no start date is given
+const BurnupDefaultDays = 30
+
+type SnapshotService struct {
+	db *gorm.DB
+}
+
+func NewSnapshotService() *SnapshotService {
+	return &SnapshotService{
+		db: database.GetDB(),
+	}
+}
+
+// SnapshotDay truncates a time to its calendar day, as stored in the DATE columns
+func SnapshotDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// sprintSnapshotSQL builds the sprint snapshot upsert for the sprints matching the condition
+func sprintSnapshotSQL(condition string) string {
+	return fmt.Sprintf(`INSERT INTO sprint_snapshots
+		(sprint_id, snapshot_date, task_count, completed_count, estimated_hours, completed_hours)
+		SELECT sprints.id, CAST(? AS DATE), %s
+		FROM sprints
+		LEFT JOIN tasks ON tasks.sprint_id = sprints.id AND tasks.archived_at IS NULL
+		LEFT JOIN kanban_columns ON kanban_columns.id = tasks.column_id
+		WHERE %s
+		GROUP BY sprints.id
+		ON CONFLICT (sprint_id, snapshot_date) %s`, snapshotAggregates, condition, snapshotUpsert)
+}
+
+// RecordSprintSnapshot stores the sprint's current state as today's snapshot
+func (s *SnapshotService) RecordSprintSnapshot(tx *gorm.DB, sprintID uint) error {
+	day := SnapshotDay(time.Now()).Format(models.TimeEntryDateLayout)
+	return tx.Exec(sprintSnapshotSQL("sprints.id = ?"), day, sprintID).Error
+}
+
+// RecordSnapshots stores today's snapshot of every active sprint and every active or on-hold project
+func (s *SnapshotService) RecordSnapshots() (int64, int64, error) {
+	day := SnapshotDay(time.Now()).Format(models.TimeEntryDateLayout)
+
+	sprints := s.db.Exec(sprintSnapshotSQL("sprints.state = ?"), day, models.SprintActive)
+	if sprints.Error != nil {
+		return 0, 0, sprints.Error
+	}
+
+	projects := s.db.Exec(fmt.Sprintf(`INSERT INTO project_snapshots
+		(project_id, snapshot_date, task_count, completed_count, estimated_hours, completed_hours)
+		SELECT projects.id, CAST(? AS DATE), %s
+		FROM projects
+		LEFT JOIN tasks ON tasks.project_id = projects.id AND tasks.archived_at IS NULL
+		LEFT JOIN kanban_columns ON kanban_columns.id = tasks.column_id
+		WHERE projects.status IN ?
+		GROUP BY projects.id
+		ON CONFLICT (project_id, snapshot_date) %s`, snapshotAggregates, snapshotUpsert),
+		day, []string{"active", "on-hold"})
+	if projects.Error != nil {
+		return sprints.RowsAffected, 0, projects.Error
+	}
+	return sprints.RowsAffected, projects.RowsAffected, nil
+}
+
+// liveCounts aggregates the current state of the not archived tasks matching the condition
+func (s *SnapshotService) liveCounts(condition string, args ...interface{}) (*models.SprintStats, error) {
+	var counts models.SprintStats
+	err := s.db.Model(&models.Task{}).
+		Select(snapshotAggregates).
+		Joins("JOIN kanban_columns ON kanban_columns.id = tasks.column_id").
+		Where("tasks.archived_at IS NULL").
+		Where(condition, args...).
+		Scan(&counts).Error
+	if err != nil {
+		return nil, err
+	}
+	return &counts, nil
+}
+
+// SprintBurndown returns one point per sprint day from the start date up to today (or the day
+// the sprint was closed). Days come from the daily snapshots, so re-estimates and scope changes
+// show on the day they happened; today's point of an active sprint is computed live.
+// Days without a snapshot repeat the previous day; planned sprints have no points.
+func (s *SnapshotService) SprintBurndown(sprint *models.Sprint) ([]models.BurndownPoint, error) {
+	if sprint.State == models.SprintPlanned {
+		return []models.BurndownPoint{}, nil
+	}
+
+	start := SnapshotDay(sprint.StartDate)
+	end := SnapshotDay(sprint.EndDate)
+	today := SnapshotDay(time.Now())
+
+	// Aktív sprint a mai napig tart (határidőn túl is), lezárt sprint a lezárás napjáig
+	last := today
+	if sprint.ClosedAt != nil {
+		last = SnapshotDay(*sprint.ClosedAt)
+	}
+
+	var snapshots []models.SprintSnapshot
+	err := s.db.Where("sprint_id = ? AND snapshot_date BETWEEN ? AND ?",
+		sprint.ID, start.Format(models.TimeEntryDateLayout), last.Format(models.TimeEntryDateLayout)).
+		Order("snapshot_date ASC").
+		Find(&snapshots).Error
+	if err != nil {
+		return nil, err
+	}
+
+	byDay := make(map[string]*models.SprintStats, len(snapshots)+1)
+	for i := range snapshots {
+		byDay[snapshots[i].SnapshotDate.Format(models.TimeEntryDateLayout)] = snapshots[i].Stats()
+	}
+	if sprint.State == models.SprintActive {
+		live, err := s.liveCounts("tasks.sprint_id = ?", sprint.ID)
+		if err != nil {
+			return nil, err
+		}
+		byDay[today.Format(models.TimeEntryDateLayout)] = live
+	}
+
+	return burndownPoints(start, end, last, byDay), nil
+}
+
+// burndownPoints builds the burndown from start to last out of the per-day counts. The ideal
+// line falls evenly from the first known scope to zero on the end date.
+func burndownPoints(start, end, last time.Time, byDay map[string]*models.SprintStats) []models.BurndownPoint {
+	points := []models.BurndownPoint{}
+	sprintDays := end.Sub(start).Hours() / 24
+	var initialHours float64
+	var current *models.SprintStats
+	for day := start; !day.After(last); day = day.AddDate(0, 0, 1) {
+		key := day.Format(models.TimeEntryDateLayout)
+		if counts, ok := byDay[key]; ok {
+			if current == nil {
+				initialHours = counts.EstimatedHours
+			}
+			current = counts
+		}
+		if current == nil {
+			continue
+		}
+
+		ideal := 0.0
+		if elapsed := day.Sub(start).Hours() / 24; sprintDays > 0 && elapsed < sprintDays {
+			ideal = math.Round(initialHours*(1-elapsed/sprintDays)*100) / 100
+		}
+
+		points = append(points, models.BurndownPoint{
+			Date:           key,
+			RemainingHours: math.Round((current.EstimatedHours-current.CompletedHours)*100) / 100,
+			RemainingCount: current.TaskCount - current.CompletedCount,
+			ScopeHours:     current.EstimatedHours,
+			ScopeCount:     current.TaskCount,
+			IdealHours:     ideal,
+		})
+	}
+	return points
+}
+
+// ProjectBurnup returns one point per day between from and to (inclusive, capped at today) with
+// the project's total scope and completed work. Today's point is computed live; days without a
+// snapshot repeat the previous day and days before the first snapshot are left out.
+func (s *SnapshotService) ProjectBurnup(projectID uint, from, to time.Time) ([]models.BurnupPoint, error) {
+	points := []models.BurnupPoint{}
+
+	from = SnapshotDay(from)
+	to = SnapshotDay(to)
+	today := SnapshotDay(time.Now())
+	if to.After(today) {
+		to = today
+	}
+	if from.After(to) {
+		return points, nil
+	}
+
+	// A kezdőnap előtti utolsó pillanatkép is kell, hogy az első napok ne maradjanak ki
+	var previous []models.ProjectSnapshot
+	err := s.db.Where("project_id = ? AND snapshot_date < ?", projectID, from.Format(models.TimeEntryDateLayout)).
+		Order("snapshot_date DESC").
+		Limit(1).
+		Find(&previous).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshots []models.ProjectSnapshot
+	err = s.db.Where("project_id = ? AND snapshot_date BETWEEN ? AND ?",
+		projectID, from.Format(models.TimeEntryDateLayout), to.Format(models.TimeEntryDateLayout)).
+		Order("snapshot_date ASC").
+		Find(&snapshots).Error
+	if err != nil {
+		return nil, err
+	}
+
+	byDay := make(map[string]*models.SprintStats, len(snapshots)+1)
+	for i := range snapshots {
+		byDay[snapshots[i].SnapshotDate.Format(models.TimeEntryDateLayout)] = snapshots[i].Stats()
+	}
+	if !to.Before(today) {
+		live, err := s.liveCounts("tasks.project_id = ?", projectID)
+		if err != nil {
+			return nil, err
+		}
+		byDay[today.Format(models.TimeEntryDateLayout)] = live
+	}
+
+	var current *models.SprintStats
+	if len(previous) > 0 {
+		current = previous[0].Stats()
+	}
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		key := day.Format(models.TimeEntryDateLayout)
+		if counts, ok := byDay[key]; ok {
+			current = counts
+		}
+		if current == nil {
+			continue
+		}
+
+		points = append(points, models.BurnupPoint{
+			Date:           key,
+			ScopeHours:     current.EstimatedHours,
+			ScopeCount:     current.TaskCount,
+			CompletedHours: current.CompletedHours,
+			CompletedCount: current.CompletedCount,
+		})
+	}
+	return points, nil
+}
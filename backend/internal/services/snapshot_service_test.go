@@ -0,0 +1,56 @@
+package services
+
+import (
+	"dev-bridge-manager/internal/models"
+	"testing"
+	"time"
+)
+
+func TestSnapshotDay(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	got := SnapshotDay(time.Date(2026, 3, 5, 23, 30, 0, 0, loc))
+	if want := time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("SnapshotDay = %s, want %s (the local calendar day)", got, want)
+	}
+}
+
+func TestBurndownPoints(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 0, 0, 0, 0, time.UTC) }
+	byDay := map[string]*models.SprintStats{
+		// Az első nap nincs pillanatkép, a második napon indul a diagram
+		"2026-03-02": {TaskCount: 4, CompletedCount: 0, EstimatedHours: 40},
+		"2026-03-04": {TaskCount: 5, CompletedCount: 2, EstimatedHours: 48, CompletedHours: 16.5},
+	}
+
+	points := burndownPoints(day(1), day(5), day(6), byDay)
+
+	want := []models.BurndownPoint{
+		{Date: "2026-03-02", RemainingHours: 40, RemainingCount: 4, ScopeHours: 40, ScopeCount: 4, IdealHours: 30},
+		{Date: "2026-03-03", RemainingHours: 40, RemainingCount: 4, ScopeHours: 40, ScopeCount: 4, IdealHours: 20},
+		{Date: "2026-03-04", RemainingHours: 31.5, RemainingCount: 3, ScopeHours: 48, ScopeCount: 5, IdealHours: 10},
+		{Date: "2026-03-05", RemainingHours: 31.5, RemainingCount: 3, ScopeHours: 48, ScopeCount: 5, IdealHours: 0},
+		{Date: "2026-03-06", RemainingHours: 31.5, RemainingCount: 3, ScopeHours: 48, ScopeCount: 5, IdealHours: 0},
+	}
+	if len(points) != len(want) {
+		t.Fatalf("got %d points, want %d: %+v", len(points), len(want), points)
+	}
+	for i := range want {
+		if points[i] != want[i] {
+			t.Errorf("point %d = %+v, want %+v", i, points[i], want[i])
+		}
+	}
+}
+
+func TestBurndownPointsWithoutSnapshots(t *testing.T) {
+	day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	if points := burndownPoints(day, day.AddDate(0, 0, 5), day.AddDate(0, 0, 2), nil); len(points) != 0 {
+		t.Errorf("got %d points without snapshots, want none", len(points))
+	}
+
+	// Egynapos sprintnél az ideális vonal nulla, nem osztunk nullával
+	byDay := map[string]*models.SprintStats{"2026-03-01": {TaskCount: 1, EstimatedHours: 8}}
+	points := burndownPoints(day, day, day, byDay)
+	if len(points) != 1 || points[0].IdealHours != 0 {
+		t.Errorf("single day sprint = %+v", points)
+	}
+}
@@ -0,0 +1,382 @@
+package services
+
+import (
+	"dev-bridge-manager/internal/database"
+	"dev-bridge-manager/internal/models"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrSprintClosed is returned when a closed sprint would be changed or given tasks
+var ErrSprintClosed = errors.New("sprint is closed")
+
+// ErrSprintNotPlanned is returned when only a planned sprint can be started or deleted
+var ErrSprintNotPlanned = errors.New("sprint is not in the planned state")
+
+// ErrSprintNotActive is returned when closing a sprint that is not running
+var ErrSprintNotActive = errors.New("sprint is not active")
+
+// ErrActiveSprintExists is returned when the project already has a running sprint
+var ErrActiveSprintExists = errors.New("project already has an active sprint")
+
+// ErrNoNextSprint is returned when unfinished tasks should move to a next sprint but there is none
+var ErrNoNextSprint = errors.New("there is no planned sprint to move unfinished tasks to")
+
+// ErrSprintNotFound is returned when a sprint is not part of the project
+var ErrSprintNotFound = errors.New("sprint not found in this project")
+
+type SprintService struct {
+	db              *gorm.DB
+	snapshotService *SnapshotService
+}
+
+func NewSprintService() *SprintService {
+	return &SprintService{
+		db:              database.GetDB(),
+		snapshotService: NewSnapshotService(),
+	}
+}
+
+// ListSprints returns a project's sprints in chronological order
+func (s *SprintService) ListSprints(projectID uint) ([]models.Sprint, error) {
+	var sprints []models.Sprint
+	err := s.db.Where("project_id = ?", projectID).
+		Order("start_date ASC, id ASC").
+		Find(&sprints).Error
+	return sprints, err
+}
+
+// LoadSprint loads a project's sprint
+func (s *SprintService) LoadSprint(tx *gorm.DB, projectID, sprintID uint) (*models.Sprint, error) {
+	var sprint models.Sprint
+	err := tx.Where("id = ? AND project_id = ?", sprintID, projectID).First(&sprint).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrSprintNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &sprint, nil
+}
+
+// lockSprint loads a project's sprint and locks it for the rest of the transaction
+func (s *SprintService) lockSprint(tx *gorm.DB, projectID, sprintID uint) (*models.Sprint, error) {
+	return s.LoadSprint(tx.Clauses(clause.Locking{Strength: "UPDATE"}), projectID, sprintID)
+}
+
+// Stats summarizes the (not archived) tasks of the given sprints; tasks in a done column count as completed
+func (s *SprintService) Stats(sprintIDs []uint) (map[uint]*models.SprintStats, error) {
+	stats := make(map[uint]*models.SprintStats, len(sprintIDs))
+	for _, id := range sprintIDs {
+		stats[id] = &models.SprintStats{}
+	}
+	if len(sprintIDs) == 0 {
+		return stats, nil
+	}
+
+	var rows []struct {
+		SprintID       uint
+		TaskCount      int
+		CompletedCount int
+		EstimatedHours float64
+		CompletedHours float64
+	}
+	err := s.db.Model(&models.Task{}).
+		Select("tasks.sprint_id, COUNT(*) AS task_count, "+
+			"COUNT(*) FILTER (WHERE kanban_columns.category = 'done') AS completed_count, "+
+			"COALESCE(SUM(tasks.estimated_hours), 0) AS estimated_hours, "+
+			"COALESCE(SUM(tasks.estimated_hours) FILTER (WHERE kanban_columns.category = 'done'), 0) AS completed_hours").
+		Joins("JOIN kanban_columns ON kanban_columns.id = tasks.column_id").
+		Where("tasks.sprint_id IN ? AND tasks.archived_at IS NULL", sprintIDs).
+		Group("tasks.sprint_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		stats[row.SprintID] = &models.SprintStats{
+			TaskCount:      row.TaskCount,
+			CompletedCount: row.CompletedCount,
+			EstimatedHours: row.EstimatedHours,
+			CompletedHours: row.CompletedHours,
+		}
+	}
+	return stats, nil
+}
+
+// CreateSprint stores a new planned sprint
+func (s *SprintService) CreateSprint(sprint *models.Sprint) error {
+	sprint.State = models.SprintPlanned
+	return s.db.Create(sprint).Error
+}
+
+// UpdateSprint changes a sprint's name, goal or dates; closed sprints are read-only
+func (s *SprintService) UpdateSprint(projectID, sprintID uint, updates map[string]interface{}) (*models.Sprint, error) {
+	var sprint *models.Sprint
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		sprint, err = s.lockSprint(tx, projectID, sprintID)
+		if err != nil {
+			return err
+		}
+		if sprint.IsClosed() {
+			return ErrSprintClosed
+		}
+		return tx.Model(sprint).Updates(updates).Error
+	})
+	return sprint, err
+}
+
+// DeleteSprint deletes a planned sprint; its tasks go back to the backlog
+func (s *SprintService) DeleteSprint(projectID, sprintID uint) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		sprint, err := s.lockSprint(tx, projectID, sprintID)
+		if err != nil {
+			return err
+		}
+		if sprint.State != models.SprintPlanned {
+			return ErrSprintNotPlanned
+		}
+		return tx.Delete(&models.Sprint{}, sprint.ID).Error
+	})
+}
+
+// StartSprint activates a planned sprint; a project can only have one active sprint
+func (s *SprintService) StartSprint(projectID, sprintID uint) (*models.Sprint, error) {
+	var sprint *models.Sprint
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		sprint, err = s.lockSprint(tx, projectID, sprintID)
+		if err != nil {
+			return err
+		}
+		if sprint.State != models.SprintPlanned {
+			return ErrSprintNotPlanned
+		}
+
+		var activeCount int64
+		err = tx.Model(&models.Sprint{}).
+			Where("project_id = ? AND state = ?", projectID, models.SprintActive).
+			Count(&activeCount).Error
+		if err != nil {
+			return err
+		}
+		if activeCount > 0 {
+			return ErrActiveSprintExists
+		}
+
+		now := time.Now()
+		err = tx.Model(sprint).Updates(map[string]interface{}{
+			"state":      models.SprintActive,
+			"started_at": now,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		// A burndown kezdőpontja az indításkori scope
+		return s.snapshotService.RecordSprintSnapshot(tx, sprint.ID)
+	})
+	return sprint, err
+}
+
+// RecordScopeChange stores that a task was added to or removed from an active sprint
+func (s *SprintService) RecordScopeChange(tx *gorm.DB, sprintID uint, task *models.Task, changeType string, userID uint) error {
+	change := models.SprintScopeChange{
+		SprintID:       sprintID,
+		TaskID:         task.ID,
+		ChangeType:     changeType,
+		EstimatedHours: task.EstimatedHours,
+		ChangedBy:      &userID,
+	}
+	return tx.Create(&change).Error
+}
+
+// ValidateSprint checks that a task can be put into the sprint (same project, not closed)
+func (s *SprintService) ValidateSprint(tx *gorm.DB, projectID, sprintID uint) (*models.Sprint, error) {
+	sprint, err := s.LoadSprint(tx, projectID, sprintID)
+	if err != nil {
+		return nil, err
+	}
+	if sprint.IsClosed() {
+		return nil, ErrSprintClosed
+	}
+	return sprint, nil
+}
+
+// AssignTask moves a task into a sprint (0 = backlog) and records the scope change
+// for active sprints on either side
+func (s *SprintService) AssignTask(tx *gorm.DB, task *models.Task, sprintID, userID uint) error {
+	var target *models.Sprint
+	if sprintID != 0 {
+		var err error
+		if target, err = s.ValidateSprint(tx, task.ProjectID, sprintID); err != nil {
+			return err
+		}
+	}
+
+	// Az aktuális sprint újraolvasása, hogy a scope változás a valós állapotot tükrözze
+	var current models.Task
+	if err := tx.Select("id", "sprint_id", "estimated_hours").First(&current, task.ID).Error; err != nil {
+		return err
+	}
+	if (current.SprintID == nil && target == nil) || (current.SprintID != nil && target != nil && *current.SprintID == target.ID) {
+		return nil
+	}
+
+	var newSprintID *uint
+	if target != nil {
+		newSprintID = &target.ID
+	}
+	if err := tx.Model(&models.Task{}).Where("id = ?", task.ID).UpdateColumn("sprint_id", newSprintID).Error; err != nil {
+		return err
+	}
+	task.SprintID = newSprintID
+
+	if current.SprintID != nil {
+		var previous models.Sprint
+		if err := tx.Select("id", "state").First(&previous, *current.SprintID).Error; err != nil {
+			return err
+		}
+		if previous.State == models.SprintActive {
+			if err := s.RecordScopeChange(tx, previous.ID, &current, models.ScopeRemoved, userID); err != nil {
+				return err
+			}
+		}
+	}
+	if target != nil && target.State == models.SprintActive {
+		return s.RecordScopeChange(tx, target.ID, &current, models.ScopeAdded, userID)
+	}
+	return nil
+}
+
+// ScopeChanges returns the scope changes recorded for a sprint in order
+func (s *SprintService) ScopeChanges(tx *gorm.DB, sprintID uint) ([]models.SprintScopeChange, error) {
+	var changes []models.SprintScopeChange
+	err := tx.Preload("Task").
+		Where("sprint_id = ?", sprintID).
+		Order("created_at ASC, id ASC").
+		Find(&changes).Error
+	return changes, err
+}
+
+// nextSprint returns the target sprint for unfinished tasks: the given one or the
+// earliest planned sprint of the project
+func (s *SprintService) nextSprint(tx *gorm.DB, closing *models.Sprint, targetID *uint) (*models.Sprint, error) {
+	if targetID != nil {
+		target, err := s.ValidateSprint(tx, closing.ProjectID, *targetID)
+		if err != nil {
+			return nil, err
+		}
+		if target.ID == closing.ID {
+			return nil, ErrSprintClosed
+		}
+		return target, nil
+	}
+
+	var sprints []models.Sprint
+	err := tx.Where("project_id = ? AND state = ? AND id <> ?", closing.ProjectID, models.SprintPlanned, closing.ID).
+		Order("start_date ASC, id ASC").
+		Limit(1).
+		Find(&sprints).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(sprints) == 0 {
+		return nil, ErrNoNextSprint
+	}
+	return &sprints[0], nil
+}
+
+// CloseSprint closes the active sprint. Unfinished (not done, not archived) tasks move to the
+// next sprint (moveTo=next) or the backlog; the report lists them with the sprint's scope changes.
+func (s *SprintService) CloseSprint(projectID, sprintID, userID uint, moveTo string, targetID *uint) (*models.SprintCloseReport, error) {
+	report := &models.SprintCloseReport{
+		CompletedTaskIDs: []uint{},
+		MovedTaskIDs:     []uint{},
+		MovedTo:          "backlog",
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		sprint, err := s.lockSprint(tx, projectID, sprintID)
+		if err != nil {
+			return err
+		}
+		if sprint.State != models.SprintActive {
+			return ErrSprintNotActive
+		}
+
+		var target *models.Sprint
+		if moveTo == "next" {
+			if target, err = s.nextSprint(tx, sprint, targetID); err != nil {
+				return err
+			}
+			report.MovedTo = "next"
+			report.TargetSprintID = &target.ID
+		}
+
+		// A burndown utolsó pontja a lezárás előtti állapot, mielőtt a befejezetlen taskok kikerülnek
+		if err := s.snapshotService.RecordSprintSnapshot(tx, sprint.ID); err != nil {
+			return err
+		}
+
+		var tasks []models.Task
+		err = tx.Preload("Column").
+			Where("sprint_id = ? AND archived_at IS NULL", sprint.ID).
+			Order("id ASC").
+			Find(&tasks).Error
+		if err != nil {
+			return err
+		}
+		for i := range tasks {
+			if tasks[i].IsResolved() {
+				report.CompletedTaskIDs = append(report.CompletedTaskIDs, tasks[i].ID)
+			} else {
+				report.MovedTaskIDs = append(report.MovedTaskIDs, tasks[i].ID)
+			}
+		}
+
+		if len(report.MovedTaskIDs) > 0 {
+			var newSprintID *uint
+			if target != nil {
+				newSprintID = &target.ID
+			}
+			err := tx.Model(&models.Task{}).
+				Where("id IN ?", report.MovedTaskIDs).
+				UpdateColumn("sprint_id", newSprintID).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		now := time.Now()
+		err = tx.Model(sprint).Updates(map[string]interface{}{
+			"state":            models.SprintClosed,
+			"closed_at":        now,
+			"closed_by":        userID,
+			"completed_tasks":  len(report.CompletedTaskIDs),
+			"incomplete_tasks": len(report.MovedTaskIDs),
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		changes, err := s.ScopeChanges(tx, sprint.ID)
+		if err != nil {
+			return err
+		}
+		report.ScopeChanges = make([]models.SprintScopeChangeResponse, 0, len(changes))
+		for i := range changes {
+			report.ScopeChanges = append(report.ScopeChanges, changes[i].ToResponse())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
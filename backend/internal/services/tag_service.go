@@ -0,0 +1,218 @@
+package services
+
+import (
+	"dev-bridge-manager/internal/database"
+	"dev-bridge-manager/internal/models"
+	"errors"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrTagExists is returned when a project already has a tag with the same name
+var ErrTagExists = errors.New("a tag with this name already exists in the project")
+
+// ErrTagMergeSelf is returned when a tag would be merged into itself
+var ErrTagMergeSelf = errors.New("a tag cannot be merged into itself")
+
+type TagService struct {
+	db *gorm.DB
+}
+
+func NewTagService() *TagService {
+	return &TagService{
+		db: database.GetDB(),
+	}
+}
+
+// NormalizeTagNames trims the names and drops empty and case-insensitive duplicates
+func NormalizeTagNames(names []string) []string {
+	seen := make(map[string]bool, len(names))
+	result := make([]string, 0, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		key := strings.ToLower(name)
+		if name == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, name)
+	}
+	return result
+}
+
+// ResolveTags returns the project's tags with the given names, creating the missing ones
+func (s *TagService) ResolveTags(tx *gorm.DB, projectID uint, names []string) ([]models.Tag, error) {
+	names = NormalizeTagNames(names)
+	if len(names) == 0 {
+		return []models.Tag{}, nil
+	}
+
+	newTags := make([]models.Tag, 0, len(names))
+	lowered := make([]string, 0, len(names))
+	for _, name := range names {
+		newTags = append(newTags, models.Tag{ProjectID: projectID, Name: name, Color: models.DefaultTagColor})
+		lowered = append(lowered, strings.ToLower(name))
+	}
+
+	// Párhuzamos létrehozásnál a unique index dönt, a meglévő tag marad
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&newTags).Error; err != nil {
+		return nil, err
+	}
+
+	var tags []models.Tag
+	err := tx.Where("project_id = ? AND LOWER(name) IN ?", projectID, lowered).
+		Order("LOWER(name) ASC").
+		Find(&tags).Error
+	return tags, err
+}
+
+// SetTaskTagNames replaces a task's tags with the named project tags
+func (s *TagService) SetTaskTagNames(tx *gorm.DB, task *models.Task, names []string) error {
+	tags, err := s.ResolveTags(tx, task.ProjectID, names)
+	if err != nil {
+		return err
+	}
+	if err := tx.Exec("DELETE FROM task_tags WHERE task_id = ?", task.ID).Error; err != nil {
+		return err
+	}
+	for _, tag := range tags {
+		err := tx.Exec("INSERT INTO task_tags (task_id, tag_id) VALUES (?, ?)", task.ID, tag.ID).Error
+		if err != nil {
+			return err
+		}
+	}
+	// A task módosult, az updated_at jelezze
+	return tx.Model(&models.Task{}).Where("id = ?", task.ID).UpdateColumn("updated_at", gorm.Expr("NOW()")).Error
+}
+
+// ListTags returns a project's tags with the number of tasks using each one
+func (s *TagService) ListTags(projectID uint) ([]models.TagUsage, error) {
+	tags := []models.TagUsage{}
+	err := s.db.Model(&models.Tag{}).
+		Select("tags.*, COUNT(task_tags.task_id) AS task_count").
+		Joins("LEFT JOIN task_tags ON task_tags.tag_id = tags.id").
+		Where("tags.project_id = ?", projectID).
+		Group("tags.id").
+		Order("LOWER(tags.name) ASC").
+		Scan(&tags).Error
+	return tags, err
+}
+
+// LoadTag loads a project's tag with its usage count
+func (s *TagService) LoadTag(projectID, tagID uint) (*models.TagUsage, error) {
+	var tags []models.TagUsage
+	err := s.db.Model(&models.Tag{}).
+		Select("tags.*, COUNT(task_tags.task_id) AS task_count").
+		Joins("LEFT JOIN task_tags ON task_tags.tag_id = tags.id").
+		Where("tags.id = ? AND tags.project_id = ?", tagID, projectID).
+		Group("tags.id").
+		Scan(&tags).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(tags) == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &tags[0], nil
+}
+
+// nameTaken checks whether another tag of the project already uses the name
+func (s *TagService) nameTaken(tx *gorm.DB, projectID uint, name string, exceptID uint) (bool, error) {
+	var count int64
+	err := tx.Model(&models.Tag{}).
+		Where("project_id = ? AND LOWER(name) = LOWER(?) AND id <> ?", projectID, name, exceptID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// CreateTag creates a new project tag
+func (s *TagService) CreateTag(tag *models.Tag) error {
+	taken, err := s.nameTaken(s.db, tag.ProjectID, tag.Name, 0)
+	if err != nil {
+		return err
+	}
+	if taken {
+		return ErrTagExists
+	}
+	return s.db.Create(tag).Error
+}
+
+// UpdateTag renames or recolors a tag. Tasks reference the tag by ID, so every
+// task using it shows the new name immediately.
+func (s *TagService) UpdateTag(projectID, tagID uint, updates map[string]interface{}) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var tag models.Tag
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND project_id = ?", tagID, projectID).
+			First(&tag).Error
+		if err != nil {
+			return err
+		}
+
+		if name, ok := updates["name"].(string); ok {
+			taken, err := s.nameTaken(tx, projectID, name, tag.ID)
+			if err != nil {
+				return err
+			}
+			if taken {
+				return ErrTagExists
+			}
+		}
+
+		return tx.Model(&tag).Updates(updates).Error
+	})
+}
+
+// MergeTags moves every task of the source tag to the target tag and deletes the source
+func (s *TagService) MergeTags(projectID, sourceID, targetID uint) error {
+	if sourceID == targetID {
+		return ErrTagMergeSelf
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var tags []models.Tag
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id IN ? AND project_id = ?", []uint{sourceID, targetID}, projectID).
+			Order("id ASC").
+			Find(&tags).Error
+		if err != nil {
+			return err
+		}
+		if len(tags) != 2 {
+			return gorm.ErrRecordNotFound
+		}
+
+		// A mindkét taggel rendelkező taskoknál a meglévő sor marad
+		err = tx.Exec(`
+			INSERT INTO task_tags (task_id, tag_id)
+			SELECT task_id, ? FROM task_tags WHERE tag_id = ?
+			ON CONFLICT DO NOTHING`, targetID, sourceID).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Model(&models.Task{}).
+			Where("id IN (SELECT task_id FROM task_tags WHERE tag_id = ?)", sourceID).
+			UpdateColumn("updated_at", gorm.Expr("NOW()")).Error
+		if err != nil {
+			return err
+		}
+
+		// A forrás tag kapcsolatai ON DELETE CASCADE-del törlődnek
+		return tx.Delete(&models.Tag{}, sourceID).Error
+	})
+}
+
+// DeleteTag deletes a tag and removes it from every task
+func (s *TagService) DeleteTag(projectID, tagID uint) error {
+	result := s.db.Where("id = ? AND project_id = ?", tagID, projectID).Delete(&models.Tag{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package services
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeTagNames(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []string
+		want []string
+	}{
+		{"nil", nil, []string{}},
+		{"trims", []string{"  bug ", "ui"}, []string{"bug", "ui"}},
+		{"drops empty", []string{"", "   ", "bug"}, []string{"bug"}},
+		{"case insensitive duplicates keep the first spelling", []string{"Bug", "bug", " BUG "}, []string{"Bug"}},
+		{"keeps order", []string{"b", "a", "c", "a"}, []string{"b", "a", "c"}},
+		{"unicode case", []string{"Sürgős", "SÜRGŐS"}, []string{"Sürgős"}},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeTagNames(tt.in); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: NormalizeTagNames(%q) = %q, want %q", tt.name, tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestMergeTagsRejectsSelfMergeBeforeTransaction(t *testing.T) {
+	// Egy tag önmagába olvasztása tranzakció nélkül utasítódik el, így a nil db sosem használódik
+	if err := (&TagService{}).MergeTags(1, 3, 3); !errors.Is(err, ErrTagMergeSelf) {
+		t.Errorf("merging tag 3 into itself: err = %v, want ErrTagMergeSelf", err)
+	}
+}
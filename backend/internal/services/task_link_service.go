@@ -0,0 +1,172 @@
+package services
+
+import (
+	"dev-bridge-manager/internal/database"
+	"dev-bridge-manager/internal/models"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// ErrLinkCycle is returned when a blocking link would create a dependency cycle
+var ErrLinkCycle = errors.New("link would create a blocking cycle")
+
+// ErrLinkExists is returned when the same link already exists
+var ErrLinkExists = errors.New("tasks are already linked this way")
+
+// ErrLinkSelf is returned when a task would be linked to itself
+var ErrLinkSelf = errors.New("a task cannot be linked to itself")
+
+type TaskLinkService struct {
+	db *gorm.DB
+}
+
+func NewTaskLinkService() *TaskLinkService {
+	return &TaskLinkService{
+		db: database.GetDB(),
+	}
+}
+
+// withLinkedTasks preloads both ends of links with their columns
+func withLinkedTasks(db *gorm.DB) *gorm.DB {
+	return db.Preload("SourceTask.Column").Preload("TargetTask.Column")
+}
+
+// NewLink builds a link from the viewed task's perspective (blocks, blocked_by, relates_to).
+// blocked_by is stored as a reversed blocks link; relates_to is stored with the lower ID first.
+func NewLink(taskID, otherTaskID uint, linkType string, userID uint) models.TaskLink {
+	link := models.TaskLink{
+		SourceTaskID: taskID,
+		TargetTaskID: otherTaskID,
+		LinkType:     linkType,
+		CreatedBy:    &userID,
+	}
+	switch linkType {
+	case "blocked_by":
+		link.SourceTaskID, link.TargetTaskID = otherTaskID, taskID
+		link.LinkType = models.TaskLinkBlocks
+	case models.TaskLinkRelatesTo:
+		if link.SourceTaskID > link.TargetTaskID {
+			link.SourceTaskID, link.TargetTaskID = link.TargetTaskID, link.SourceTaskID
+		}
+	}
+	return link
+}
+
+// blocksPathExists walks the blocks links breadth-first from one task and reports whether
+// another task is reachable. blocked returns the tasks directly blocked by the given ones.
+func blocksPathExists(from, to uint, blocked func(taskIDs []uint) ([]uint, error)) (bool, error) {
+	visited := map[uint]bool{from: true}
+	frontier := []uint{from}
+	for len(frontier) > 0 {
+		next, err := blocked(frontier)
+		if err != nil {
+			return false, err
+		}
+		frontier = nil
+		for _, id := range next {
+			if id == to {
+				return true, nil
+			}
+			// A már bejárt taskok kihagyása, így a meglévő körök sem okoznak végtelen ciklust
+			if !visited[id] {
+				visited[id] = true
+				frontier = append(frontier, id)
+			}
+		}
+	}
+	return false, nil
+}
+
+// CreateLink stores a link, rejecting duplicates and blocking cycles
+func (s *TaskLinkService) CreateLink(link *models.TaskLink) error {
+	if link.SourceTaskID == link.TargetTaskID {
+		return ErrLinkSelf
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		// A link létrehozások sorba rendezése, hogy két párhuzamos kérés együtt se zárhasson kört
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext('task_links'))").Error; err != nil {
+			return err
+		}
+
+		var count int64
+		err := tx.Model(&models.TaskLink{}).
+			Where("source_task_id = ? AND target_task_id = ? AND link_type = ?",
+				link.SourceTaskID, link.TargetTaskID, link.LinkType).
+			Count(&count).Error
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return ErrLinkExists
+		}
+
+		if link.LinkType == models.TaskLinkBlocks {
+			// Kör akkor keletkezik, ha a target-ből a blocks éleken el lehet jutni a source-ig
+			cycle, err := blocksPathExists(link.TargetTaskID, link.SourceTaskID, func(taskIDs []uint) ([]uint, error) {
+				var blocked []uint
+				err := tx.Model(&models.TaskLink{}).
+					Where("source_task_id IN ? AND link_type = ?", taskIDs, models.TaskLinkBlocks).
+					Distinct().Pluck("target_task_id", &blocked).Error
+				return blocked, err
+			})
+			if err != nil {
+				return err
+			}
+			if cycle {
+				return ErrLinkCycle
+			}
+		}
+
+		return tx.Create(link).Error
+	})
+}
+
+// LoadLink loads a link with both tasks
+func (s *TaskLinkService) LoadLink(linkID uint) (*models.TaskLink, error) {
+	var link models.TaskLink
+	if err := s.db.Scopes(withLinkedTasks).First(&link, linkID).Error; err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
+
+// TaskLinks returns every link the task takes part in
+func (s *TaskLinkService) TaskLinks(taskID uint) ([]models.TaskLink, error) {
+	var links []models.TaskLink
+	err := s.db.Scopes(withLinkedTasks).
+		Where("source_task_id = ? OR target_task_id = ?", taskID, taskID).
+		Order("created_at ASC, id ASC").
+		Find(&links).Error
+	return links, err
+}
+
+// ProjectLinks returns every link with at least one end in the project
+func (s *TaskLinkService) ProjectLinks(projectID uint) ([]models.TaskLink, error) {
+	var links []models.TaskLink
+	err := s.db.Scopes(withLinkedTasks).
+		Where(`EXISTS (SELECT 1 FROM tasks WHERE tasks.project_id = ?
+			AND tasks.id IN (task_links.source_task_id, task_links.target_task_id))`, projectID).
+		Order("id ASC").
+		Find(&links).Error
+	return links, err
+}
+
+// UnresolvedBlockers returns the tasks blocking the task that are neither done nor archived
+func (s *TaskLinkService) UnresolvedBlockers(taskID uint) ([]models.Task, error) {
+	var blockers []models.Task
+	err := s.db.Preload("Column").
+		Joins("JOIN task_links ON task_links.source_task_id = tasks.id").
+		Joins("JOIN kanban_columns ON kanban_columns.id = tasks.column_id").
+		Where("task_links.target_task_id = ? AND task_links.link_type = ?", taskID, models.TaskLinkBlocks).
+		Where("tasks.archived_at IS NULL AND kanban_columns.category <> ?", "done").
+		Order("tasks.id ASC").
+		Find(&blockers).Error
+	return blockers, err
+}
+
+// DeleteLink deletes a link
+func (s *TaskLinkService) DeleteLink(linkID uint) error {
+	return s.db.Delete(&models.TaskLink{}, linkID).Error
+}
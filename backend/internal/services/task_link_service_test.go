@@ -0,0 +1,111 @@
+package services
+
+import (
+	"dev-bridge-manager/internal/models"
+	"errors"
+	"testing"
+)
+
+// blocksGraph returns a lookup over blocks edges (source -> targets) that records how often it ran
+func blocksGraph(edges map[uint][]uint, calls *int) func([]uint) ([]uint, error) {
+	return func(taskIDs []uint) ([]uint, error) {
+		*calls++
+		var blocked []uint
+		for _, id := range taskIDs {
+			blocked = append(blocked, edges[id]...)
+		}
+		return blocked, nil
+	}
+}
+
+func TestBlocksPathExists(t *testing.T) {
+	edges := map[uint][]uint{
+		1: {2},
+		2: {3, 4},
+		4: {5},
+		6: {7},
+		7: {6}, // meglévő kör, amely nem érinti a keresett taskot
+	}
+
+	tests := []struct {
+		name     string
+		from, to uint
+		want     bool
+	}{
+		{"direct", 1, 2, true},
+		{"transitive", 1, 5, true},
+		{"branch", 2, 5, true},
+		{"reverse direction", 5, 1, false},
+		{"unconnected", 3, 4, false},
+		{"leaf", 3, 1, false},
+		{"existing cycle terminates", 6, 1, false},
+		{"inside existing cycle", 6, 7, true},
+	}
+
+	for _, tt := range tests {
+		calls := 0
+		got, err := blocksPathExists(tt.from, tt.to, blocksGraph(edges, &calls))
+		if err != nil || got != tt.want {
+			t.Errorf("%s: blocksPathExists(%d, %d) = %v, %v; want %v", tt.name, tt.from, tt.to, got, err, tt.want)
+		}
+		if calls > len(edges)+1 {
+			t.Errorf("%s: %d lookups for %d tasks", tt.name, calls, len(edges))
+		}
+	}
+}
+
+func TestBlocksPathExistsDetectsCycles(t *testing.T) {
+	// 1 blocks 2, 2 blocks 3: a "3 blocks 1" link akkor zárna kört, ha 1-ből elérhető 3
+	edges := map[uint][]uint{1: {2}, 2: {3}}
+	calls := 0
+
+	if cycle, _ := blocksPathExists(1, 3, blocksGraph(edges, &calls)); !cycle {
+		t.Error("3 blocks 1 was not detected as a cycle")
+	}
+	if cycle, _ := blocksPathExists(3, 1, blocksGraph(edges, &calls)); cycle {
+		t.Error("1 blocks 3 was reported as a cycle")
+	}
+}
+
+func TestBlocksPathExistsReturnsLookupErrors(t *testing.T) {
+	lookupErr := errors.New("db down")
+	_, err := blocksPathExists(1, 2, func([]uint) ([]uint, error) { return nil, lookupErr })
+	if !errors.Is(err, lookupErr) {
+		t.Errorf("err = %v, want %v", err, lookupErr)
+	}
+}
+
+func TestNewLink(t *testing.T) {
+	tests := []struct {
+		name                string
+		taskID, otherTaskID uint
+		linkType            string
+		wantSource          uint
+		wantTarget          uint
+		wantType            string
+	}{
+		{"blocks", 1, 2, models.TaskLinkBlocks, 1, 2, models.TaskLinkBlocks},
+		{"blocked by is reversed", 1, 2, "blocked_by", 2, 1, models.TaskLinkBlocks},
+		{"relates to keeps lower id first", 5, 3, models.TaskLinkRelatesTo, 3, 5, models.TaskLinkRelatesTo},
+		{"relates to in order", 3, 5, models.TaskLinkRelatesTo, 3, 5, models.TaskLinkRelatesTo},
+	}
+
+	for _, tt := range tests {
+		link := NewLink(tt.taskID, tt.otherTaskID, tt.linkType, 9)
+		if link.SourceTaskID != tt.wantSource || link.TargetTaskID != tt.wantTarget || link.LinkType != tt.wantType {
+			t.Errorf("%s: NewLink = %d -%s-> %d, want %d -%s-> %d", tt.name,
+				link.SourceTaskID, link.LinkType, link.TargetTaskID, tt.wantSource, tt.wantType, tt.wantTarget)
+		}
+		if link.CreatedBy == nil || *link.CreatedBy != 9 {
+			t.Errorf("%s: CreatedBy = %v, want 9", tt.name, link.CreatedBy)
+		}
+	}
+}
+
+func TestCreateLinkRejectsSelfLinkBeforeLocking(t *testing.T) {
+	// Az önmagára mutató link az advisory lock megszerzése előtt elutasítódik (a db itt nil)
+	link := NewLink(4, 4, models.TaskLinkBlocks, 1)
+	if err := (&TaskLinkService{}).CreateLink(&link); !errors.Is(err, ErrLinkSelf) {
+		t.Errorf("task 4 blocking itself: err = %v, want ErrLinkSelf", err)
+	}
+}
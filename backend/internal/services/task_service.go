@@ -0,0 +1,639 @@
+package services
+
+import (
+	"context"
+	"dev-bridge-manager/internal/database"
+	"dev-bridge-manager/internal/models"
+	"dev-bridge-manager/internal/storage"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrConcurrentMove is returned when another request moved the task mid-flight
+var ErrConcurrentMove = errors.New("task was moved by another request, please retry")
+
+// ErrTaskArchived is returned when a board operation targets an archived task
+var ErrTaskArchived = errors.New("task is archived")
+
+// ErrTaskNotArchived is returned when restoring a task that is still on the board
+var ErrTaskNotArchived = errors.New("task is not archived")
+
+// ErrParentNotFound is returned when the parent task is not part of the project
+var ErrParentNotFound = errors.New("parent task not found in this project")
+
+// ErrSubtaskDepth is returned when a parent/child link would nest tasks more than two levels deep
+var ErrSubtaskDepth = errors.New("subtasks can only be nested one level below a top-level task")
+
+// OpenSubtasksError is returned when a parent would be moved to Done while children are still open
+type OpenSubtasksError struct {
+	OpenCount int64
+}
+
+func (e *OpenSubtasksError) Error() string {
+	return fmt.Sprintf("Task has %d open subtasks; set force to move it to Done anyway", e.OpenCount)
+}
+
+// WipLimitError is returned when a move would exceed a column's WIP limit
+type WipLimitError struct {
+	ColumnID    uint
+	ColumnTitle string
+	MaxTasks    int
+}
+
+func (e *WipLimitError) Error() string {
+	return fmt.Sprintf("Column %q has reached its WIP limit of %d tasks", e.ColumnTitle, e.MaxTasks)
+}
+
+type TaskService struct {
+	db              *gorm.DB
+	kanbanService   *KanbanService
+	workflowService *WorkflowService
+	storage         storage.Storage
+}
+
+func NewTaskService() *TaskService {
+	return &TaskService{
+		db:              database.GetDB(),
+		kanbanService:   NewKanbanService(),
+		workflowService: NewWorkflowService(),
+		storage:         storage.Default(),
+	}
+}
+
+// LockColumns locks the given board columns for the rest of the transaction.
+// Columns are locked in ID order so concurrent moves cannot deadlock.
+func (s *TaskService) LockColumns(tx *gorm.DB, columnIDs ...uint) ([]models.KanbanColumn, error) {
+	var columns []models.KanbanColumn
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id IN ?", columnIDs).
+		Order("id ASC").
+		Find(&columns).Error
+	return columns, err
+}
+
+// columnTasks returns the active (not archived) tasks of a column ordered by position
+func (s *TaskService) columnTasks(tx *gorm.DB, columnID uint) ([]models.Task, error) {
+	var tasks []models.Task
+	err := tx.Where("column_id = ? AND archived_at IS NULL", columnID).
+		Order("position ASC, id ASC").
+		Find(&tasks).Error
+	return tasks, err
+}
+
+// writePositions renumbers tasks 0..n-1 in slice order, touching only changed rows
+func (s *TaskService) writePositions(tx *gorm.DB, tasks []models.Task) error {
+	for i := range tasks {
+		if tasks[i].Position == i {
+			continue
+		}
+		tasks[i].Position = i
+		if err := tx.Model(&models.Task{}).Where("id = ?", tasks[i].ID).UpdateColumn("position", i).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RenumberColumn closes position gaps in a column (e.g. after a delete)
+func (s *TaskService) RenumberColumn(tx *gorm.DB, columnID uint) error {
+	if _, err := s.LockColumns(tx, columnID); err != nil {
+		return err
+	}
+	tasks, err := s.columnTasks(tx, columnID)
+	if err != nil {
+		return err
+	}
+	return s.writePositions(tx, tasks)
+}
+
+// recordTransition stores a column change in the task's status history (from nil: creation)
+func (s *TaskService) recordTransition(tx *gorm.DB, task *models.Task, from, to *models.KanbanColumn, userID uint) error {
+	entry := models.TaskStatusHistory{
+		TaskID:     task.ID,
+		ProjectID:  task.ProjectID,
+		ToColumnID: &to.ID,
+		ToCategory: to.Category,
+		ChangedBy:  &userID,
+		ChangedAt:  time.Now(),
+	}
+	if from != nil {
+		entry.FromColumnID = &from.ID
+		entry.FromCategory = &from.Category
+	}
+	return tx.Create(&entry).Error
+}
+
+// CreateTask inserts a task at the end of its column
+func (s *TaskService) CreateTask(task *models.Task) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		return s.CreateTaskTx(tx, task)
+	})
+}
+
+// CreateTaskTx inserts a task at the end of its column inside an existing transaction
+func (s *TaskService) CreateTaskTx(tx *gorm.DB, task *models.Task) error {
+	columns, err := s.LockColumns(tx, task.ColumnID)
+	if err != nil {
+		return err
+	}
+	if len(columns) == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	var maxPosition int
+	err = tx.Model(&models.Task{}).
+		Where("column_id = ? AND archived_at IS NULL", task.ColumnID).
+		Select("COALESCE(MAX(position), -1)").
+		Scan(&maxPosition).Error
+	if err != nil {
+		return err
+	}
+
+	// A task státusza az oszlop kategóriáját követi
+	task.Position = maxPosition + 1
+	task.Status = columns[0].Category
+	if err := tx.Create(task).Error; err != nil {
+		return err
+	}
+	return s.recordTransition(tx, task, nil, &columns[0], task.CreatedBy)
+}
+
+// DeleteTask deletes a task and closes the gap it leaves in its column.
+// The stored files of its attachments are removed after the commit.
+func (s *TaskService) DeleteTask(ctx context.Context, task *models.Task) error {
+	var keys []string
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if _, err := s.LockColumns(tx, task.ColumnID); err != nil {
+			return err
+		}
+		var err error
+		if keys, err = attachmentKeys(tx, "task_id = ?", task.ID); err != nil {
+			return err
+		}
+		if err := tx.Delete(&models.Task{}, task.ID).Error; err != nil {
+			return err
+		}
+		tasks, err := s.columnTasks(tx, task.ColumnID)
+		if err != nil {
+			return err
+		}
+		return s.writePositions(tx, tasks)
+	})
+	if err != nil {
+		return err
+	}
+
+	removeStoredFiles(ctx, s.storage, keys)
+	return nil
+}
+
+// MoveTask moves a task to the given column and position and renumbers the
+// siblings in both the source and target column inside a single transaction
+func (s *TaskService) MoveTask(projectID, taskID, columnID uint, position int, userID uint, force bool) (*models.Task, error) {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		return s.MoveTaskTx(tx, projectID, taskID, columnID, position, userID, force)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.loadTask(taskID)
+}
+
+// MoveTaskTx performs a move inside an existing transaction.
+// A position past the end of the target column appends the task.
+// Moving a parent with open subtasks to Done requires force.
+func (s *TaskService) MoveTaskTx(tx *gorm.DB, projectID, taskID, columnID uint, position int, userID uint, force bool) error {
+	var moved models.Task
+
+	if err := tx.Where("id = ? AND project_id = ?", taskID, projectID).First(&moved).Error; err != nil {
+		return err
+	}
+	if moved.IsArchived() {
+		return ErrTaskArchived
+	}
+
+	target, err := s.kanbanService.GetProjectColumn(tx, projectID, columnID)
+	if err != nil {
+		return err
+	}
+
+	sourceColumnID := moved.ColumnID
+	locked, err := s.LockColumns(tx, sourceColumnID, columnID)
+	if err != nil {
+		return err
+	}
+
+	// A zárolás után újraolvassuk: ha közben egy másik kérés máshova mozgatta, újra kell próbálni
+	if err := tx.First(&moved, moved.ID).Error; err != nil {
+		return err
+	}
+	if moved.ColumnID != sourceColumnID {
+		return ErrConcurrentMove
+	}
+
+	targetTasks, err := s.columnTasks(tx, columnID)
+	if err != nil {
+		return err
+	}
+
+	var source *models.KanbanColumn
+	for i := range locked {
+		if locked[i].ID == sourceColumnID {
+			source = &locked[i]
+		}
+	}
+
+	if sourceColumnID != columnID {
+		// A projekt workflow-ja korlátozhatja az oszlopváltást
+		if source != nil {
+			if err := s.workflowService.CheckTransition(tx, projectID, source, target, userID); err != nil {
+				return err
+			}
+		}
+		if err := s.checkWipLimit(tx, projectID, target, len(targetTasks)); err != nil {
+			return err
+		}
+		if !force && target.IsDone() {
+			if err := s.checkOpenSubtasks(tx, moved.ID); err != nil {
+				return err
+			}
+		}
+	}
+
+	// A mozgatott task eltávolítása a cél oszlop listájából (azonos oszlopon belüli mozgatás)
+	siblings := make([]models.Task, 0, len(targetTasks)+1)
+	for _, t := range targetTasks {
+		if t.ID != moved.ID {
+			siblings = append(siblings, t)
+		}
+	}
+
+	if position < 0 {
+		position = 0
+	}
+	if position > len(siblings) {
+		position = len(siblings)
+	}
+
+	// Az ideiglenes -1 pozíció garantálja, hogy writePositions frissíti a mozgatott taskot
+	moved.Position = -1
+	siblings = append(siblings[:position], append([]models.Task{moved}, siblings[position:]...)...)
+
+	updates := map[string]interface{}{
+		"column_id":  columnID,
+		"status":     target.Category,
+		"updated_by": userID,
+	}
+	if err := tx.Model(&models.Task{}).Where("id = ?", moved.ID).Updates(updates).Error; err != nil {
+		return err
+	}
+	if err := s.writePositions(tx, siblings); err != nil {
+		return err
+	}
+
+	if sourceColumnID != columnID {
+		sourceTasks, err := s.columnTasks(tx, sourceColumnID)
+		if err != nil {
+			return err
+		}
+		if err := s.writePositions(tx, sourceTasks); err != nil {
+			return err
+		}
+
+		// Oszlopváltás rögzítése a status history-ban
+		if err := s.recordTransition(tx, &moved, source, target, userID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkWipLimit rejects adding a task to a full column when the board enforces WIP limits
+func (s *TaskService) checkWipLimit(tx *gorm.DB, projectID uint, column *models.KanbanColumn, currentCount int) error {
+	if column.MaxTasks == nil || *column.MaxTasks <= 0 {
+		return nil
+	}
+
+	var board models.KanbanBoard
+	if err := tx.Where("project_id = ?", projectID).First(&board).Error; err != nil {
+		return err
+	}
+	if !board.Settings.EnableWipLimits {
+		return nil
+	}
+
+	if currentCount >= *column.MaxTasks {
+		return &WipLimitError{
+			ColumnID:    column.ID,
+			ColumnTitle: column.Title,
+			MaxTasks:    *column.MaxTasks,
+		}
+	}
+	return nil
+}
+
+// checkOpenSubtasks rejects moving a parent to a done column while it has open children
+func (s *TaskService) checkOpenSubtasks(tx *gorm.DB, taskID uint) error {
+	var openCount int64
+	err := tx.Model(&models.Task{}).
+		Joins("JOIN kanban_columns ON kanban_columns.id = tasks.column_id").
+		Where("tasks.parent_id = ? AND tasks.archived_at IS NULL AND kanban_columns.category <> ?", taskID, "done").
+		Count(&openCount).Error
+	if err != nil {
+		return err
+	}
+	if openCount > 0 {
+		return &OpenSubtasksError{OpenCount: openCount}
+	}
+	return nil
+}
+
+// ValidateParent checks that parentID can become the parent of taskID (0 for a new task):
+// the parent must be a top-level task of the same project and the task must not have children
+func (s *TaskService) ValidateParent(tx *gorm.DB, projectID, taskID, parentID uint) error {
+	if parentID == taskID {
+		return ErrSubtaskDepth
+	}
+
+	var parent models.Task
+	err := tx.Where("id = ? AND project_id = ?", parentID, projectID).First(&parent).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrParentNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if parent.ParentID != nil {
+		return ErrSubtaskDepth
+	}
+
+	if taskID == 0 {
+		return nil
+	}
+	var childCount int64
+	if err := tx.Model(&models.Task{}).Where("parent_id = ?", taskID).Count(&childCount).Error; err != nil {
+		return err
+	}
+	if childCount > 0 {
+		return ErrSubtaskDepth
+	}
+	return nil
+}
+
+// Progress rolls up subtask and checklist completion and the hour totals of the given
+// tasks. Subtasks in a done column count as completed; archived ones are ignored.
+func (s *TaskService) Progress(tasks []models.Task) (map[uint]*models.TaskProgress, error) {
+	progress := make(map[uint]*models.TaskProgress, len(tasks))
+	if len(tasks) == 0 {
+		return progress, nil
+	}
+
+	taskIDs := make([]uint, 0, len(tasks))
+	for i := range tasks {
+		taskIDs = append(taskIDs, tasks[i].ID)
+		progress[tasks[i].ID] = &models.TaskProgress{
+			TotalEstimatedHours: tasks[i].EstimatedHours,
+			TotalLoggedHours:    tasks[i].LoggedHours,
+		}
+	}
+
+	var subtaskRows []struct {
+		ParentID          uint
+		SubtaskCount      int
+		CompletedSubtasks int
+		EstimatedHours    float64
+		LoggedHours       float64
+	}
+	err := s.db.Model(&models.Task{}).
+		Select("tasks.parent_id, COUNT(*) AS subtask_count, "+
+			"COUNT(*) FILTER (WHERE kanban_columns.category = 'done') AS completed_subtasks, "+
+			"COALESCE(SUM(tasks.estimated_hours), 0) AS estimated_hours, "+
+			"COALESCE(SUM(tasks.logged_hours), 0) AS logged_hours").
+		Joins("JOIN kanban_columns ON kanban_columns.id = tasks.column_id").
+		Where("tasks.parent_id IN ? AND tasks.archived_at IS NULL", taskIDs).
+		Group("tasks.parent_id").
+		Scan(&subtaskRows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range subtaskRows {
+		p := progress[row.ParentID]
+		p.SubtaskCount = row.SubtaskCount
+		p.CompletedSubtasks = row.CompletedSubtasks
+		p.TotalEstimatedHours += row.EstimatedHours
+		p.TotalLoggedHours += row.LoggedHours
+	}
+
+	var checklistRows []struct {
+		TaskID             uint
+		ChecklistCount     int
+		CompletedChecklist int
+	}
+	err = s.db.Model(&models.TaskChecklistItem{}).
+		Select("task_id, COUNT(*) AS checklist_count, COUNT(*) FILTER (WHERE is_done) AS completed_checklist").
+		Where("task_id IN ?", taskIDs).
+		Group("task_id").
+		Scan(&checklistRows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range checklistRows {
+		p := progress[row.TaskID]
+		p.ChecklistCount = row.ChecklistCount
+		p.CompletedChecklist = row.CompletedChecklist
+	}
+
+	for _, p := range progress {
+		p.Percent = progressPercent(p.CompletedSubtasks+p.CompletedChecklist, p.SubtaskCount+p.ChecklistCount)
+	}
+	return progress, nil
+}
+
+// progressPercent returns done/total as a percentage rounded to one decimal, 0 when there is nothing to do
+func progressPercent(done, total int) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return math.Round(float64(done)*1000/float64(total)) / 10
+}
+
+// DuplicateTask copies a task (description, tags, estimate and parent included, but no time
+// entries, comments, checklist items or sprint) to the end of the original's column
+func (s *TaskService) DuplicateTask(projectID, taskID, userID uint) (*models.Task, error) {
+	var original models.Task
+	err := s.db.Preload("Tags").
+		Where("id = ? AND project_id = ?", taskID, projectID).
+		First(&original).Error
+	if err != nil {
+		return nil, err
+	}
+
+	title := original.Title + " (copy)"
+	if len(title) > 255 {
+		title = original.Title
+	}
+
+	duplicate := models.Task{
+		ProjectID:       original.ProjectID,
+		ColumnID:        original.ColumnID,
+		ParentID:        original.ParentID,
+		Title:           title,
+		Description:     original.Description,
+		HTMLDescription: original.HTMLDescription,
+		Priority:        original.Priority,
+		Status:          original.Status,
+		AssigneeID:      original.AssigneeID,
+		EstimatedHours:  original.EstimatedHours,
+		Tags:            original.Tags,
+		DueDate:         original.DueDate,
+		CreatedBy:       userID,
+		UpdatedBy:       userID,
+	}
+	if err := s.CreateTask(&duplicate); err != nil {
+		return nil, err
+	}
+
+	return s.loadTask(duplicate.ID)
+}
+
+// ArchiveTask removes a task from the board, remembering its column and position
+func (s *TaskService) ArchiveTask(projectID, taskID, userID uint) (*models.Task, error) {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var task models.Task
+		if err := tx.Where("id = ? AND project_id = ?", taskID, projectID).First(&task).Error; err != nil {
+			return err
+		}
+		if task.IsArchived() {
+			return ErrTaskArchived
+		}
+
+		if _, err := s.LockColumns(tx, task.ColumnID); err != nil {
+			return err
+		}
+
+		updates := map[string]interface{}{
+			"archived_at":       time.Now(),
+			"archived_by":       userID,
+			"archived_position": task.Position,
+			"updated_by":        userID,
+		}
+		if err := tx.Model(&models.Task{}).Where("id = ?", task.ID).Updates(updates).Error; err != nil {
+			return err
+		}
+
+		siblings, err := s.columnTasks(tx, task.ColumnID)
+		if err != nil {
+			return err
+		}
+		return s.writePositions(tx, siblings)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.loadTask(taskID)
+}
+
+// RestoreTask puts an archived task back into its original column and position
+func (s *TaskService) RestoreTask(projectID, taskID, userID uint) (*models.Task, error) {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var task models.Task
+		if err := tx.Where("id = ? AND project_id = ?", taskID, projectID).First(&task).Error; err != nil {
+			return err
+		}
+		if !task.IsArchived() {
+			return ErrTaskNotArchived
+		}
+
+		columns, err := s.LockColumns(tx, task.ColumnID)
+		if err != nil {
+			return err
+		}
+		if len(columns) == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		siblings, err := s.columnTasks(tx, task.ColumnID)
+		if err != nil {
+			return err
+		}
+		if err := s.checkWipLimit(tx, projectID, &columns[0], len(siblings)); err != nil {
+			return err
+		}
+
+		position := len(siblings)
+		if task.ArchivedPosition != nil && *task.ArchivedPosition < position {
+			position = max(*task.ArchivedPosition, 0)
+		}
+
+		updates := map[string]interface{}{
+			"archived_at":       nil,
+			"archived_by":       nil,
+			"archived_position": nil,
+			"updated_by":        userID,
+		}
+		if err := tx.Model(&models.Task{}).Where("id = ?", task.ID).Updates(updates).Error; err != nil {
+			return err
+		}
+
+		// Az ideiglenes -1 pozíció garantálja, hogy writePositions frissíti a visszaállított taskot
+		task.Position = -1
+		siblings = append(siblings[:position], append([]models.Task{task}, siblings[position:]...)...)
+		return s.writePositions(tx, siblings)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.loadTask(taskID)
+}
+
+// PurgeArchivedTasks permanently deletes tasks archived before the cutoff
+// together with the stored files of their attachments
+func (s *TaskService) PurgeArchivedTasks(ctx context.Context, cutoff time.Time) (int64, error) {
+	var keys []string
+	var purged int64
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		// A zárolás miatt egy közben visszaállított task nem veszíti el a fájljait
+		var ids []uint
+		if err := tx.Model(&models.Task{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("archived_at IS NOT NULL AND archived_at < ?", cutoff).
+			Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		var err error
+		if keys, err = attachmentKeys(tx, "task_id IN ?", ids); err != nil {
+			return err
+		}
+		result := tx.Where("id IN ?", ids).Delete(&models.Task{})
+		purged = result.RowsAffected
+		return result.Error
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	removeStoredFiles(ctx, s.storage, keys)
+	return purged, nil
+}
+
+// loadTask loads a task with its assignee and tags
+func (s *TaskService) loadTask(taskID uint) (*models.Task, error) {
+	var task models.Task
+	if err := s.db.Preload("Assignee").Preload("Tags").First(&task, taskID).Error; err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
@@ -0,0 +1,44 @@
+package services
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestProgressPercent(t *testing.T) {
+	tests := []struct {
+		done, total int
+		want        float64
+	}{
+		{0, 0, 0},
+		{0, 4, 0},
+		{1, 4, 25},
+		{1, 3, 33.3},
+		{2, 3, 66.7},
+		{3, 3, 100},
+	}
+
+	for _, tt := range tests {
+		if got := progressPercent(tt.done, tt.total); got != tt.want {
+			t.Errorf("progressPercent(%d, %d) = %v, want %v", tt.done, tt.total, got, tt.want)
+		}
+	}
+}
+
+func TestValidateParentRejectsOwnParentWithoutQuery(t *testing.T) {
+	// nil tx: ha a szülő betöltése megelőzné az önhivatkozás vizsgálatát, a teszt pánikolna
+	if err := (&TaskService{}).ValidateParent(nil, 1, 7, 7); !errors.Is(err, ErrSubtaskDepth) {
+		t.Errorf("task 7 as its own parent: err = %v, want ErrSubtaskDepth", err)
+	}
+}
+
+func TestOpenSubtasksErrorMessage(t *testing.T) {
+	var err error = &OpenSubtasksError{OpenCount: 2}
+	var open *OpenSubtasksError
+	if !errors.As(err, &open) || open.OpenCount != 2 {
+		t.Fatalf("errors.As(%v) failed", err)
+	}
+	if want := "Task has 2 open subtasks; set force to move it to Done anyway"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
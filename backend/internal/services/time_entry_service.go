@@ -0,0 +1,145 @@
+package services
+
+import (
+	"dev-bridge-manager/internal/database"
+	"dev-bridge-manager/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TimeEntryService struct {
+	db *gorm.DB
+}
+
+func NewTimeEntryService() *TimeEntryService {
+	return &TimeEntryService{
+		db: database.GetDB(),
+	}
+}
+
+// lockTask locks the task row so concurrent entry changes recalculate its total one at a time
+func (s *TimeEntryService) lockTask(tx *gorm.DB, taskID uint) error {
+	return tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&models.Task{}, taskID).Error
+}
+
+// RecalculateLoggedHours sets a task's logged_hours to the sum of its time entries
+func (s *TimeEntryService) RecalculateLoggedHours(tx *gorm.DB, taskID uint) error {
+	return tx.Exec(`
+		UPDATE tasks SET logged_hours = (
+			SELECT COALESCE(SUM(hours), 0) FROM time_entries WHERE task_id = ?
+		) WHERE id = ?`, taskID, taskID).Error
+}
+
+// DefaultBillable returns the project's default billable setting for new entries
+func (s *TimeEntryService) DefaultBillable(tx *gorm.DB, projectID uint) (bool, error) {
+	var boards []models.KanbanBoard
+	if err := tx.Where("project_id = ?", projectID).Limit(1).Find(&boards).Error; err != nil {
+		return false, err
+	}
+	if len(boards) == 0 {
+		return models.DefaultKanbanSettings.DefaultBillable, nil
+	}
+	return boards[0].Settings.DefaultBillable, nil
+}
+
+// CreateEntryTx creates a time entry and updates the task total inside a transaction
+func (s *TimeEntryService) CreateEntryTx(tx *gorm.DB, entry *models.TimeEntry) error {
+	if err := s.lockTask(tx, entry.TaskID); err != nil {
+		return err
+	}
+	if err := tx.Create(entry).Error; err != nil {
+		return err
+	}
+	return s.RecalculateLoggedHours(tx, entry.TaskID)
+}
+
+// CreateEntry creates a time entry and updates the task total
+func (s *TimeEntryService) CreateEntry(entry *models.TimeEntry) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		return s.CreateEntryTx(tx, entry)
+	})
+}
+
+// UpdateEntry updates a time entry and the task total
+func (s *TimeEntryService) UpdateEntry(entry *models.TimeEntry, updates map[string]interface{}) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := s.lockTask(tx, entry.TaskID); err != nil {
+			return err
+		}
+		if err := tx.Model(entry).Updates(updates).Error; err != nil {
+			return err
+		}
+		return s.RecalculateLoggedHours(tx, entry.TaskID)
+	})
+}
+
+// DeleteEntry deletes a time entry and updates the task total
+func (s *TimeEntryService) DeleteEntry(entry *models.TimeEntry) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := s.lockTask(tx, entry.TaskID); err != nil {
+			return err
+		}
+		if err := tx.Delete(&models.TimeEntry{}, entry.ID).Error; err != nil {
+			return err
+		}
+		return s.RecalculateLoggedHours(tx, entry.TaskID)
+	})
+}
+
+// LoadEntry loads a project's time entry with its author
+func (s *TimeEntryService) LoadEntry(projectID, entryID uint) (*models.TimeEntry, error) {
+	var entry models.TimeEntry
+	err := s.db.Preload("User").
+		Where("id = ? AND project_id = ?", entryID, projectID).
+		First(&entry).Error
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// Summarize aggregates the time entries matched by the (already filtered) query in SQL
+func (s *TimeEntryService) Summarize(query *gorm.DB) (*models.TimeSummaryResponse, error) {
+	const hoursColumns = "COALESCE(SUM(time_entries.hours), 0) AS hours, " +
+		"COALESCE(SUM(time_entries.hours) FILTER (WHERE time_entries.billable), 0) AS billable_hours"
+
+	query = query.Model(&models.TimeEntry{}).Session(&gorm.Session{})
+
+	var totals struct {
+		Hours         float64
+		BillableHours float64
+	}
+	if err := query.Select(hoursColumns).Scan(&totals).Error; err != nil {
+		return nil, err
+	}
+
+	taskBreakdown := []models.TimeSummaryTask{}
+	err := query.Select("time_entries.task_id, tasks.title AS task_title, " + hoursColumns).
+		Joins("JOIN tasks ON tasks.id = time_entries.task_id").
+		Group("time_entries.task_id, tasks.title").
+		Order("hours DESC, time_entries.task_id ASC").
+		Scan(&taskBreakdown).Error
+	if err != nil {
+		return nil, err
+	}
+
+	userBreakdown := []models.TimeSummaryUser{}
+	err = query.Select("time_entries.user_id, users.name AS user_name, " + hoursColumns).
+		Joins("JOIN users ON users.id = time_entries.user_id").
+		Group("time_entries.user_id, users.name").
+		Order("hours DESC, time_entries.user_id ASC").
+		Scan(&userBreakdown).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.TimeSummaryResponse{
+		Success:       true,
+		Message:       "Time summary retrieved successfully",
+		TotalHours:    totals.Hours,
+		BillableHours: totals.BillableHours,
+		TaskBreakdown: taskBreakdown,
+		UserBreakdown: userBreakdown,
+	}, nil
+}
@@ -0,0 +1,216 @@
+package services
+
+import (
+	"dev-bridge-manager/internal/database"
+	"dev-bridge-manager/internal/models"
+	"errors"
+	"os"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrNoActiveTimer is returned when stopping a timer that is not running
+var ErrNoActiveTimer = errors.New("no active timer for this task")
+
+// ErrTimerExceeded is returned when a timer ran past the maximum duration and
+// would be logged without the user confirming the hours
+var ErrTimerExceeded = errors.New("timer exceeded the maximum duration, stop it with explicit hours or discard it")
+
+type TimerService struct {
+	db               *gorm.DB
+	timeEntryService *TimeEntryService
+	// MaxDuration is the longest a timer may run before it is flagged (TIMER_MAX_HOURS, default 10, at most 24)
+	MaxDuration time.Duration
+}
+
+func NewTimerService() *TimerService {
+	maxHours := 10
+	if value := os.Getenv("TIMER_MAX_HOURS"); value != "" {
+		if hours, err := strconv.Atoi(value); err == nil && hours > 0 {
+			maxHours = min(hours, 24)
+		}
+	}
+
+	return &TimerService{
+		db:               database.GetDB(),
+		timeEntryService: NewTimeEntryService(),
+		MaxDuration:      time.Duration(maxHours) * time.Hour,
+	}
+}
+
+// IsExceeded reports whether the timer was flagged or has run past the maximum duration
+func (s *TimerService) IsExceeded(timer *models.Timer, now time.Time) bool {
+	return timer.FlaggedAt != nil || now.Sub(timer.StartedAt) > s.MaxDuration
+}
+
+// lockActiveTimer returns the user's running timer (or nil) and holds the user's
+// row lock until the transaction ends, so concurrent starts are serialised
+func (s *TimerService) lockActiveTimer(tx *gorm.DB, userID uint) (*models.Timer, error) {
+	var user models.User
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&user, userID).Error; err != nil {
+		return nil, err
+	}
+
+	var timers []models.Timer
+	if err := tx.Where("user_id = ?", userID).Limit(1).Find(&timers).Error; err != nil {
+		return nil, err
+	}
+	if len(timers) == 0 {
+		return nil, nil
+	}
+	return &timers[0], nil
+}
+
+// stopTimerTx deletes the timer and records its time as a time entry.
+// Returns nil when nothing was logged (discarded or under a minute).
+func (s *TimerService) stopTimerTx(tx *gorm.DB, timer *models.Timer, now time.Time, req models.TimerStopRequest) (*models.TimeEntry, error) {
+	hours := timer.ElapsedHours(now)
+	if req.Hours != nil {
+		hours = *req.Hours
+	} else if !req.Discard && s.IsExceeded(timer, now) {
+		return nil, ErrTimerExceeded
+	}
+
+	if err := tx.Delete(&models.Timer{}, timer.ID).Error; err != nil {
+		return nil, err
+	}
+	if req.Discard || hours < 0.01 {
+		return nil, nil
+	}
+
+	description := timer.Description
+	if req.Description != nil {
+		description = *req.Description
+	}
+
+	// A bejegyzés a timer indításának napjára kerül
+	date, err := time.Parse(models.TimeEntryDateLayout, timer.StartedAt.Format(models.TimeEntryDateLayout))
+	if err != nil {
+		return nil, err
+	}
+
+	billable, err := s.timeEntryService.DefaultBillable(tx, timer.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := models.TimeEntry{
+		TaskID:      timer.TaskID,
+		ProjectID:   timer.ProjectID,
+		UserID:      timer.UserID,
+		Hours:       hours,
+		Description: description,
+		Date:        date,
+		Billable:    billable,
+	}
+	if err := s.timeEntryService.CreateEntryTx(tx, &entry); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// StartTimer starts a timer on the task. A timer already running on another task
+// is stopped and returned as a time entry; starting the running task again is a no-op.
+func (s *TimerService) StartTimer(projectID, taskID, userID uint) (*models.Timer, *models.TimeEntry, error) {
+	var timerID uint
+	var stopped *models.TimeEntry
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		active, err := s.lockActiveTimer(tx, userID)
+		if err != nil {
+			return err
+		}
+
+		if active != nil {
+			if active.TaskID == taskID {
+				timerID = active.ID
+				return nil
+			}
+			if stopped, err = s.stopTimerTx(tx, active, time.Now(), models.TimerStopRequest{}); err != nil {
+				return err
+			}
+		}
+
+		timer := models.Timer{
+			UserID:    userID,
+			TaskID:    taskID,
+			ProjectID: projectID,
+			StartedAt: time.Now(),
+		}
+		if err := tx.Create(&timer).Error; err != nil {
+			return err
+		}
+		timerID = timer.ID
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	timer, err := s.loadTimer(timerID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if stopped != nil {
+		if stopped, err = s.timeEntryService.LoadEntry(stopped.ProjectID, stopped.ID); err != nil {
+			return nil, nil, err
+		}
+	}
+	return timer, stopped, nil
+}
+
+// StopTimer stops the user's timer running on the given task
+func (s *TimerService) StopTimer(projectID, taskID, userID uint, req models.TimerStopRequest) (*models.TimeEntry, error) {
+	var entry *models.TimeEntry
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		active, err := s.lockActiveTimer(tx, userID)
+		if err != nil {
+			return err
+		}
+		if active == nil || active.ProjectID != projectID || active.TaskID != taskID {
+			return ErrNoActiveTimer
+		}
+
+		entry, err = s.stopTimerTx(tx, active, time.Now(), req)
+		return err
+	})
+	if err != nil || entry == nil {
+		return nil, err
+	}
+
+	return s.timeEntryService.LoadEntry(entry.ProjectID, entry.ID)
+}
+
+// GetActiveTimer returns the user's running timer in the project, or nil
+func (s *TimerService) GetActiveTimer(projectID, userID uint) (*models.Timer, error) {
+	var timers []models.Timer
+	err := s.db.Preload("Task").
+		Where("user_id = ? AND project_id = ?", userID, projectID).
+		Limit(1).
+		Find(&timers).Error
+	if err != nil || len(timers) == 0 {
+		return nil, err
+	}
+	return &timers[0], nil
+}
+
+// FlagExceededTimers marks timers running longer than MaxDuration
+func (s *TimerService) FlagExceededTimers() (int64, error) {
+	result := s.db.Model(&models.Timer{}).
+		Where("flagged_at IS NULL AND started_at < ?", time.Now().Add(-s.MaxDuration)).
+		Update("flagged_at", time.Now())
+	return result.RowsAffected, result.Error
+}
+
+// loadTimer loads a timer with its task
+func (s *TimerService) loadTimer(timerID uint) (*models.Timer, error) {
+	var timer models.Timer
+	if err := s.db.Preload("Task").First(&timer, timerID).Error; err != nil {
+		return nil, err
+	}
+	return &timer, nil
+}
@@ -0,0 +1,201 @@
+package services
+
+import (
+	"dev-bridge-manager/internal/database"
+	"dev-bridge-manager/internal/models"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// ErrTransitionColumn is returned when a transition refers to a column of another board
+var ErrTransitionColumn = errors.New("transition columns must belong to the project board")
+
+// ErrTransitionSelf is returned when a transition would lead from a column to itself
+var ErrTransitionSelf = errors.New("a transition must lead to a different column")
+
+// TransitionError is returned when the project workflow does not allow a move.
+// Allowed lists the columns the user may move the task to from its current column.
+type TransitionError struct {
+	From         models.KanbanColumn
+	To           models.KanbanColumn
+	RequiredRole string
+	Allowed      []models.WorkflowColumn
+}
+
+func (e *TransitionError) Error() string {
+	if e.RequiredRole != "" {
+		return fmt.Sprintf("Moving from %q to %q requires the %s project role", e.From.Title, e.To.Title, e.RequiredRole)
+	}
+	return fmt.Sprintf("Moving from %q to %q is not allowed by the project workflow", e.From.Title, e.To.Title)
+}
+
+type WorkflowService struct {
+	db                *gorm.DB
+	permissionService *PermissionService
+}
+
+func NewWorkflowService() *WorkflowService {
+	return &WorkflowService{
+		db:                database.GetDB(),
+		permissionService: NewPermissionService(),
+	}
+}
+
+// ListTransitions returns the board's transitions
+func (s *WorkflowService) ListTransitions(boardID uint) ([]models.WorkflowTransition, error) {
+	var transitions []models.WorkflowTransition
+	err := s.db.Where("board_id = ?", boardID).
+		Order("from_column_id ASC, to_column_id ASC").
+		Find(&transitions).Error
+	return transitions, err
+}
+
+// UpdateWorkflow switches enforcement and, when transitions is not nil, replaces the whole
+// transition list of the board. Repeated transitions are stored once.
+func (s *WorkflowService) UpdateWorkflow(board *models.KanbanBoard, enforce *bool, transitions []models.WorkflowTransitionInput) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if enforce != nil {
+			if err := tx.Model(board).Update("enforce_workflow", *enforce).Error; err != nil {
+				return err
+			}
+		}
+		if transitions == nil {
+			return nil
+		}
+
+		var columnIDs []uint
+		if err := tx.Model(&models.KanbanColumn{}).Where("board_id = ?", board.ID).Pluck("id", &columnIDs).Error; err != nil {
+			return err
+		}
+		onBoard := make(map[uint]bool, len(columnIDs))
+		for _, id := range columnIDs {
+			onBoard[id] = true
+		}
+
+		rows, err := buildTransitions(board.ID, onBoard, transitions)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Where("board_id = ?", board.ID).Delete(&models.WorkflowTransition{}).Error; err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		return tx.Create(&rows).Error
+	})
+}
+
+// buildTransitions validates the transition inputs against the board's columns and drops repeats.
+// The member role is the minimum for moving tasks anyway, so it is stored as no requirement.
+func buildTransitions(boardID uint, onBoard map[uint]bool, transitions []models.WorkflowTransitionInput) ([]models.WorkflowTransition, error) {
+	rows := make([]models.WorkflowTransition, 0, len(transitions))
+	seen := make(map[[2]uint]bool, len(transitions))
+	for _, input := range transitions {
+		if !onBoard[input.FromColumnID] || !onBoard[input.ToColumnID] {
+			return nil, ErrTransitionColumn
+		}
+		if input.FromColumnID == input.ToColumnID {
+			return nil, ErrTransitionSelf
+		}
+		key := [2]uint{input.FromColumnID, input.ToColumnID}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		row := models.WorkflowTransition{
+			BoardID:      boardID,
+			FromColumnID: input.FromColumnID,
+			ToColumnID:   input.ToColumnID,
+		}
+		if input.RequiredRole != "" && input.RequiredRole != "member" {
+			role := input.RequiredRole
+			row.RequiredRole = &role
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// matchTransition reports whether the rules leading out of a column allow a move to toID.
+// When they do not, it returns the columns the user may move to instead and, if a rule to
+// toID exists but needs a role the user lacks, that role.
+func matchTransition(rules []models.WorkflowTransition, toID uint, hasRole func(role string) (bool, error)) (bool, []uint, string, error) {
+	var allowedIDs []uint
+	requiredRole := ""
+	for _, rule := range rules {
+		ok := true
+		if rule.RequiredRole != nil {
+			var err error
+			if ok, err = hasRole(*rule.RequiredRole); err != nil {
+				return false, nil, "", err
+			}
+		}
+
+		if ok {
+			if rule.ToColumnID == toID {
+				return true, nil, "", nil
+			}
+			allowedIDs = append(allowedIDs, rule.ToColumnID)
+		} else if rule.ToColumnID == toID {
+			requiredRole = *rule.RequiredRole
+		}
+	}
+	return false, allowedIDs, requiredRole, nil
+}
+
+// CheckTransition enforces the project workflow on a move between two columns of the board.
+// Without enforcement every move is allowed; with it only the listed transitions are, and
+// transitions with a required role only for users having at least that project role.
+func (s *WorkflowService) CheckTransition(tx *gorm.DB, projectID uint, from, to *models.KanbanColumn, userID uint) error {
+	var board models.KanbanBoard
+	if err := tx.First(&board, from.BoardID).Error; err != nil {
+		return err
+	}
+	if !board.Settings.EnforceWorkflow {
+		return nil
+	}
+
+	var rules []models.WorkflowTransition
+	if err := tx.Where("from_column_id = ?", from.ID).Find(&rules).Error; err != nil {
+		return err
+	}
+
+	// Szerepkörönként egyszer ellenőrizzük a jogosultságot
+	roleOK := make(map[string]bool)
+	allowed, allowedIDs, requiredRole, err := matchTransition(rules, to.ID, func(role string) (bool, error) {
+		ok, seen := roleOK[role]
+		if !seen {
+			var err error
+			if ok, err = s.permissionService.HasProjectRole(userID, projectID, role); err != nil {
+				return false, err
+			}
+			roleOK[role] = ok
+		}
+		return ok, nil
+	})
+	if err != nil || allowed {
+		return err
+	}
+
+	transitionErr := &TransitionError{
+		From:         *from,
+		To:           *to,
+		RequiredRole: requiredRole,
+		Allowed:      []models.WorkflowColumn{},
+	}
+	if len(allowedIDs) > 0 {
+		var columns []models.KanbanColumn
+		if err := tx.Where("id IN ?", allowedIDs).Order("position ASC").Find(&columns).Error; err != nil {
+			return err
+		}
+		for i := range columns {
+			transitionErr.Allowed = append(transitionErr.Allowed, columns[i].ToWorkflowColumn())
+		}
+	}
+	return transitionErr
+}
@@ -0,0 +1,121 @@
+package services
+
+import (
+	"dev-bridge-manager/internal/models"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBuildTransitions(t *testing.T) {
+	onBoard := map[uint]bool{1: true, 2: true, 3: true}
+
+	rows, err := buildTransitions(7, onBoard, []models.WorkflowTransitionInput{
+		{FromColumnID: 1, ToColumnID: 2},
+		{FromColumnID: 2, ToColumnID: 3, RequiredRole: "reviewer"},
+		{FromColumnID: 1, ToColumnID: 2, RequiredRole: "manager"},
+		{FromColumnID: 3, ToColumnID: 1, RequiredRole: "member"},
+	})
+	if err != nil {
+		t.Fatalf("buildTransitions: %v", err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("got %d rows, want 3 (repeats dropped): %+v", len(rows), rows)
+	}
+	for _, row := range rows {
+		if row.BoardID != 7 {
+			t.Errorf("row %d->%d has board %d", row.FromColumnID, row.ToColumnID, row.BoardID)
+		}
+	}
+	if rows[0].RequiredRole != nil {
+		t.Errorf("first spelling of a repeated transition should win, got role %q", *rows[0].RequiredRole)
+	}
+	if rows[1].RequiredRole == nil || *rows[1].RequiredRole != "reviewer" {
+		t.Errorf("reviewer requirement lost: %+v", rows[1])
+	}
+	if rows[2].RequiredRole != nil {
+		t.Errorf("member requirement should be stored as none, got %q", *rows[2].RequiredRole)
+	}
+}
+
+func TestBuildTransitionsRejectsInvalidColumns(t *testing.T) {
+	onBoard := map[uint]bool{1: true, 2: true}
+
+	tests := []struct {
+		name  string
+		input models.WorkflowTransitionInput
+		want  error
+	}{
+		{"source on another board", models.WorkflowTransitionInput{FromColumnID: 9, ToColumnID: 1}, ErrTransitionColumn},
+		{"target on another board", models.WorkflowTransitionInput{FromColumnID: 1, ToColumnID: 9}, ErrTransitionColumn},
+		{"same column", models.WorkflowTransitionInput{FromColumnID: 2, ToColumnID: 2}, ErrTransitionSelf},
+	}
+
+	for _, tt := range tests {
+		inputs := []models.WorkflowTransitionInput{{FromColumnID: 1, ToColumnID: 2}, tt.input}
+		if _, err := buildTransitions(1, onBoard, inputs); !errors.Is(err, tt.want) {
+			t.Errorf("%s: err = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestMatchTransition(t *testing.T) {
+	role := func(r string) *string { return &r }
+	// Todo (1) -> In Progress (2) bárkinek, -> Review (3) bárkinek, -> Done (4) csak reviewernek
+	rules := []models.WorkflowTransition{
+		{FromColumnID: 1, ToColumnID: 2},
+		{FromColumnID: 1, ToColumnID: 4, RequiredRole: role("reviewer")},
+		{FromColumnID: 1, ToColumnID: 3},
+	}
+	reviewer := func(string) (bool, error) { return true, nil }
+	member := func(string) (bool, error) { return false, nil }
+
+	tests := []struct {
+		name         string
+		toID         uint
+		hasRole      func(string) (bool, error)
+		wantOK       bool
+		wantAllowed  []uint
+		wantRequired string
+	}{
+		{"listed transition", 2, member, true, nil, ""},
+		{"role transition with the role", 4, reviewer, true, nil, ""},
+		{"role transition without the role", 4, member, false, []uint{2, 3}, "reviewer"},
+		{"unlisted transition", 5, member, false, []uint{2, 3}, ""},
+		{"unlisted transition for a reviewer", 5, reviewer, false, []uint{2, 4, 3}, ""},
+	}
+
+	for _, tt := range tests {
+		ok, allowed, required, err := matchTransition(rules, tt.toID, tt.hasRole)
+		if err != nil || ok != tt.wantOK || !reflect.DeepEqual(allowed, tt.wantAllowed) || required != tt.wantRequired {
+			t.Errorf("%s: matchTransition = %v, %v, %q, %v; want %v, %v, %q",
+				tt.name, ok, allowed, required, err, tt.wantOK, tt.wantAllowed, tt.wantRequired)
+		}
+	}
+
+	// Szabályok nélkül bekapcsolt workflow mellett semmilyen mozgatás nem engedélyezett
+	if ok, allowed, _, _ := matchTransition(nil, 2, reviewer); ok || len(allowed) != 0 {
+		t.Errorf("no rules allowed the move: %v %v", ok, allowed)
+	}
+
+	roleErr := errors.New("db down")
+	if _, _, _, err := matchTransition(rules, 4, func(string) (bool, error) { return false, roleErr }); !errors.Is(err, roleErr) {
+		t.Errorf("role check error = %v, want %v", err, roleErr)
+	}
+}
+
+func TestTransitionErrorMessage(t *testing.T) {
+	err := &TransitionError{
+		From: models.KanbanColumn{Title: "Review"},
+		To:   models.KanbanColumn{Title: "Done"},
+	}
+	if !strings.Contains(err.Error(), "not allowed by the project workflow") {
+		t.Errorf("Error() = %q", err.Error())
+	}
+
+	err.RequiredRole = "reviewer"
+	if want := `Moving from "Review" to "Done" requires the reviewer project role`; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
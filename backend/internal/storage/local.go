@@ -0,0 +1,132 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LocalDownloadPath is the route serving signed local downloads, relative to PUBLIC_BASE_URL
+const LocalDownloadPath = "/api/v1/files"
+
+// LocalStorage keeps files on the local filesystem under root
+type LocalStorage struct {
+	root       string
+	baseURL    string
+	signingKey []byte
+}
+
+func NewLocalStorage(root, baseURL string, signingKey []byte) (*LocalStorage, error) {
+	if len(signingKey) == 0 {
+		return nil, errors.New("local storage requires a signing key")
+	}
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(absRoot, 0o755); err != nil {
+		return nil, fmt.Errorf("create storage directory: %w", err)
+	}
+
+	return &LocalStorage{
+		root:       absRoot,
+		baseURL:    baseURL,
+		signingKey: signingKey,
+	}, nil
+}
+
+// path resolves a key inside the root, rejecting keys that would escape it
+func (s *LocalStorage) path(key string) (string, error) {
+	full := filepath.Join(s.root, filepath.FromSlash(key))
+	if !strings.HasPrefix(full, s.root+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid storage key %q", key)
+	}
+	return full, nil
+}
+
+func (s *LocalStorage) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	full, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+		return err
+	}
+
+	// Ideiglenes fájlba írás, majd átnevezés - félbeszakadt feltöltés nem marad a helyén
+	tmp, err := os.CreateTemp(filepath.Dir(full), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, body); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), full)
+}
+
+func (s *LocalStorage) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	full, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(full)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return file, err
+}
+
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	full, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(full); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// SignedURL returns a link to the local download route signed with HMAC-SHA256
+func (s *LocalStorage) SignedURL(key, filename string, ttl time.Duration) (string, error) {
+	expires := time.Now().Add(ttl).Unix()
+	query := url.Values{}
+	query.Set("name", filename)
+	query.Set("expires", strconv.FormatInt(expires, 10))
+	query.Set("signature", s.sign(key, filename, expires))
+	return s.baseURL + "/" + key + "?" + query.Encode(), nil
+}
+
+// Verify checks a signed URL's parameters against the key
+func (s *LocalStorage) Verify(key, filename, expires, signature string) error {
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || time.Now().Unix() > expiresAt {
+		return ErrInvalidSignature
+	}
+	if !hmac.Equal([]byte(signature), []byte(s.sign(key, filename, expiresAt))) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func (s *LocalStorage) sign(key, filename string, expires int64) string {
+	mac := hmac.New(sha256.New, s.signingKey)
+	fmt.Fprintf(mac, "%s\n%s\n%d", key, filename, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,141 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testBaseURL = "http://localhost:8080" + LocalDownloadPath
+
+func newTestLocalStorage(t *testing.T, signingKey string) *LocalStorage {
+	t.Helper()
+	store, err := NewLocalStorage(t.TempDir(), testBaseURL, []byte(signingKey))
+	if err != nil {
+		t.Fatalf("NewLocalStorage: %v", err)
+	}
+	return store
+}
+
+func TestLocalStoragePutOpenDelete(t *testing.T) {
+	ctx := context.Background()
+	store := newTestLocalStorage(t, "secret")
+	key := "projects/1/tasks/2/file.txt"
+
+	if err := store.Put(ctx, key, strings.NewReader("first"), 5, "text/plain"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	// Az újbóli Put felülírja a meglévő objektumot
+	if err := store.Put(ctx, key, strings.NewReader("second"), 6, "text/plain"); err != nil {
+		t.Fatalf("Put replace: %v", err)
+	}
+
+	reader, err := store.Open(ctx, key)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	content, err := io.ReadAll(reader)
+	reader.Close()
+	if err != nil || string(content) != "second" {
+		t.Fatalf("content = %q, %v; want %q", content, err, "second")
+	}
+
+	if err := store.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := store.Open(ctx, key); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Open after Delete: err = %v, want ErrNotFound", err)
+	}
+	if err := store.Delete(ctx, key); err != nil {
+		t.Errorf("Delete of a missing object: %v", err)
+	}
+}
+
+func TestLocalStorageRejectsEscapingKeys(t *testing.T) {
+	ctx := context.Background()
+	store := newTestLocalStorage(t, "secret")
+
+	for _, key := range []string{"../outside.txt", "projects/../../outside.txt", "", "."} {
+		if err := store.Put(ctx, key, strings.NewReader("x"), 1, "text/plain"); err == nil {
+			t.Errorf("Put(%q) succeeded, want error", key)
+		}
+		if _, err := store.Open(ctx, key); err == nil || errors.Is(err, ErrNotFound) {
+			t.Errorf("Open(%q): err = %v, want invalid key error", key, err)
+		}
+	}
+}
+
+// signedParams splits a signed URL into the key and query parameters the download route receives
+func signedParams(t *testing.T, signed string) (string, url.Values) {
+	t.Helper()
+	if !strings.HasPrefix(signed, testBaseURL+"/") {
+		t.Fatalf("signed URL %q does not start with %q", signed, testBaseURL)
+	}
+	parsed, err := url.Parse(signed)
+	if err != nil {
+		t.Fatalf("parse signed URL: %v", err)
+	}
+	return strings.TrimPrefix(parsed.Path, LocalDownloadPath+"/"), parsed.Query()
+}
+
+func TestLocalStorageSignedURLRoundTrip(t *testing.T) {
+	store := newTestLocalStorage(t, "secret")
+	key := "projects/1/tasks/2/abc.pdf"
+
+	signed, err := store.SignedURL(key, "Report Q1.pdf", time.Minute)
+	if err != nil {
+		t.Fatalf("SignedURL: %v", err)
+	}
+	gotKey, query := signedParams(t, signed)
+	if gotKey != key || query.Get("name") != "Report Q1.pdf" {
+		t.Fatalf("signed URL carries key %q name %q", gotKey, query.Get("name"))
+	}
+
+	expires, signature := query.Get("expires"), query.Get("signature")
+	if err := store.Verify(gotKey, query.Get("name"), expires, signature); err != nil {
+		t.Fatalf("Verify of a fresh URL: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		key       string
+		filename  string
+		expires   string
+		signature string
+	}{
+		{"other key", "projects/1/tasks/2/other.pdf", "Report Q1.pdf", expires, signature},
+		{"other filename", key, "evil.html", expires, signature},
+		{"extended expiry", key, "Report Q1.pdf", "9999999999", signature},
+		{"malformed expiry", key, "Report Q1.pdf", "soon", signature},
+		{"tampered signature", key, "Report Q1.pdf", expires, strings.Repeat("0", len(signature))},
+		{"missing signature", key, "Report Q1.pdf", expires, ""},
+	}
+	for _, tt := range tests {
+		if err := store.Verify(tt.key, tt.filename, tt.expires, tt.signature); !errors.Is(err, ErrInvalidSignature) {
+			t.Errorf("%s: err = %v, want ErrInvalidSignature", tt.name, err)
+		}
+	}
+
+	// Más kulccsal aláírt URL nem érvényes
+	other := newTestLocalStorage(t, "another-secret")
+	if err := other.Verify(gotKey, query.Get("name"), expires, signature); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Verify with another signing key: err = %v, want ErrInvalidSignature", err)
+	}
+}
+
+func TestLocalStorageSignedURLExpires(t *testing.T) {
+	store := newTestLocalStorage(t, "secret")
+
+	signed, err := store.SignedURL("projects/1/tasks/2/abc.pdf", "a.pdf", -time.Second)
+	if err != nil {
+		t.Fatalf("SignedURL: %v", err)
+	}
+	key, query := signedParams(t, signed)
+	if err := store.Verify(key, query.Get("name"), query.Get("expires"), query.Get("signature")); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Verify of an expired URL: err = %v, want ErrInvalidSignature", err)
+	}
+}
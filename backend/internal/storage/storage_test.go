@@ -0,0 +1,57 @@
+package storage
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSigningKey(t *testing.T) {
+	t.Run("configured key", func(t *testing.T) {
+		t.Setenv("GO_ENV", "production")
+		t.Setenv("STORAGE_SIGNING_KEY", "storage-secret")
+		key, err := signingKey()
+		if err != nil {
+			t.Fatalf("signingKey: %v", err)
+		}
+		if string(key) != "storage-secret" {
+			t.Errorf("key = %q, want the STORAGE_SIGNING_KEY value", key)
+		}
+	})
+
+	t.Run("required outside development", func(t *testing.T) {
+		t.Setenv("GO_ENV", "production")
+		t.Setenv("STORAGE_DRIVER", "local")
+		t.Setenv("STORAGE_SIGNING_KEY", "")
+		// A JWT secret nem helyettesítheti a tárhely kulcsát
+		t.Setenv("JWT_SECRET", "jwt-secret")
+		if _, err := signingKey(); err == nil {
+			t.Error("signingKey without STORAGE_SIGNING_KEY in production succeeded")
+		}
+		if _, err := NewFromEnv(); err == nil {
+			t.Error("NewFromEnv for the local driver without a signing key succeeded")
+		}
+	})
+
+	t.Run("random key in development", func(t *testing.T) {
+		t.Setenv("GO_ENV", "")
+		t.Setenv("STORAGE_SIGNING_KEY", "")
+		t.Setenv("JWT_SECRET", "jwt-secret")
+		first, err := signingKey()
+		if err != nil {
+			t.Fatalf("signingKey: %v", err)
+		}
+		second, _ := signingKey()
+		if len(first) != 32 || bytes.Equal(first, second) {
+			t.Errorf("development keys must be random 32-byte keys, got %x and %x", first, second)
+		}
+		if string(first) == "jwt-secret" {
+			t.Error("development key fell back to JWT_SECRET")
+		}
+	})
+}
+
+func TestNewLocalStorageRequiresSigningKey(t *testing.T) {
+	if _, err := NewLocalStorage(t.TempDir(), testBaseURL, nil); err == nil {
+		t.Error("NewLocalStorage without a signing key succeeded")
+	}
+}
@@ -0,0 +1,131 @@
+package totp
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfcSecret is the SHA-1 test key of RFC 6238 ("12345678901234567890") in base32
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestCodeMatchesRFC6238(t *testing.T) {
+	// Az RFC 8 jegyű értékeinek utolsó 6 jegye
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+
+	for _, tt := range tests {
+		got, err := Code(rfcSecret, Step(time.Unix(tt.unix, 0)))
+		if err != nil || got != tt.want {
+			t.Errorf("Code at %d = %q, %v; want %q", tt.unix, got, err, tt.want)
+		}
+	}
+}
+
+func TestCodeAcceptsLowercaseSecret(t *testing.T) {
+	got, err := Code(" "+strings.ToLower(rfcSecret)+" ", Step(time.Unix(59, 0)))
+	if err != nil || got != "287082" {
+		t.Errorf("Code with a lowercase secret = %q, %v", got, err)
+	}
+	if _, err := Code("not base32!", 1); err == nil {
+		t.Error("Code accepted an invalid secret")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	current := Step(now)
+	code := func(step int64) string {
+		c, _ := Code(rfcSecret, step)
+		return c
+	}
+
+	tests := []struct {
+		name     string
+		code     string
+		wantStep int64
+		wantOK   bool
+	}{
+		{"current step", code(current), current, true},
+		{"previous step within skew", code(current - 1), current - 1, true},
+		{"next step within skew", code(current + 1), current + 1, true},
+		{"too old", code(current - 2), 0, false},
+		{"too new", code(current + 2), 0, false},
+		{"spaces are ignored", code(current)[:3] + " " + code(current)[3:], current, true},
+		{"wrong code", "000000", 0, false},
+		{"too short", code(current)[:5], 0, false},
+		{"too long", code(current) + "1", 0, false},
+		{"empty", "", 0, false},
+	}
+
+	for _, tt := range tests {
+		step, ok := Validate(rfcSecret, tt.code, now)
+		if ok != tt.wantOK || step != tt.wantStep {
+			t.Errorf("%s: Validate(%q) = %d, %v; want %d, %v", tt.name, tt.code, step, ok, tt.wantStep, tt.wantOK)
+		}
+	}
+}
+
+func TestValidateReturnsStepForReplayProtection(t *testing.T) {
+	// Ugyanaz a kód a következő lépésben is elfogadható a skew miatt, de ugyanazt a lépést adja
+	// vissza, így a tárolt utolsó lépés alapján az újrahasználat elutasítható
+	issued := time.Unix(1234567890, 0)
+	c, _ := Code(rfcSecret, Step(issued))
+
+	first, ok := Validate(rfcSecret, c, issued)
+	if !ok {
+		t.Fatal("fresh code rejected")
+	}
+	replayed, ok := Validate(rfcSecret, c, issued.Add(Period))
+	if !ok || replayed != first {
+		t.Errorf("replayed code matched step %d (ok=%v), want %d", replayed, ok, first)
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		t.Fatalf("GenerateSecret: %v", err)
+	}
+	if len(secret) != 32 {
+		t.Errorf("secret length = %d, want 32 base32 characters", len(secret))
+	}
+	if _, err := Code(secret, 1); err != nil {
+		t.Errorf("generated secret is not usable: %v", err)
+	}
+	if other, _ := GenerateSecret(); other == secret {
+		t.Error("two secrets are equal")
+	}
+}
+
+func TestProvisioningURI(t *testing.T) {
+	uri := ProvisioningURI(rfcSecret, "Dev Bridge", "jane@example.com")
+	if !strings.HasPrefix(uri, "otpauth://totp/Dev%20Bridge:jane@example.com?") {
+		t.Errorf("unexpected label in %q", uri)
+	}
+	if strings.Contains(uri, "+") {
+		t.Errorf("URI contains '+': %q", uri)
+	}
+
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		t.Fatalf("parse %q: %v", uri, err)
+	}
+	query := parsed.Query()
+	want := map[string]string{"secret": rfcSecret, "issuer": "Dev Bridge", "algorithm": "SHA1", "digits": "6", "period": "30"}
+	for key, value := range want {
+		if query.Get(key) != value {
+			t.Errorf("%s = %q, want %q", key, query.Get(key), value)
+		}
+	}
+}
@@ -1,5 +1,7 @@
 package types
 
+import "time"
+
 // Common response types used across handlers
 
 type RoleInfo struct {
@@ -9,12 +11,13 @@ type RoleInfo struct {
 }
 
 type UserResponse struct {
-	ID          uint     `json:"id"`
-	Name        string   `json:"name"`
-	Email       string   `json:"email"`
-	Position    string   `json:"position"`
-	Role        RoleInfo `json:"role"`
-	Permissions []string `json:"permissions,omitempty"`
+	ID            uint     `json:"id"`
+	Name          string   `json:"name"`
+	Email         string   `json:"email"`
+	EmailVerified bool     `json:"email_verified"`
+	Position      string   `json:"position"`
+	Role          RoleInfo `json:"role"`
+	Permissions   []string `json:"permissions,omitempty"`
 }
 
 type UserListResponse struct {
@@ -24,9 +27,31 @@ type UserListResponse struct {
 	User    *UserResponse  `json:"user,omitempty"`
 }
 
+// AuthResponse - a Token rövid életű access token, a RefreshToken-nel a /auth/refresh végponton újítható
+// MFA esetén a belépés nem ad tokeneket: MFARequired (kód bekérése) vagy MFASetupRequired (kötelező beállítás)
+// mellett az MFAToken rövid életű challenge token a /auth/mfa végpontokhoz.
 type AuthResponse struct {
-	Success bool          `json:"success"`
-	Message string        `json:"message"`
-	Token   string        `json:"token,omitempty"`
-	User    *UserResponse `json:"user,omitempty"`
+	Success          bool          `json:"success"`
+	Message          string        `json:"message"`
+	Token            string        `json:"token,omitempty"`
+	ExpiresAt        *time.Time    `json:"expires_at,omitempty"`
+	RefreshToken     string        `json:"refresh_token,omitempty"`
+	MFARequired      bool          `json:"mfa_required,omitempty"`
+	MFASetupRequired bool          `json:"mfa_setup_required,omitempty"`
+	MFAToken         string        `json:"mfa_token,omitempty"`
+	RecoveryCodes    []string      `json:"recovery_codes,omitempty"`
+	LockedUntil      *time.Time    `json:"locked_until,omitempty"`
+	User             *UserResponse `json:"user,omitempty"`
+}
+
+// MFAResponse - beállítás (titok és provisioning URI), helyreállító kódok és állapot
+type MFAResponse struct {
+	Success             bool     `json:"success"`
+	Message             string   `json:"message"`
+	Enabled             bool     `json:"enabled"`
+	Required            bool     `json:"required"`
+	Secret              string   `json:"secret,omitempty"`
+	ProvisioningURI     string   `json:"provisioning_uri,omitempty"`
+	RecoveryCodes       []string `json:"recovery_codes,omitempty"`
+	RemainingRecoveries int64    `json:"remaining_recovery_codes"`
 }
@@ -0,0 +1,28 @@
+-- 000008_create_tasks_table.up.sql
+-- Kanban feladatok (a column_id a board táblák bevezetéséig nincs kényszerrel összekötve)
+CREATE TABLE tasks (
+                       id SERIAL PRIMARY KEY,
+                       project_id INTEGER NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+                       column_id INTEGER NOT NULL,
+                       title VARCHAR(255) NOT NULL,
+                       description TEXT,
+                       html_description TEXT,
+                       priority VARCHAR(20) DEFAULT 'medium', -- 'low', 'medium', 'high', 'urgent'
+                       status VARCHAR(50) DEFAULT 'todo',     -- 'todo', 'in_progress', 'review', 'done'
+                       assignee_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+                       estimated_hours NUMERIC(10, 2) DEFAULT 0,
+                       tags TEXT[] DEFAULT '{}',
+                       position INTEGER NOT NULL DEFAULT 0,
+                       due_date TIMESTAMP,
+                       created_by INTEGER NOT NULL REFERENCES users(id),
+                       updated_by INTEGER NOT NULL REFERENCES users(id),
+                       created_at TIMESTAMP DEFAULT NOW(),
+                       updated_at TIMESTAMP DEFAULT NOW()
+);
+
+-- Indexek a board lekérdezésekhez és a szűrőkhöz
+CREATE INDEX idx_tasks_project ON tasks(project_id);
+CREATE INDEX idx_tasks_column_position ON tasks(column_id, position);
+CREATE INDEX idx_tasks_assignee ON tasks(assignee_id);
+CREATE INDEX idx_tasks_due_date ON tasks(due_date);
+CREATE INDEX idx_tasks_tags ON tasks USING GIN(tags);
@@ -0,0 +1,45 @@
+-- 000009_create_kanban_boards_table.up.sql
+-- Projektenkénti kanban board a beállításokkal
+CREATE TABLE kanban_boards (
+                               id SERIAL PRIMARY KEY,
+                               project_id INTEGER NOT NULL UNIQUE REFERENCES projects(id) ON DELETE CASCADE,
+                               enable_wip_limits BOOLEAN DEFAULT FALSE,
+                               enable_time_tracking BOOLEAN DEFAULT TRUE,
+                               enable_comments BOOLEAN DEFAULT TRUE,
+                               enable_priorities BOOLEAN DEFAULT TRUE,
+                               enable_tags BOOLEAN DEFAULT TRUE,
+                               default_estimate_unit VARCHAR(20) DEFAULT 'hours', -- 'hours', 'days', 'points'
+                               created_at TIMESTAMP DEFAULT NOW(),
+                               updated_at TIMESTAMP DEFAULT NOW()
+);
+
+-- Board oszlopok sorrenddel és opcionális WIP limittel
+CREATE TABLE kanban_columns (
+                                id SERIAL PRIMARY KEY,
+                                board_id INTEGER NOT NULL REFERENCES kanban_boards(id) ON DELETE CASCADE,
+                                title VARCHAR(100) NOT NULL,
+                                color VARCHAR(20) DEFAULT '#6B7280',
+                                position INTEGER NOT NULL DEFAULT 0,
+                                max_tasks INTEGER,
+                                created_at TIMESTAMP DEFAULT NOW(),
+                                updated_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_kanban_columns_board_position ON kanban_columns(board_id, position);
+
+-- Default board a meglévő projektekhez
+INSERT INTO kanban_boards (project_id)
+SELECT p.id FROM projects p
+WHERE NOT EXISTS (SELECT 1 FROM kanban_boards b WHERE b.project_id = p.id);
+
+INSERT INTO kanban_columns (board_id, title, color, position)
+SELECT b.id, c.title, c.color, c.position
+FROM kanban_boards b
+         CROSS JOIN (VALUES ('To Do', '#6B7280', 0),
+                            ('In Progress', '#3B82F6', 1),
+                            ('Review', '#F59E0B', 2),
+                            ('Done', '#10B981', 3)) AS c(title, color, position)
+WHERE NOT EXISTS (SELECT 1 FROM kanban_columns kc WHERE kc.board_id = b.id);
+
+-- A taskok oszlop hivatkozása mostantól kényszerrel védett
+ALTER TABLE tasks ADD CONSTRAINT fk_tasks_column FOREIGN KEY (column_id) REFERENCES kanban_columns(id);
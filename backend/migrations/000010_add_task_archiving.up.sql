@@ -0,0 +1,7 @@
+-- 000010_add_task_archiving.up.sql
+-- Archivált taskok: eltűnnek a board-ról, de kereshetők és visszaállíthatók az eredeti helyükre
+ALTER TABLE tasks ADD COLUMN archived_at TIMESTAMP;
+ALTER TABLE tasks ADD COLUMN archived_by INTEGER REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE tasks ADD COLUMN archived_position INTEGER;
+
+CREATE INDEX idx_tasks_archived_at ON tasks(archived_at);
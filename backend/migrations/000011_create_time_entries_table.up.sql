@@ -0,0 +1,21 @@
+-- 000011_create_time_entries_table.up.sql
+-- Taskokra rögzített munkaidő bejegyzések
+CREATE TABLE time_entries (
+                              id SERIAL PRIMARY KEY,
+                              task_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+                              project_id INTEGER NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+                              user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                              hours NUMERIC(10, 2) NOT NULL CHECK (hours > 0),
+                              description TEXT,
+                              date DATE NOT NULL DEFAULT CURRENT_DATE,
+                              created_at TIMESTAMP DEFAULT NOW(),
+                              updated_at TIMESTAMP DEFAULT NOW()
+);
+
+-- Indexek a task, projekt és dátum szerinti lekérdezésekhez
+CREATE INDEX idx_time_entries_task ON time_entries(task_id);
+CREATE INDEX idx_time_entries_project_date ON time_entries(project_id, date);
+CREATE INDEX idx_time_entries_user ON time_entries(user_id);
+
+-- A task logged_hours mezője a bejegyzések összegét tárolja
+ALTER TABLE tasks ADD COLUMN logged_hours NUMERIC(10, 2) DEFAULT 0;
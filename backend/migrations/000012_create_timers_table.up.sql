@@ -0,0 +1,16 @@
+-- 000012_create_timers_table.up.sql
+-- Futó időmérők - felhasználónként legfeljebb egy aktív timer
+CREATE TABLE timers (
+                        id SERIAL PRIMARY KEY,
+                        user_id INTEGER NOT NULL UNIQUE REFERENCES users(id) ON DELETE CASCADE,
+                        task_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+                        project_id INTEGER NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+                        description TEXT,
+                        started_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                        flagged_at TIMESTAMP,
+                        created_at TIMESTAMP DEFAULT NOW()
+);
+
+-- Index a maximális időtartamot túllépő timerek kereséséhez
+CREATE INDEX idx_timers_started_at ON timers(started_at);
+CREATE INDEX idx_timers_task ON timers(task_id);
@@ -0,0 +1,9 @@
+-- 000013_add_time_entry_billable.up.sql
+-- Számlázható jelölés az időbejegyzéseken
+ALTER TABLE time_entries ADD COLUMN billable BOOLEAN NOT NULL DEFAULT TRUE;
+
+-- Projektenkénti alapértelmezés az új bejegyzésekhez
+ALTER TABLE kanban_boards ADD COLUMN default_billable BOOLEAN DEFAULT TRUE;
+
+-- Index az összesítő lekérdezésekhez (projekt + felhasználó + dátum szűrés)
+CREATE INDEX idx_time_entries_project_user_date ON time_entries(project_id, user_id, date);
@@ -0,0 +1,41 @@
+-- 000014_create_task_comments_table.up.sql
+-- Task kommentek (plain text és HTML tartalommal)
+CREATE TABLE task_comments (
+                               id SERIAL PRIMARY KEY,
+                               task_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+                               project_id INTEGER NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+                               user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                               content TEXT NOT NULL,
+                               html_content TEXT,
+                               is_edited BOOLEAN DEFAULT FALSE,
+                               is_pinned BOOLEAN DEFAULT FALSE,
+                               pinned_at TIMESTAMP,
+                               pinned_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+                               created_at TIMESTAMP DEFAULT NOW(),
+                               updated_at TIMESTAMP DEFAULT NOW()
+);
+
+-- Kitűzött kommentek elöl, utána időrendben
+CREATE INDEX idx_task_comments_task_order ON task_comments(task_id, is_pinned DESC, created_at);
+
+-- Szerkesztés előtti változatok
+CREATE TABLE task_comment_revisions (
+                                        id SERIAL PRIMARY KEY,
+                                        comment_id INTEGER NOT NULL REFERENCES task_comments(id) ON DELETE CASCADE,
+                                        content TEXT NOT NULL,
+                                        html_content TEXT,
+                                        edited_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+                                        created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_task_comment_revisions_comment ON task_comment_revisions(comment_id, created_at);
+
+-- Emoji reakciók - felhasználónként emojinként egy
+CREATE TABLE task_comment_reactions (
+                                        id SERIAL PRIMARY KEY,
+                                        comment_id INTEGER NOT NULL REFERENCES task_comments(id) ON DELETE CASCADE,
+                                        user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                                        emoji VARCHAR(32) NOT NULL,
+                                        created_at TIMESTAMP DEFAULT NOW(),
+                                        UNIQUE(comment_id, user_id, emoji)
+);
@@ -0,0 +1,18 @@
+-- 000015_create_task_attachments_table.up.sql
+-- Task csatolmányok - maga a fájl a storage driver-ben (local / S3) van
+CREATE TABLE task_attachments (
+                                  id SERIAL PRIMARY KEY,
+                                  task_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+                                  project_id INTEGER NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+                                  storage_key VARCHAR(500) NOT NULL UNIQUE,
+                                  filename VARCHAR(255) NOT NULL,
+                                  original_name VARCHAR(255) NOT NULL,
+                                  mime_type VARCHAR(255) NOT NULL,
+                                  size BIGINT NOT NULL CHECK (size >= 0),
+                                  uploaded_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+                                  created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_task_attachments_task ON task_attachments(task_id);
+-- Index a projekt kvóta számításhoz
+CREATE INDEX idx_task_attachments_project ON task_attachments(project_id);
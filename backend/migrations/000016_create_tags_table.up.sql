@@ -0,0 +1,38 @@
+-- 000016_create_tags_table.up.sql
+-- Projekt szintű tagek - a tasks.tags TEXT[] oszlopot kapcsolótábla váltja fel
+CREATE TABLE tags (
+                      id SERIAL PRIMARY KEY,
+                      project_id INTEGER NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+                      name VARCHAR(50) NOT NULL,
+                      color VARCHAR(20) DEFAULT '#6B7280',
+                      created_at TIMESTAMP DEFAULT NOW(),
+                      updated_at TIMESTAMP DEFAULT NOW()
+);
+
+-- Projekten belül a tag név egyedi (kis- és nagybetű érzéketlenül)
+CREATE UNIQUE INDEX idx_tags_project_name ON tags(project_id, LOWER(name));
+
+CREATE TABLE task_tags (
+                           task_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+                           tag_id INTEGER NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+                           PRIMARY KEY (task_id, tag_id)
+);
+
+CREATE INDEX idx_task_tags_tag ON task_tags(tag_id);
+
+-- Meglévő tagek átvétele a tasks.tags tömbből
+INSERT INTO tags (project_id, name)
+SELECT DISTINCT ON (t.project_id, LOWER(TRIM(tag))) t.project_id, LEFT(TRIM(tag), 50)
+FROM tasks t
+         CROSS JOIN LATERAL UNNEST(t.tags) AS tag
+WHERE TRIM(tag) <> ''
+ORDER BY t.project_id, LOWER(TRIM(tag)), TRIM(tag);
+
+INSERT INTO task_tags (task_id, tag_id)
+SELECT DISTINCT t.id, g.id
+FROM tasks t
+         CROSS JOIN LATERAL UNNEST(t.tags) AS tag
+         JOIN tags g ON g.project_id = t.project_id AND LOWER(g.name) = LOWER(LEFT(TRIM(tag), 50));
+
+DROP INDEX IF EXISTS idx_tasks_tags;
+ALTER TABLE tasks DROP COLUMN tags;
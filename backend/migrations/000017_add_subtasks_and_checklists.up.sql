@@ -0,0 +1,20 @@
+-- 000017_add_subtasks_and_checklists.up.sql
+-- Subtaskok (legfeljebb két szint) és task checklisták
+ALTER TABLE tasks ADD COLUMN parent_id INTEGER REFERENCES tasks(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_tasks_parent ON tasks(parent_id);
+
+CREATE TABLE task_checklist_items (
+                                      id SERIAL PRIMARY KEY,
+                                      task_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+                                      title VARCHAR(255) NOT NULL,
+                                      is_done BOOLEAN NOT NULL DEFAULT FALSE,
+                                      position INTEGER NOT NULL DEFAULT 0,
+                                      done_at TIMESTAMP,
+                                      done_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+                                      created_by INTEGER NOT NULL REFERENCES users(id),
+                                      created_at TIMESTAMP DEFAULT NOW(),
+                                      updated_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_task_checklist_items_task_position ON task_checklist_items(task_id, position);
@@ -0,0 +1,9 @@
+-- 000018_add_kanban_column_category.up.sql
+-- Oszlop státusz kategória (todo, in_progress, review, done) - a "folyamatban" és "kész" oszlopok jelölése
+ALTER TABLE kanban_columns ADD COLUMN category VARCHAR(20) NOT NULL DEFAULT 'todo';
+
+-- Meglévő oszlopok besorolása: cím alapján, a board utolsó oszlopa kész
+UPDATE kanban_columns SET category = 'in_progress' WHERE LOWER(TRIM(title)) IN ('in progress', 'doing');
+UPDATE kanban_columns SET category = 'review' WHERE LOWER(TRIM(title)) IN ('review', 'in review');
+UPDATE kanban_columns SET category = 'done'
+WHERE id IN (SELECT DISTINCT ON (board_id) id FROM kanban_columns ORDER BY board_id, position DESC, id DESC);
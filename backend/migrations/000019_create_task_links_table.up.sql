@@ -0,0 +1,15 @@
+-- 000019_create_task_links_table.up.sql
+-- Task kapcsolatok: blocks (source blokkolja a target-et) és relates_to, projektek között is
+CREATE TABLE task_links (
+                            id SERIAL PRIMARY KEY,
+                            source_task_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+                            target_task_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+                            link_type VARCHAR(20) NOT NULL, -- 'blocks', 'relates_to'
+                            created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+                            created_at TIMESTAMP DEFAULT NOW(),
+                            CHECK (source_task_id <> target_task_id)
+);
+
+-- Egy kapcsolat típusonként egyszer szerepelhet
+CREATE UNIQUE INDEX idx_task_links_unique ON task_links(source_task_id, target_task_id, link_type);
+CREATE INDEX idx_task_links_target ON task_links(target_task_id);
@@ -0,0 +1,43 @@
+-- 000020_create_sprints_table.up.sql
+-- Projektenkénti sprintek és a sprint indulása utáni scope változások
+CREATE TABLE sprints (
+                         id SERIAL PRIMARY KEY,
+                         project_id INTEGER NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+                         name VARCHAR(100) NOT NULL,
+                         goal TEXT,
+                         start_date DATE NOT NULL,
+                         end_date DATE NOT NULL,
+                         state VARCHAR(20) NOT NULL DEFAULT 'planned', -- 'planned', 'active', 'closed'
+                         started_at TIMESTAMP,
+                         closed_at TIMESTAMP,
+                         closed_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+                         completed_tasks INTEGER NOT NULL DEFAULT 0,
+                         incomplete_tasks INTEGER NOT NULL DEFAULT 0,
+                         created_by INTEGER NOT NULL REFERENCES users(id),
+                         created_at TIMESTAMP DEFAULT NOW(),
+                         updated_at TIMESTAMP DEFAULT NOW(),
+                         CHECK (end_date >= start_date)
+);
+
+CREATE INDEX idx_sprints_project_start ON sprints(project_id, start_date);
+
+-- Projektenként legfeljebb egy aktív sprint
+CREATE UNIQUE INDEX idx_sprints_one_active ON sprints(project_id) WHERE state = 'active';
+
+-- A sprint nélküli taskok a backlogban vannak
+ALTER TABLE tasks ADD COLUMN sprint_id INTEGER REFERENCES sprints(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_tasks_sprint ON tasks(sprint_id);
+
+-- Aktív sprinthez adott vagy onnan kivett taskok
+CREATE TABLE sprint_scope_changes (
+                                      id SERIAL PRIMARY KEY,
+                                      sprint_id INTEGER NOT NULL REFERENCES sprints(id) ON DELETE CASCADE,
+                                      task_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+                                      change_type VARCHAR(20) NOT NULL, -- 'added', 'removed'
+                                      estimated_hours NUMERIC(10, 2) DEFAULT 0,
+                                      changed_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+                                      created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_sprint_scope_changes_sprint ON sprint_scope_changes(sprint_id, created_at);
@@ -0,0 +1,28 @@
+-- 000021_create_progress_snapshots.up.sql
+-- Napi sprint és projekt pillanatképek a burndown/burnup diagramokhoz.
+-- Naponta egy sor, a nap folyamán felülíródik, így a nap végi állapotot őrzi.
+CREATE TABLE sprint_snapshots (
+                                  id SERIAL PRIMARY KEY,
+                                  sprint_id INTEGER NOT NULL REFERENCES sprints(id) ON DELETE CASCADE,
+                                  snapshot_date DATE NOT NULL,
+                                  task_count INTEGER NOT NULL DEFAULT 0,
+                                  completed_count INTEGER NOT NULL DEFAULT 0,
+                                  estimated_hours NUMERIC(10, 2) NOT NULL DEFAULT 0,
+                                  completed_hours NUMERIC(10, 2) NOT NULL DEFAULT 0,
+                                  updated_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_sprint_snapshots_day ON sprint_snapshots(sprint_id, snapshot_date);
+
+CREATE TABLE project_snapshots (
+                                   id SERIAL PRIMARY KEY,
+                                   project_id INTEGER NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+                                   snapshot_date DATE NOT NULL,
+                                   task_count INTEGER NOT NULL DEFAULT 0,
+                                   completed_count INTEGER NOT NULL DEFAULT 0,
+                                   estimated_hours NUMERIC(10, 2) NOT NULL DEFAULT 0,
+                                   completed_hours NUMERIC(10, 2) NOT NULL DEFAULT 0,
+                                   updated_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_project_snapshots_day ON project_snapshots(project_id, snapshot_date);
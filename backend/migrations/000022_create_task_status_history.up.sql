@@ -0,0 +1,17 @@
+-- 000022_create_task_status_history.up.sql
+-- A taskok oszlopváltásai (létrehozás és mozgatás) a cycle time / lead time elemzéshez.
+-- Az oszlop kategóriája a váltás pillanatában kerül mentésre, mert később módosulhat.
+CREATE TABLE task_status_history (
+                                     id SERIAL PRIMARY KEY,
+                                     task_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+                                     project_id INTEGER NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+                                     from_column_id INTEGER REFERENCES kanban_columns(id) ON DELETE SET NULL, -- NULL: létrehozás
+                                     to_column_id INTEGER REFERENCES kanban_columns(id) ON DELETE SET NULL,
+                                     from_category VARCHAR(20),
+                                     to_category VARCHAR(20) NOT NULL,
+                                     changed_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+                                     changed_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_task_status_history_task ON task_status_history(task_id, changed_at);
+CREATE INDEX idx_task_status_history_project ON task_status_history(project_id, changed_at);
@@ -0,0 +1,22 @@
+-- 000023_create_workflow_transitions.up.sql
+-- Projektenkénti workflow: az engedélyezett oszlopváltások listája, opcionális minimális projekt szerepkörrel.
+-- Csak akkor érvényes, ha a board enforce_workflow beállítása be van kapcsolva.
+ALTER TABLE kanban_boards ADD COLUMN enforce_workflow BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE workflow_transitions (
+                                      id SERIAL PRIMARY KEY,
+                                      board_id INTEGER NOT NULL REFERENCES kanban_boards(id) ON DELETE CASCADE,
+                                      from_column_id INTEGER NOT NULL REFERENCES kanban_columns(id) ON DELETE CASCADE,
+                                      to_column_id INTEGER NOT NULL REFERENCES kanban_columns(id) ON DELETE CASCADE,
+                                      required_role VARCHAR(20), -- NULL: bármely member; 'reviewer', 'manager', 'owner'
+                                      created_at TIMESTAMP DEFAULT NOW(),
+                                      CHECK (from_column_id <> to_column_id)
+);
+
+CREATE UNIQUE INDEX idx_workflow_transitions_unique ON workflow_transitions(from_column_id, to_column_id);
+CREATE INDEX idx_workflow_transitions_board ON workflow_transitions(board_id);
+
+-- A task státusza ezentúl az oszlop kategóriáját követi
+UPDATE tasks SET status = kanban_columns.category
+FROM kanban_columns
+WHERE kanban_columns.id = tasks.column_id AND tasks.status <> kanban_columns.category;
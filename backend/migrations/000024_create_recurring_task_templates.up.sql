@@ -0,0 +1,36 @@
+-- 000024_create_recurring_task_templates.up.sql
+-- Ismétlődő task sablonok cron ütemezéssel; az ütemező a next_run_at alapján hozza létre a taskokat
+CREATE TABLE recurring_task_templates (
+                                          id SERIAL PRIMARY KEY,
+                                          project_id INTEGER NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+                                          column_id INTEGER REFERENCES kanban_columns(id) ON DELETE SET NULL, -- NULL: a board első oszlopa
+                                          title VARCHAR(255) NOT NULL,
+                                          description TEXT,
+                                          priority VARCHAR(20) DEFAULT 'medium',
+                                          assignee_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+                                          estimated_hours NUMERIC(10, 2) DEFAULT 0,
+                                          due_in_days INTEGER, -- a létrehozott task határideje ennyi nappal a futás után
+                                          schedule VARCHAR(100) NOT NULL, -- cron kifejezés, pl. '0 9 * * mon'
+                                          timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+                                          is_active BOOLEAN NOT NULL DEFAULT TRUE,
+                                          next_run_at TIMESTAMP NOT NULL,
+                                          last_run_at TIMESTAMP,
+                                          last_task_id INTEGER REFERENCES tasks(id) ON DELETE SET NULL,
+                                          created_by INTEGER NOT NULL REFERENCES users(id),
+                                          created_at TIMESTAMP DEFAULT NOW(),
+                                          updated_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_recurring_task_templates_project ON recurring_task_templates(project_id);
+CREATE INDEX idx_recurring_task_templates_due ON recurring_task_templates(next_run_at) WHERE is_active;
+
+-- Futásonként egy sor; az egyedi index kizárja, hogy ugyanarra az időpontra két task készüljön
+CREATE TABLE recurring_task_runs (
+                                     id SERIAL PRIMARY KEY,
+                                     template_id INTEGER NOT NULL REFERENCES recurring_task_templates(id) ON DELETE CASCADE,
+                                     scheduled_for TIMESTAMP NOT NULL,
+                                     task_id INTEGER REFERENCES tasks(id) ON DELETE SET NULL,
+                                     created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_recurring_task_runs_unique ON recurring_task_runs(template_id, scheduled_for);
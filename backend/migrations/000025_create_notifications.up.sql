@@ -0,0 +1,28 @@
+-- 000025_create_notifications.up.sql
+-- Felhasználói értesítések (pl. határidő emlékeztetők) és a már elküldött task emlékeztetők
+CREATE TABLE notifications (
+                               id SERIAL PRIMARY KEY,
+                               user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                               project_id INTEGER REFERENCES projects(id) ON DELETE CASCADE,
+                               task_id INTEGER REFERENCES tasks(id) ON DELETE CASCADE,
+                               type VARCHAR(50) NOT NULL, -- task_due_soon, task_overdue, task_overdue_escalation
+                               title VARCHAR(255) NOT NULL,
+                               message TEXT,
+                               read_at TIMESTAMP,
+                               created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_notifications_user ON notifications(user_id, created_at DESC);
+CREATE INDEX idx_notifications_unread ON notifications(user_id) WHERE read_at IS NULL;
+
+-- Küszöbönként és határidőnként egy sor: az egyedi index biztosítja, hogy egy emlékeztető
+-- csak egyszer menjen ki, több szerver példány esetén is. A határidő módosítása új sorozatot indít.
+CREATE TABLE task_reminders (
+                                id SERIAL PRIMARY KEY,
+                                task_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+                                due_date TIMESTAMP NOT NULL,
+                                threshold VARCHAR(30) NOT NULL, -- due_soon, overdue, escalation
+                                sent_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_task_reminders_unique ON task_reminders(task_id, due_date, threshold);
@@ -0,0 +1,28 @@
+-- 000026_create_sessions.up.sql
+-- Bejelentkezési sessionök (refresh token családok) eszköz, IP és user agent adatokkal
+CREATE TABLE sessions (
+                          id SERIAL PRIMARY KEY,
+                          user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                          device VARCHAR(255),
+                          ip_address VARCHAR(64),
+                          user_agent TEXT,
+                          expires_at TIMESTAMP NOT NULL, -- minden refresh kitolja
+                          last_used_at TIMESTAMP DEFAULT NOW(),
+                          revoked_at TIMESTAMP,
+                          revoked_reason VARCHAR(50), -- logout, reuse_detected, ...
+                          created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_sessions_user ON sessions(user_id) WHERE revoked_at IS NULL;
+
+-- A session refresh tokenjei, csak SHA-256 hash formában. Minden refresh új tokent ad ki és
+-- a régit felhasználtnak jelöli; egy már felhasznált token ismételt bemutatása a teljes sessiont visszavonja.
+CREATE TABLE session_refresh_tokens (
+                                        id SERIAL PRIMARY KEY,
+                                        session_id INTEGER NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+                                        token_hash VARCHAR(64) NOT NULL UNIQUE,
+                                        used_at TIMESTAMP, -- NULL: a session aktuális tokenje
+                                        created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_session_refresh_tokens_session ON session_refresh_tokens(session_id);
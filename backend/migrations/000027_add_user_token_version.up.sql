@@ -0,0 +1,3 @@
+-- 000027_add_user_token_version.up.sql
+-- Felhasználónkénti token verzió: növelése (jelszócsere, szerepkör módosítás) minden kiadott access tokent érvénytelenít
+ALTER TABLE users ADD COLUMN token_version INTEGER NOT NULL DEFAULT 0;
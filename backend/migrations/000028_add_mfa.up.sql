@@ -0,0 +1,21 @@
+-- 000028_add_mfa.up.sql
+-- TOTP alapú kétlépcsős azonosítás. A titok titkosítva tárolódik; a megerősítésig (mfa_enabled = FALSE)
+-- csak függőben lévő beállítás. A mfa_last_step a legutóbb elfogadott időlépés, egy kód nem használható kétszer.
+ALTER TABLE users ADD COLUMN mfa_enabled BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE users ADD COLUMN mfa_secret TEXT;
+ALTER TABLE users ADD COLUMN mfa_enabled_at TIMESTAMP;
+ALTER TABLE users ADD COLUMN mfa_last_step BIGINT;
+
+-- Szerepkör szintű kötelező MFA (pl. super_admin); az érintett felhasználók belépéskor beállítják
+ALTER TABLE roles ADD COLUMN mfa_required BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Egyszer használható helyreállító kódok SHA-256 hash formában
+CREATE TABLE user_recovery_codes (
+                                     id SERIAL PRIMARY KEY,
+                                     user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                                     code_hash VARCHAR(64) NOT NULL,
+                                     used_at TIMESTAMP,
+                                     created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_user_recovery_codes_user ON user_recovery_codes(user_id) WHERE used_at IS NULL;
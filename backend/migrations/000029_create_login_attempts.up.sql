@@ -0,0 +1,29 @@
+-- 000029_create_login_attempts.up.sql
+-- Sikertelen belépési kísérletek kulcsonként (fiók: "account:<email>", IP: "ip:<cím>"). A postgres
+-- limiter itt tárolja az állapotot, így több szerver példány is ugyanazt a számlálót látja.
+CREATE TABLE login_attempts (
+                                attempt_key VARCHAR(320) PRIMARY KEY,
+                                failures INTEGER NOT NULL DEFAULT 0, -- az utolsó zárolás óta, az ablakon belül
+                                lockouts INTEGER NOT NULL DEFAULT 0, -- egymást követő zárolások (exponenciális várakozás)
+                                last_failure_at TIMESTAMP,
+                                locked_until TIMESTAMP,
+                                updated_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_login_attempts_last_failure ON login_attempts(last_failure_at);
+
+-- Zárolási események naplója (a limiter meghajtójától függetlenül); admin feloldáskor unlocked_at kitöltve
+CREATE TABLE login_lockouts (
+                                id SERIAL PRIMARY KEY,
+                                user_id INTEGER REFERENCES users(id) ON DELETE CASCADE, -- NULL: nem létező fiók
+                                email VARCHAR(255) NOT NULL,
+                                ip_address VARCHAR(64),
+                                scope VARCHAR(20) NOT NULL, -- account, ip
+                                lockout_count INTEGER NOT NULL DEFAULT 1, -- hányadik egymást követő zárolás
+                                locked_until TIMESTAMP NOT NULL,
+                                unlocked_at TIMESTAMP,
+                                unlocked_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+                                created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_login_lockouts_user ON login_lockouts(user_id, created_at DESC);
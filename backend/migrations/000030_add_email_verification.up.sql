@@ -0,0 +1,19 @@
+-- 000030_add_email_verification.up.sql
+-- Email cím megerősítése; a meglévő fiókok megerősítettnek számítanak
+ALTER TABLE users ADD COLUMN email_verified_at TIMESTAMP;
+
+UPDATE users SET email_verified_at = COALESCE(created_at, NOW());
+
+-- Egyszer használható, lejáró tokenek (jelszó visszaállítás, email megerősítés), csak SHA-256 hash formában
+CREATE TABLE user_tokens (
+                             id SERIAL PRIMARY KEY,
+                             user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                             purpose VARCHAR(30) NOT NULL, -- password_reset, email_verification
+                             token_hash VARCHAR(64) NOT NULL UNIQUE,
+                             email VARCHAR(255) NOT NULL, -- a cím, ahová a token ment
+                             expires_at TIMESTAMP NOT NULL,
+                             used_at TIMESTAMP,
+                             created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_user_tokens_user ON user_tokens(user_id, purpose) WHERE used_at IS NULL;